@@ -34,6 +34,7 @@ import (
 	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 	"github.com/cosmos/peggy/x/oracle"
 	bridge "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
+	bridgeclient "github.com/lcnem/proximax-pegzone/x/proximax-bridge/client"
 )
 
 const appName = "pxb"
@@ -59,7 +60,11 @@ var (
 		staking.AppModuleBasic{},
 		mint.AppModuleBasic{},
 		distr.AppModuleBasic{},
-		gov.NewAppModuleBasic(paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler),
+		gov.NewAppModuleBasic(
+			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			bridgeclient.ChangeMultisigAddressProposalHandler, bridgeclient.AddCosignerProposalHandler, bridgeclient.RemoveCosignerProposalHandler,
+			bridgeclient.ChangeMultisigApprovalProposalHandler,
+		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
 		slashing.AppModuleBasic{},
@@ -80,6 +85,7 @@ var (
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
 		bridge.ModuleName:         {supply.Burner, supply.Staking},
+		bridge.FeePoolName:        nil,
 	}
 )
 
@@ -157,7 +163,7 @@ func NewInitApp(
 		gov.StoreKey, params.StoreKey, evidence.StoreKey, upgrade.StoreKey,
 		oracle.StoreKey,
 
-		bridge.StoreKey, bridge.StoreKeyForPeg, bridge.StoreKeyForUnpeg, bridge.StoreKeyForCosign, bridge.StoreKeyForInvite,
+		bridge.StoreKey, bridge.StoreKeyForPeg, bridge.StoreKeyForUnpeg, bridge.StoreKeyForCosign, bridge.StoreKeyForInvite, bridge.StoreKeyForRemoval, bridge.StoreKeyForProphecy,
 	)
 	tKeys := sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
 
@@ -261,29 +267,33 @@ func NewInitApp(
 
 	app.evidenceKeeper = *evidenceKeeper
 
+	// TODO: Add your module(s) keepers
+	// NOTE: bridge claims are finalized with the consensus thresholds in the bridge params,
+	// the threshold given here only applies to claims processed by this keeper directly
+	app.oracleKeeper = oracle.NewKeeper(app.cdc, keys[oracle.StoreKey], &stakingKeeper, oracle.DefaultConsensusNeeded)
+	app.bridgeKeeper = bridge.NewKeeper(app.cdc, keys[bridge.StoreKey], keys[bridge.StoreKeyForPeg], keys[bridge.StoreKeyForUnpeg], keys[bridge.StoreKeyForCosign], keys[bridge.StoreKeyForInvite], keys[bridge.StoreKeyForRemoval], keys[bridge.StoreKeyForProphecy], keys[oracle.StoreKey], app.subspaces[bridge.ModuleName], app.supplyKeeper, app.slashingKeeper, &stakingKeeper, bridge.NewOracleKeeper(app.oracleKeeper, keys[oracle.StoreKey]))
+
+	// register the staking hooks
+	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
+	app.stakingKeeper = *stakingKeeper.SetHooks(
+		staking.NewMultiStakingHooks(
+			app.distrKeeper.Hooks(),
+			app.slashingKeeper.Hooks(),
+			app.bridgeKeeper.Hooks()),
+	)
+
 	// register the proposal types
 	govRouter := gov.NewRouter()
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
 		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
+		AddRoute(bridge.RouterKey, bridge.NewProposalHandler(app.bridgeKeeper))
 	app.govKeeper = gov.NewKeeper(
 		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName],
 		app.supplyKeeper, &stakingKeeper, govRouter,
 	)
 
-	// register the staking hooks
-	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
-	app.stakingKeeper = *stakingKeeper.SetHooks(
-		staking.NewMultiStakingHooks(
-			app.distrKeeper.Hooks(),
-			app.slashingKeeper.Hooks()),
-	)
-
-	// TODO: Add your module(s) keepers
-	app.oracleKeeper = oracle.NewKeeper(app.cdc, keys[oracle.StoreKey], app.stakingKeeper, oracle.DefaultConsensusNeeded)
-	app.bridgeKeeper = bridge.NewKeeper(app.cdc, keys[bridge.StoreKey], keys[bridge.StoreKeyForPeg], keys[bridge.StoreKeyForUnpeg], keys[bridge.StoreKeyForCosign], keys[bridge.StoreKeyForInvite], app.subspaces[bridge.ModuleName], app.supplyKeeper, app.slashingKeeper, app.oracleKeeper)
-
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
 	app.mm = module.NewManager(
@@ -309,7 +319,7 @@ func NewInitApp(
 	// CanWithdrawInvariant invariant.
 
 	app.mm.SetOrderBeginBlockers(distr.ModuleName, slashing.ModuleName)
-	app.mm.SetOrderEndBlockers(staking.ModuleName)
+	app.mm.SetOrderEndBlockers(gov.ModuleName, staking.ModuleName, bridge.ModuleName)
 
 	// Sets the order of Genesis - Order matters, genutil is to always come last
 	// NOTE: The genutils module must occur after staking so that pools are
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+
+	bridge "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
+)
+
+// AddBridgeInstanceCmd returns add-bridge-instance cobra Command.
+func AddBridgeInstanceCmd(
+	ctx *server.Context, cdc *codec.Codec, defaultNodeHome, defaultClientHome string,
+) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "add-bridge-instance [name] [network_type] [denom] [mainchain_mosaic] [divisibility]",
+		Short: "Add a bridge instance to a ProximaX network to genesis.json",
+		Long: `Add a bridge instance to a ProximaX network to genesis.json, pegging the denom to a mosaic of that network.
+The mosaic is given by its namespace alias, such as prime.xpx, or by its id in hex.
+Its multisig vaults and their cosigners are registered with register-multisig and add-cosigner.`,
+		Args: cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			networkType, err := bridge.NewMainchainNetworkType(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid [network_type]: %w", err)
+			}
+
+			divisibility, err := strconv.ParseUint(args[4], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid [divisibility]: %w", err)
+			}
+
+			denoms := []bridge.DenomMapping{{Denom: args[2], MainchainMosaic: args[3], Divisibility: uint32(divisibility)}}
+			instance := bridge.NewBridgeInstance(args[0], networkType, []bridge.Vault{}, []bridge.Cosigner{}, denoms)
+			if err := instance.Validate(); err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			bridgeState := bridge.GetGenesisStateFromAppState(cdc, appState)
+			if _, err := findBridgeInstance(bridgeState, instance.Name); err == nil {
+				return fmt.Errorf("bridge instance has already been added: %s", instance.Name)
+			}
+			bridgeState.Instances = append(bridgeState.Instances, instance)
+			if err := bridge.ValidateGenesis(bridgeState); err != nil {
+				return err
+			}
+
+			bridgeStateBz, err := cdc.MarshalJSON(bridgeState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal auth genesis state: %w", err)
+			}
+
+			appState[bridge.ModuleName] = bridgeStateBz
+
+			appStateJSON, err := cdc.MarshalJSON(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
+			}
+
+			genDoc.AppState = appStateJSON
+			return genutil.ExportGenesisFile(genDoc, genFile)
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	cmd.Flags().String(flagClientHome, defaultClientHome, "client's home directory")
+
+	return cmd
+}
+
+// findBridgeInstance returns the index of the named bridge instance in the genesis state
+func findBridgeInstance(bridgeState bridge.GenesisState, name string) (int, error) {
+	for i, instance := range bridgeState.Instances {
+		if instance.Name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("bridge instance not found in genesis: %s", name)
+}
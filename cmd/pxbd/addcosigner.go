@@ -3,7 +3,6 @@ package main
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,22 +24,22 @@ func AddCosignerCmd(
 ) *cobra.Command {
 
 	cmd := &cobra.Command{
-		Use:   "add-cosigner [address_or_key_name] [cosigner_public_key]",
-		Short: "Add a cosigner account to genesis.json",
-		Long:  `Add a cosigner account to genesis.json.`,
-		Args:  cobra.ExactArgs(2),
+		Use:   "add-cosigner [instance] [vault] [address_or_key_name] [cosigner_public_key]",
+		Short: "Add a cosigner account of a vault of a bridge instance to genesis.json",
+		Long:  `Add a cosigner account of a vault of a bridge instance to genesis.json. The vault must have been registered with register-multisig.`,
+		Args:  cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config := ctx.Config
 			config.SetRoot(viper.GetString(cli.HomeFlag))
 
-			validator, err := sdk.ValAddressFromBech32(args[0])
+			validator, err := sdk.ValAddressFromBech32(args[2])
 			if err != nil {
 				return err
 			}
 
-			mainchainPublicKey := args[1]
-			if len(strings.Trim(mainchainPublicKey, "")) == 0 {
-				return errors.New(fmt.Sprintf("invalid [cosigner_public_key]: %s", mainchainPublicKey))
+			mainchainPublicKey, err := bridge.NewMainchainPublicKey(args[3])
+			if err != nil {
+				return fmt.Errorf("invalid [cosigner_public_key]: %w", err)
 			}
 
 			genFile := config.GenesisFile()
@@ -50,14 +49,19 @@ func AddCosignerCmd(
 			}
 
 			bridgeState := bridge.GetGenesisStateFromAppState(cdc, appState)
-			for _, cosigner := range bridgeState.Cosigners {
-				if cosigner.MainchainPublicKey == mainchainPublicKey {
-					return errors.New(fmt.Sprintf("Cosigner has already been added: %s", mainchainPublicKey))
-				}
+			i, err := findBridgeInstance(bridgeState, args[0])
+			if err != nil {
+				return err
+			}
+			if _, found := bridgeState.Instances[i].GetCosigner(mainchainPublicKey); found {
+				return errors.New(fmt.Sprintf("Cosigner has already been added: %s", mainchainPublicKey))
 			}
 
-			cosigner := bridge.Cosigner{ValidatorAddress: validator.String(), MainchainPublicKey: mainchainPublicKey}
-			bridgeState.Cosigners = append(bridgeState.Cosigners, cosigner)
+			cosigner := bridge.Cosigner{ValidatorAddress: validator.String(), MainchainPublicKey: mainchainPublicKey, Vault: args[1]}
+			bridgeState.Instances[i].Cosigners = append(bridgeState.Instances[i].Cosigners, cosigner)
+			if err := bridgeState.Instances[i].Validate(); err != nil {
+				return err
+			}
 
 			bridgeStateBz, err := cdc.MarshalJSON(bridgeState)
 			if err != nil {
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -18,23 +16,27 @@ import (
 	bridge "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
 )
 
+const flagCold = "cold"
+
 // AddGenesisAccountCmd returns add-genesis-account cobra Command.
 func RegisterMultisigAddressCmd(
 	ctx *server.Context, cdc *codec.Codec, defaultNodeHome, defaultClientHome string,
 ) *cobra.Command {
 
 	cmd := &cobra.Command{
-		Use:   "register-multisig [multisig_account_address]",
-		Short: "Register Multisig Account Address to genesis.json",
-		Long:  `Register Multisig Account Address to genesis.json.`,
-		Args:  cobra.ExactArgs(1),
+		Use:   "register-multisig [instance] [vault] [multisig_account_address]",
+		Short: "Register Multisig Account Address of a vault of a bridge instance to genesis.json",
+		Long: `Register Multisig Account Address of a vault of a bridge instance to genesis.json.
+The vault is added to the instance unless it exists already, in which case its address is replaced.
+With --cold, the vault is a cold vault holding the reserves beyond the hot vault limits.`,
+		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config := ctx.Config
 			config.SetRoot(viper.GetString(cli.HomeFlag))
 
-			multisigAddress := args[0]
-			if len(strings.Trim(multisigAddress, "")) == 0 {
-				return errors.New(fmt.Sprintf("invalid [multisig_account_address]: %s", multisigAddress))
+			multisigAddress, err := bridge.NewMainchainAddress(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid [multisig_account_address]: %w", err)
 			}
 
 			genFile := config.GenesisFile()
@@ -44,7 +46,28 @@ func RegisterMultisigAddressCmd(
 			}
 
 			bridgeState := bridge.GetGenesisStateFromAppState(cdc, appState)
-			bridgeState.MainchainMultisigAddress = multisigAddress
+			i, err := findBridgeInstance(bridgeState, args[0])
+			if err != nil {
+				return err
+			}
+			vault := bridge.NewVault(args[1], multisigAddress, viper.GetBool(flagCold))
+			if err := vault.ValidateFor(bridgeState.Instances[i].MainchainNetworkType); err != nil {
+				return err
+			}
+			instance := &bridgeState.Instances[i]
+			registered := false
+			for j := range instance.Vaults {
+				if instance.Vaults[j].Name == vault.Name {
+					instance.Vaults[j] = vault
+					registered = true
+				}
+			}
+			if !registered {
+				instance.Vaults = append(instance.Vaults, vault)
+			}
+			if err := instance.Validate(); err != nil {
+				return err
+			}
 
 			bridgeStateBz, err := cdc.MarshalJSON(bridgeState)
 			if err != nil {
@@ -66,6 +89,7 @@ func RegisterMultisigAddressCmd(
 	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
 	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
 	cmd.Flags().String(flagClientHome, defaultClientHome, "client's home directory")
+	cmd.Flags().Bool(flagCold, false, "register a cold vault")
 
 	return cmd
 }
@@ -71,10 +71,22 @@ var rootCmd = &cobra.Command{
 
 func relayerCmd() *cobra.Command {
 	relayerCmd := &cobra.Command{
-		Use:     "start [tendermint_node] [proximax_node] [validator_from_name] [proximax_private_key] [proximax_multisig_publickey] --chain-id [chain-id]",
-		Short:   "Initializes a web socket which streams live events from the ProximaX network and relays them to the Cosmos network",
-		Args:    cobra.ExactArgs(5),
-		Example: "pxbrelayer start validator http://localhost:7475 http://bctestnet1.brimstone.xpxsirius.io:3000 3A700AE4105431BB0F24440AAA0CD08E1FD0D87D60EB805278F7DB3EA7C7D62D VBK6ZOASJSJOFUOX7XUHHZVCBO4Q11GCF726AKHG --chain-id=testing",
+		Use:   "start [tendermint_node] [validator_from_name] [bridge_config_file] --chain-id [chain-id]",
+		Short: "Initializes web sockets which stream live events from the ProximaX networks of the bridge instances and relay them to the Cosmos network",
+		Long: `Initializes web sockets which stream live events from the ProximaX networks of the bridge instances and relay them to the Cosmos network.
+
+The bridge config file is a JSON list of the bridge instances the validator cosigns for.
+The multisig vaults of each instance are read from the parameters of the zone when the relayer starts:
+
+[
+  {
+    "instance": "sirius",
+    "proximax_node": "http://bctestnet1.brimstone.xpxsirius.io:3000",
+    "cosigner_private_key": "3A700AE4105431BB0F24440AAA0CD08E1FD0D87D60EB805278F7DB3EA7C7D62D"
+  }
+]`,
+		Args:    cobra.ExactArgs(3),
+		Example: "pxbrelayer start http://localhost:26657 validator bridges.json --chain-id=testing",
 		RunE:    RunRelayerCmd,
 	}
 
@@ -92,24 +104,14 @@ func RunRelayerCmd(cmd *cobra.Command, args []string) error {
 		return errors.New(fmt.Sprintf("invalid [tendermint_node]: %s", tendermintNode))
 	}
 
-	proximaXNode := args[1]
-	if len(strings.Trim(proximaXNode, "")) == 0 {
-		return errors.New(fmt.Sprintf("invalid [proximax_node]: %s", proximaXNode))
-	}
-
-	validatorMoniker := args[2]
+	validatorMoniker := args[1]
 	if len(strings.Trim(validatorMoniker, "")) == 0 {
 		return errors.New(fmt.Sprintf("invalid [validator_from_name]: %s", validatorMoniker))
 	}
 
-	cosignerPrivateKey := args[3]
-	if len(strings.Trim(cosignerPrivateKey, "")) == 0 {
-		return errors.New(fmt.Sprintf("invalid [proximax_private_key]: %s", cosignerPrivateKey))
-	}
-
-	multisigPublicKey := args[4]
-	if len(strings.Trim(multisigPublicKey, "")) == 0 {
-		return errors.New(fmt.Sprintf("invalid [proximax_multisig_address]: %s", multisigPublicKey))
+	bridgeConfigs, err := relayer.LoadBridgeConfigs(args[2])
+	if err != nil {
+		return errors.New(fmt.Sprintf("invalid [bridge_config_file]: %s", err))
 	}
 
 	inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -125,11 +127,16 @@ func RunRelayerCmd(cmd *cobra.Command, args []string) error {
 		WithTxEncoder(utils.GetTxEncoder(appCodec)).
 		WithChainID(chainID)
 
-	proximaXSub, err := relayer.NewProximaxSub(appCodec, cliCtx, txBldr, logger, chainID, validatorMoniker, validatorAddress, proximaXNode, cosignerPrivateKey, multisigPublicKey)
-	if err != nil {
-		return err
+	// One ProximaX subscription per bridge instance, they share the Cosmos subscription
+	proximaXSubs := make([]relayer.ProximaXSub, 0, len(bridgeConfigs))
+	for _, bridgeConfig := range bridgeConfigs {
+		proximaXSub, err := relayer.NewProximaxSub(appCodec, cliCtx, txBldr, logger, chainID, validatorMoniker, validatorAddress, bridgeConfig)
+		if err != nil {
+			return err
+		}
+		proximaXSubs = append(proximaXSubs, proximaXSub)
 	}
-	cosmosSub, err := relayer.NewCosmosSub(appCodec, cliCtx, txBldr, logger, tendermintNode, chainID, validatorMoniker, validatorAddress, proximaXNode, cosignerPrivateKey, multisigPublicKey)
+	cosmosSub, err := relayer.NewCosmosSub(appCodec, cliCtx, txBldr, logger, tendermintNode, chainID, validatorMoniker, validatorAddress, bridgeConfigs)
 	if err != nil {
 		return err
 	}
@@ -137,7 +144,9 @@ func RunRelayerCmd(cmd *cobra.Command, args []string) error {
 	exitSignal := make(chan os.Signal, 1)
 	signal.Notify(exitSignal, syscall.SIGINT, syscall.SIGTERM)
 
-	go proximaXSub.Start(exitSignal)
+	for i := range proximaXSubs {
+		go proximaXSubs[i].Start(exitSignal)
+	}
 	go cosmosSub.Start(exitSignal)
 	<-exitSignal
 	return nil
@@ -20,6 +20,22 @@ import (
 	tmTypes "github.com/tendermint/tendermint/types"
 )
 
+// Bridge is the ProximaX side of a bridge instance the relayer cosigns for
+type Bridge struct {
+	Instance       string
+	PrivateKey     string
+	ProximaXClient *proximax.Client
+}
+
+// mainchainPublicKey returns the ProximaX public key of the relayer's cosigner account of the instance
+func (bridge *Bridge) mainchainPublicKey() (msgTypes.MainchainPublicKey, error) {
+	account, err := bridge.ProximaXClient.NewAccountFromPrivateKey(bridge.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	return msgTypes.NewMainchainPublicKey(account.PublicAccount.PublicKey)
+}
+
 type CosmosSub struct {
 	Cdc    *codec.Codec
 	Logger tmLog.Logger
@@ -28,19 +44,26 @@ type CosmosSub struct {
 
 	ChainId string
 
-	ValidatorMoniker         string
-	ValidatorAddress         sdk.ValAddress
-	ProximaxPrivateKey       string
-	ProximxMultisigPublicKey string
+	ValidatorMoniker string
+	ValidatorAddress sdk.ValAddress
 
 	TendermintClient *tmClient.HTTP
-	ProximaXClient   *proximax.Client
+	// Bridges are the bridge instances served by the relayer, by name
+	Bridges map[string]*Bridge
 }
 
-func NewCosmosSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr authtypes.TxBuilder, logger tmLog.Logger, tendermintNode, chainID, validatorMoniker string, validatorAddress sdk.ValAddress, proximaXNode, proximaXPrivateKey, proximaXMultisibPublicKey string) (CosmosSub, error) {
-	conf, err := proximax.NewConfig(context.Background(), []string{proximaXNode})
-	if err != nil {
-		return CosmosSub{}, err
+func NewCosmosSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr authtypes.TxBuilder, logger tmLog.Logger, tendermintNode, chainID, validatorMoniker string, validatorAddress sdk.ValAddress, configs []BridgeConfig) (CosmosSub, error) {
+	bridges := make(map[string]*Bridge)
+	for _, config := range configs {
+		conf, err := proximax.NewConfig(context.Background(), []string{config.ProximaXNode})
+		if err != nil {
+			return CosmosSub{}, err
+		}
+		bridges[config.Instance] = &Bridge{
+			Instance:       config.Instance,
+			PrivateKey:     config.CosignerPrivateKey,
+			ProximaXClient: proximax.NewClient(nil, conf),
+		}
 	}
 
 	tendermintClient, err := tmClient.NewHTTP(tendermintNode, "/websocket")
@@ -50,17 +73,15 @@ func NewCosmosSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr authtyp
 	tendermintClient.SetLogger(logger)
 
 	return CosmosSub{
-		Cdc:                      cdc,
-		Logger:                   logger,
-		CliCtx:                   cliCtx,
-		TxBldr:                   txBldr,
-		ChainId:                  chainID,
-		ValidatorMoniker:         validatorMoniker,
-		ValidatorAddress:         validatorAddress,
-		ProximaxPrivateKey:       proximaXPrivateKey,
-		ProximxMultisigPublicKey: proximaXMultisibPublicKey,
-		TendermintClient:         tendermintClient,
-		ProximaXClient:           proximax.NewClient(nil, conf),
+		Cdc:              cdc,
+		Logger:           logger,
+		CliCtx:           cliCtx,
+		TxBldr:           txBldr,
+		ChainId:          chainID,
+		ValidatorMoniker: validatorMoniker,
+		ValidatorAddress: validatorAddress,
+		TendermintClient: tendermintClient,
+		Bridges:          bridges,
 	}, nil
 }
 
@@ -80,8 +101,41 @@ func (sub *CosmosSub) Start(exitSignal chan os.Signal) {
 		os.Exit(1)
 	}
 
+	blockQuery := "tm.event = 'NewBlock'"
+	blocks, err := sub.TendermintClient.Subscribe(context.Background(), "test", blockQuery, 1000)
+	if err != nil {
+		sub.Logger.Error("Failed to subscribe to query", "err", err, "query", blockQuery)
+		os.Exit(1)
+	}
+
 	for {
 		select {
+		case result := <-blocks:
+			block, ok := result.Data.(tmTypes.EventDataNewBlock)
+			if !ok {
+				logger.Error("Type casting failed while extracting event data from new block")
+				continue
+			}
+
+			// Cosigner set changes are decided in the end blocker
+			for _, event := range block.ResultEndBlock.Events {
+				attributes := event.GetAttributes()
+				switch event.Type {
+				case "cosigner_invitation":
+					sub.handleCosignerInvitationEvent(attributes)
+				case "cosigner_removal":
+					sub.handleCosignerRemovalEvent(attributes)
+				case "rebalance_multisig":
+					sub.handleRebalanceMultisigEvent(attributes)
+				case "sweep":
+					sub.handleVaultTransferEvent(attributes, true)
+				case "unpeg":
+					// unpegs are released from the withdrawal queue
+					sub.handleUnpegEvent(attributes)
+				case "unpeg_batch":
+					sub.handleUnpegBatchEvent(attributes)
+				}
+			}
 		case result := <-out:
 			tx, ok := result.Data.(tmTypes.EventDataTx)
 			if !ok {
@@ -95,12 +149,15 @@ func (sub *CosmosSub) Start(exitSignal chan os.Signal) {
 				case "peg":
 					sub.handlePegEvent(attributes)
 					break
-				case "unpeg":
-					sub.handleUnpegEvent(attributes)
-					break
 				case "request_invitation":
 					sub.handleRequestInvitationEvent(attributes)
 					break
+				case "request_removal":
+					sub.handleRequestRemovalEvent(attributes)
+					break
+				case "vault_transfer":
+					sub.handleVaultTransferEvent(attributes, false)
+					break
 				default:
 					break
 				}
@@ -117,8 +174,21 @@ func (sub *CosmosSub) handlePegEvent(attributes []tmKv.Pair) {
 		sub.Logger.Error("Failed to convert PegClaim event to Cosmos Message", "err", err)
 		return
 	}
+	bridge, ok := sub.Bridges[cosmosMsg.Instance]
+	if !ok {
+		return
+	}
+	instance, err := sub.queryInstance(cosmosMsg.Instance)
+	if err != nil {
+		sub.Logger.Error("Failed to query bridge instance", "err", err)
+		return
+	}
+	if err := instance.ValidateCoins(cosmosMsg.Amount); err != nil {
+		sub.Logger.Error("Denom is not pegged by the instance", "err", err)
+		return
+	}
 
-	tx, err := sub.ProximaXClient.Transaction.GetTransaction(context.Background(), cosmosMsg.MainchainTxHash)
+	tx, err := bridge.ProximaXClient.Transaction.GetTransaction(context.Background(), cosmosMsg.MainchainTxHash.String())
 	if err != nil {
 		sub.Logger.Error("Transaction is not found", "err", err)
 		return
@@ -128,7 +198,7 @@ func (sub *CosmosSub) handlePegEvent(attributes []tmKv.Pair) {
 		return
 	}
 
-	status, err := sub.ProximaXClient.Transaction.GetTransactionStatus(context.Background(), cosmosMsg.MainchainTxHash)
+	status, err := bridge.ProximaXClient.Transaction.GetTransactionStatus(context.Background(), cosmosMsg.MainchainTxHash.String())
 	if err != nil {
 		sub.Logger.Error("Transaction.GetTransaction returned error", "err", err)
 		return
@@ -148,79 +218,353 @@ func (sub *CosmosSub) handlePegEvent(attributes []tmKv.Pair) {
 		return
 	}
 
-	unit := int64(proximax.XpxRelative(1).Amount)
-	var amount int64 = 0
-	for _, mosaic := range transferTx.Mosaics {
-		amount += int64(mosaic.Amount) / unit
+	// the deposit is credited to the vault it was sent to
+	recipient, err := msgTypes.NewMainchainAddress(transferTx.Recipient.Address)
+	if err != nil {
+		sub.Logger.Error("Failed to parse recipient address", "err", err)
+		return
+	}
+	vault, found := instance.GetVaultByAddress(recipient)
+	if !found {
+		sub.Logger.Info("Transaction is not sent to a vault of the instance", "hash", cosmosMsg.MainchainTxHash, "recipient", recipient)
+		return
 	}
 
-	var request int64 = 0
-	for _, coin := range cosmosMsg.Amount {
-		request += coin.Amount.Int64()
+	// the sender is checked against the address filter of the zone
+	signer := transferTx.GetAbstractTransaction().Signer
+	if signer == nil || signer.Address == nil {
+		sub.Logger.Error("Transaction has no signer", "hash", cosmosMsg.MainchainTxHash)
+		return
+	}
+	sender, err := msgTypes.NewMainchainAddress(signer.Address.Address)
+	if err != nil {
+		sub.Logger.Error("Failed to parse sender address", "err", err)
+		return
 	}
 
-	if (consumed + request) > amount {
-		sub.Logger.Error(fmt.Sprintf("Request amount exceeds remainning %s, request=%d, remainning=%d", cosmosMsg.MainchainTxHash, request, amount-consumed))
+	deposited, err := depositedCoins(bridge, instance, transferTx.Mosaics)
+	if err != nil {
+		sub.Logger.Error("Transaction deposits a mosaic which is not pegged", "hash", cosmosMsg.MainchainTxHash, "err", err)
 		return
 	}
 
-	remaiining := amount - consumed - request
-	msg := types.NewMsgPegClaim(cosmosMsg.Address, cosmosMsg.MainchainTxHash, cosmosMsg.Amount, remaiining, sub.ValidatorAddress)
+	// every denom of the request is checked against what is left of its own mosaic
+	remaining := sdk.NewCoins()
+	for _, coin := range deposited {
+		left := coin.Amount.Sub(consumed.AmountOf(coin.Denom)).Sub(cosmosMsg.Amount.AmountOf(coin.Denom))
+		if left.IsNegative() {
+			sub.Logger.Error(fmt.Sprintf("Request amount exceeds remainning %s, denom=%s, request=%s, remainning=%s", cosmosMsg.MainchainTxHash, coin.Denom, cosmosMsg.Amount.AmountOf(coin.Denom), coin.Amount.Sub(consumed.AmountOf(coin.Denom))))
+			return
+		}
+		remaining = remaining.Add(sdk.NewCoin(coin.Denom, left))
+	}
+	for _, coin := range cosmosMsg.Amount {
+		if !deposited.AmountOf(coin.Denom).IsPositive() {
+			sub.Logger.Error(fmt.Sprintf("Transaction %s deposits no %s", cosmosMsg.MainchainTxHash, coin.Denom))
+			return
+		}
+	}
+
+	var remaiining int64 = 0
+	for _, coin := range remaining {
+		remaiining += coin.Amount.Int64()
+	}
+	msg := types.NewMsgPegClaim(cosmosMsg.Address, cosmosMsg.Instance, vault.Name, cosmosMsg.MainchainTxHash, sender, cosmosMsg.Amount, remaiining, sub.ValidatorAddress)
 	err = txs.RelayPeg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
 	if err != nil {
 		sub.Logger.Error(fmt.Sprintf("Faild while broadcast transaction: %+v", err))
 	}
 }
 
+// depositedCoins returns the denoms a transfer deposits, failing on a mosaic the instance does not peg.
+// A transfer refers to a mosaic either by its id or by a namespace alias linked to it.
+func depositedCoins(bridge *Bridge, instance msgTypes.BridgeInstance, mosaics []*proximax.Mosaic) (sdk.Coins, error) {
+	assetIDs := make([][]proximax.AssetId, len(instance.Denoms))
+	for i, mapping := range instance.Denoms {
+		assetID, err := txs.MainchainAssetID(mapping)
+		if err != nil {
+			return nil, err
+		}
+		assetIDs[i] = []proximax.AssetId{assetID}
+		if namespaceID, ok := assetID.(*proximax.NamespaceId); ok {
+			if mosaicID, err := bridge.ProximaXClient.Namespace.GetLinkedMosaicId(context.Background(), namespaceID); err == nil {
+				assetIDs[i] = append(assetIDs[i], mosaicID)
+			}
+		}
+	}
+
+	coins := sdk.NewCoins()
+	for _, mosaic := range mosaics {
+		mapping, found := peggedMapping(instance, assetIDs, mosaic.AssetId)
+		if !found {
+			return nil, fmt.Errorf("mosaic %s is not pegged by instance %s", mosaic.AssetId, instance.Name)
+		}
+		unit, err := mapping.MainchainUnit()
+		if err != nil {
+			return nil, err
+		}
+		coins = coins.Add(sdk.NewCoin(mapping.Denom, sdk.NewIntFromUint64(uint64(mosaic.Amount)/unit)))
+	}
+	return coins, nil
+}
+
+// peggedMapping returns the denom mapping one of whose asset ids is the given one
+func peggedMapping(instance msgTypes.BridgeInstance, assetIDs [][]proximax.AssetId, assetID proximax.AssetId) (msgTypes.DenomMapping, bool) {
+	for i, ids := range assetIDs {
+		for _, id := range ids {
+			if equal, err := id.Equals(assetID); err == nil && equal {
+				return instance.Denoms[i], true
+			}
+		}
+	}
+	return msgTypes.DenomMapping{}, false
+}
+
 func (sub *CosmosSub) handleUnpegEvent(attributes []tmKv.Pair) {
-	msg, err := txs.UnpegEventToCosmosMsg(attributes)
+	msg, vault, multisigAddress, err := txs.UnpegEventToCosmosMsg(attributes)
 	if err != nil {
 		sub.Logger.Error("Failed to convert Unpeg event to Cosmos Message", "err", err)
 		return
 	}
-	if msg.FirstCosignerAddress.String() != sub.ValidatorAddress.String() {
+	bridge, ok := sub.Bridges[msg.Instance]
+	if !ok || msg.FirstCosignerAddress.String() != sub.ValidatorAddress.String() {
 		return
 	}
-	txHash, err := txs.RelayUnpeg(sub.ProximaXClient, sub.ProximaxPrivateKey, sub.ProximxMultisigPublicKey, msg)
+	instance, err := sub.queryInstance(msg.Instance)
+	if err != nil {
+		sub.Logger.Error("Failed to query bridge instance", "err", err)
+		return
+	}
+	txHash, err := txs.RelayUnpeg(bridge.ProximaXClient, bridge.PrivateKey, multisigAddress, instance, msg)
 	if err != nil {
 		sub.Logger.Error("Failed to Relay Transaction to ProximaX", "err", err)
 		return
 	}
 
-	firstCosignatory, err := sub.ProximaXClient.NewAccountFromPrivateKey(sub.ProximaxPrivateKey)
-	recordMsg := msgTypes.NewMsgRecordUnpeg(msg.Address, txHash, msg.Amount, firstCosignatory.PublicAccount.PublicKey, sub.ValidatorAddress)
+	pubKey, err := bridge.mainchainPublicKey()
+	if err != nil {
+		sub.Logger.Error("Failed to Get Account", "err", err)
+		return
+	}
+	recordMsg := msgTypes.NewMsgRecordUnpeg(msg.Address, msg.Instance, vault, txHash, msg.Amount, msg.GetRecipients(), pubKey, sub.ValidatorAddress)
 	err = txs.RelayRecordUnpeg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, recordMsg)
 	if err != nil {
 		sub.Logger.Error(fmt.Sprintf("Faild while broadcast transaction: %+v", err))
 	}
 }
 
+func (sub *CosmosSub) handleUnpegBatchEvent(attributes []tmKv.Pair) {
+	event, err := txs.ParseUnpegBatchEvent(attributes)
+	if err != nil {
+		sub.Logger.Error("Failed to parse UnpegBatch event", "err", err)
+		return
+	}
+	// only the first cosigner of the batch announces its aggregate, the others cosign it once
+	bridge, ok := sub.Bridges[event.Instance]
+	if !ok || event.FirstCosignerAddress.String() != sub.ValidatorAddress.String() {
+		return
+	}
+	instance, err := sub.queryInstance(event.Instance)
+	if err != nil {
+		sub.Logger.Error("Failed to query bridge instance", "err", err)
+		return
+	}
+	txHash, err := txs.RelayUnpegBatch(bridge.ProximaXClient, bridge.PrivateKey, instance, event)
+	if err != nil {
+		sub.Logger.Error("Failed to Relay Transaction to ProximaX", "err", err)
+		return
+	}
+
+	pubKey, err := bridge.mainchainPublicKey()
+	if err != nil {
+		sub.Logger.Error("Failed to Get Account", "err", err)
+		return
+	}
+	recordMsg := msgTypes.NewMsgRecordUnpegBatch(sub.ValidatorAddress, event.Instance, event.BatchID, txHash, pubKey)
+	err = txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, recordMsg)
+	if err != nil {
+		sub.Logger.Error(fmt.Sprintf("Faild while broadcast transaction: %+v", err))
+	}
+}
+
 func (sub *CosmosSub) handleRequestInvitationEvent(attributes []tmKv.Pair) {
 	msg, multisigAddress, err := txs.RequestInvitationEventToCosmosMsg(attributes)
 	if err != nil {
 		sub.Logger.Error("Failed to convert RequestInvitation event to Cosmos Message", "err", err)
 		return
 	}
-	if msg.FirstCosignerAddress.String() != sub.ValidatorAddress.String() {
+	bridge, ok := sub.Bridges[msg.Instance]
+	if !ok || msg.FirstCosignerAddress.String() != sub.ValidatorAddress.String() {
 		return
 	}
-	txHash, err := txs.RelayInvitation(sub.ProximaXClient, sub.ProximaxPrivateKey, msg, multisigAddress)
+	params, err := txs.QueryParams(sub.CliCtx)
+	if err != nil {
+		sub.Logger.Error("Failed to query bridge parameters", "err", err)
+		return
+	}
+	txHash, err := txs.RelayInvitation(bridge.ProximaXClient, bridge.PrivateKey, msg, multisigAddress, approvalOf(params, msg.Instance, multisigAddress))
 	if err != nil {
 		sub.Logger.Error("Failed to broadcase ProximaX transaction to add new cosigner", "err", err)
 		return
 	}
 
-	account, err := sub.ProximaXClient.NewAccountFromPrivateKey(sub.ProximaxPrivateKey)
+	pubKey, err := bridge.mainchainPublicKey()
 	if err != nil {
 		sub.Logger.Error("Failed to Get Account", "err", err)
 		return
 	}
-	pubKey := account.PublicAccount.PublicKey
 
-	pendingMsg := msgTypes.NewMsgPendingRequestInvitation(msg.Address, msg.NewCosignerPublicKey, msg.FirstCosignerAddress, pubKey, txHash)
+	pendingMsg := msgTypes.NewMsgPendingRequestInvitation(msg.Address, msg.Instance, msg.Vault, msg.NewCosignerPublicKey, msg.FirstCosignerAddress, pubKey, txHash)
 	err = txs.RelayPendingRequestInvitation(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, pendingMsg)
 	if err != nil {
 		sub.Logger.Error("Failed to broadcase Cosmos transaction to notify pending request", "err", err)
 		return
 	}
 }
+
+func (sub *CosmosSub) handleRequestRemovalEvent(attributes []tmKv.Pair) {
+	msg, multisigAddress, err := txs.RequestRemovalEventToCosmosMsg(attributes)
+	if err != nil {
+		sub.Logger.Error("Failed to convert RequestRemoval event to Cosmos Message", "err", err)
+		return
+	}
+	bridge, ok := sub.Bridges[msg.Instance]
+	if !ok || msg.FirstCosignerAddress.String() != sub.ValidatorAddress.String() {
+		return
+	}
+	params, err := txs.QueryParams(sub.CliCtx)
+	if err != nil {
+		sub.Logger.Error("Failed to query bridge parameters", "err", err)
+		return
+	}
+	txHash, err := txs.RelayRemoval(bridge.ProximaXClient, bridge.PrivateKey, msg, multisigAddress, approvalOf(params, msg.Instance, multisigAddress))
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast ProximaX transaction to remove cosigner", "err", err)
+		return
+	}
+
+	pubKey, err := bridge.mainchainPublicKey()
+	if err != nil {
+		sub.Logger.Error("Failed to Get Account", "err", err)
+		return
+	}
+
+	pendingMsg := msgTypes.NewMsgPendingRequestRemoval(msg.Address, msg.Instance, msg.CosignerPublicKey, msg.FirstCosignerAddress, pubKey, txHash)
+	err = txs.RelayPendingRequestRemoval(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, pendingMsg)
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast Cosmos transaction to notify pending removal", "err", err)
+		return
+	}
+}
+
+func (sub *CosmosSub) handleCosignerInvitationEvent(attributes []tmKv.Pair) {
+	event, err := txs.ParseCosignerRotationEvent(attributes)
+	if err != nil {
+		sub.Logger.Error("Failed to parse CosignerInvitation event", "err", err)
+		return
+	}
+	bridge, ok := sub.Bridges[event.Instance]
+	if !ok || !event.Validator.Equals(sub.ValidatorAddress) || event.FirstCosignerAddress.Empty() {
+		return
+	}
+
+	pubKey, err := bridge.mainchainPublicKey()
+	if err != nil {
+		sub.Logger.Error("Failed to Get Account", "err", err)
+		return
+	}
+
+	msg := msgTypes.NewMsgRequestInvitation(sub.ValidatorAddress, event.Instance, event.Vault, pubKey, event.FirstCosignerAddress)
+	err = txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast Cosmos transaction to request invitation", "err", err)
+	}
+}
+
+func (sub *CosmosSub) handleCosignerRemovalEvent(attributes []tmKv.Pair) {
+	event, err := txs.ParseCosignerRotationEvent(attributes)
+	if err != nil {
+		sub.Logger.Error("Failed to parse CosignerRemoval event", "err", err)
+		return
+	}
+	if _, ok := sub.Bridges[event.Instance]; !ok || !event.Requester.Equals(sub.ValidatorAddress) || event.FirstCosignerAddress.Empty() {
+		return
+	}
+
+	msg := msgTypes.NewMsgRequestRemoval(sub.ValidatorAddress, event.Instance, event.CosignerPublicKey, event.FirstCosignerAddress)
+	err = txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast Cosmos transaction to request removal", "err", err)
+	}
+}
+
+func (sub *CosmosSub) handleRebalanceMultisigEvent(attributes []tmKv.Pair) {
+	event, err := txs.ParseRebalanceMultisigEvent(attributes)
+	if err != nil {
+		sub.Logger.Error("Failed to parse RebalanceMultisig event", "err", err)
+		return
+	}
+	bridge, ok := sub.Bridges[event.Instance]
+	if !ok || !event.FirstCosignerAddress.Equals(sub.ValidatorAddress) {
+		return
+	}
+
+	_, err = txs.RelayRebalance(bridge.ProximaXClient, bridge.PrivateKey, event.MultisigAddress, event.Approval)
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast ProximaX transaction to re-balance the multisig", "err", err)
+	}
+}
+
+// handleVaultTransferEvent announces a transfer between vaults requested by a cosigner, or a sweep asked for by the zone
+func (sub *CosmosSub) handleVaultTransferEvent(attributes []tmKv.Pair, sweep bool) {
+	event, err := txs.ParseVaultTransferEvent(attributes)
+	if err != nil {
+		sub.Logger.Error("Failed to parse VaultTransfer event", "err", err)
+		return
+	}
+	bridge, ok := sub.Bridges[event.Instance]
+	if !ok || !event.FirstCosignerAddress.Equals(sub.ValidatorAddress) {
+		return
+	}
+	instance, err := sub.queryInstance(event.Instance)
+	if err != nil {
+		sub.Logger.Error("Failed to query bridge instance", "err", err)
+		return
+	}
+	txHash, err := txs.RelayVaultTransfer(bridge.ProximaXClient, bridge.PrivateKey, instance, event)
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast ProximaX transaction to transfer between vaults", "err", err)
+		return
+	}
+
+	pubKey, err := bridge.mainchainPublicKey()
+	if err != nil {
+		sub.Logger.Error("Failed to Get Account", "err", err)
+		return
+	}
+	recordMsg := msgTypes.NewMsgRecordVaultTransfer(sub.ValidatorAddress, event.Instance, txHash, event.FromVault, event.ToVault, event.Amount, pubKey, sweep)
+	err = txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, recordMsg)
+	if err != nil {
+		sub.Logger.Error("Failed to broadcast Cosmos transaction to record the vault transfer", "err", err)
+	}
+}
+
+// approvalOf returns the approval policy of the tier of the vault with the given multisig address
+func approvalOf(params msgTypes.Params, name string, multisigAddress msgTypes.MainchainAddress) msgTypes.MultisigApproval {
+	instance, _ := params.GetInstance(name)
+	vault, _ := instance.GetVaultByAddress(multisigAddress)
+	return params.ApprovalFor(vault)
+}
+
+// queryInstance returns the bridge instance from the current parameters of the zone
+func (sub *CosmosSub) queryInstance(name string) (msgTypes.BridgeInstance, error) {
+	params, err := txs.QueryParams(sub.CliCtx)
+	if err != nil {
+		return msgTypes.BridgeInstance{}, err
+	}
+	instance, found := params.GetInstance(name)
+	if !found {
+		return msgTypes.BridgeInstance{}, fmt.Errorf("bridge instance %s not found", name)
+	}
+	return instance, nil
+}
@@ -1,7 +1,10 @@
 package relayer
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 
 	sdkContext "github.com/cosmos/cosmos-sdk/client/context"
@@ -11,8 +14,46 @@ import (
 	amino "github.com/tendermint/go-amino"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	msgTypes "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
 )
 
+// BridgeConfig is the ProximaX side of a bridge instance served by the relayer
+type BridgeConfig struct {
+	Instance           string `json:"instance"`
+	ProximaXNode       string `json:"proximax_node"`
+	CosignerPrivateKey string `json:"cosigner_private_key"`
+}
+
+// LoadBridgeConfigs reads the JSON list of the bridge instances served by the relayer
+func LoadBridgeConfigs(file string) ([]BridgeConfig, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var configs []BridgeConfig
+	if err := json.Unmarshal(bz, &configs); err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no bridge instance in %s", file)
+	}
+
+	instances := make(map[string]bool)
+	for _, config := range configs {
+		if err := msgTypes.ValidateInstanceName(config.Instance); err != nil {
+			return nil, err
+		}
+		if instances[config.Instance] {
+			return nil, fmt.Errorf("duplicate bridge instance %s", config.Instance)
+		}
+		instances[config.Instance] = true
+		if config.ProximaXNode == "" || config.CosignerPrivateKey == "" {
+			return nil, fmt.Errorf("instance %s: proximax_node and cosigner_private_key are required", config.Instance)
+		}
+	}
+	return configs, nil
+}
+
 func LoadTendermintCLIContext(appCodec *amino.Codec, validatorAddress sdk.ValAddress, validatorName string,
 	rpcURL string, chainID string) sdkContext.CLIContext {
 	cliCtx := sdkContext.NewCLIContext().
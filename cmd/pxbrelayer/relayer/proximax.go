@@ -11,6 +11,7 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/lcnem/proximax-pegzone/cmd/pxbrelayer/txs"
 	msgTypes "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
 	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
 	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
 	tmLog "github.com/tendermint/tendermint/libs/log"
@@ -23,20 +24,20 @@ type ProximaXSub struct {
 	TxBldr authtypes.TxBuilder
 	Logger tmLog.Logger
 
-	ChainId string
+	ChainId  string
+	Instance string
 
 	ValidatorMoniker string
 	ValidatorAddress cosmosSdk.ValAddress
 	SignerAccount    *sdk.Account
-	MultisigAccount  *sdk.PublicAccount
 
 	TendermintClient *tmClient.HTTP
 	ProximaXClient   *sdk.Client
 	ProximaXWsClient websocket.CatapultClient
 }
 
-func NewProximaxSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr authtypes.TxBuilder, logger tmLog.Logger, chainID, validatorMoniker string, validatorAddress cosmosSdk.ValAddress, proximaXNode, proximaXPrivateKey, proximaXMultisibPublicKey string) (ProximaXSub, error) {
-	conf, err := sdk.NewConfig(context.Background(), []string{proximaXNode})
+func NewProximaxSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr authtypes.TxBuilder, logger tmLog.Logger, chainID, validatorMoniker string, validatorAddress cosmosSdk.ValAddress, bridge BridgeConfig) (ProximaXSub, error) {
+	conf, err := sdk.NewConfig(context.Background(), []string{bridge.ProximaXNode})
 	if err != nil {
 		return ProximaXSub{}, err
 	}
@@ -47,11 +48,7 @@ func NewProximaxSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr autht
 		return ProximaXSub{}, err
 	}
 
-	account, err := client.NewAccountFromPrivateKey(proximaXPrivateKey)
-	if err != nil {
-		return ProximaXSub{}, err
-	}
-	multisigAccount, err := client.NewAccountFromPublicKey(proximaXMultisibPublicKey)
+	account, err := client.NewAccountFromPrivateKey(bridge.CosignerPrivateKey)
 	if err != nil {
 		return ProximaXSub{}, err
 	}
@@ -62,10 +59,10 @@ func NewProximaxSub(cdc *codec.Codec, cliCtx sdkContext.CLIContext, txBldr autht
 		TxBldr:           txBldr,
 		Logger:           logger,
 		ChainId:          chainID,
+		Instance:         bridge.Instance,
 		ValidatorMoniker: validatorMoniker,
 		ValidatorAddress: validatorAddress,
 		SignerAccount:    account,
-		MultisigAccount:  multisigAccount,
 		ProximaXClient:   client,
 		ProximaXWsClient: wsClient,
 	}, nil
@@ -81,10 +78,14 @@ func (sub *ProximaXSub) Start(exitSignal chan os.Signal) error {
 	}
 
 	err = sub.ProximaXWsClient.AddStatusHandlers(sub.SignerAccount.Address, func(info *sdk.StatusInfo) bool {
-		hash := info.Hash.String()
+		hash, err := msgTypes.NewMainchainTxHash(info.Hash.String())
+		if err != nil {
+			sub.Logger.Error("Failed to parse transaction hash", "err", err)
+			return false
+		}
 
-		msg := msgTypes.NewMsgNotCosignedClaim(sub.ValidatorAddress, hash)
-		err := txs.RelayNotCosigned(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
+		msg := msgTypes.NewMsgNotCosignedClaim(sub.ValidatorAddress, sub.Instance, hash)
+		err = txs.RelayNotCosigned(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
 		if err != nil {
 			sub.Logger.Error("Failed to Relay NotCosigned", "err", err)
 		}
@@ -94,12 +95,54 @@ func (sub *ProximaXSub) Start(exitSignal chan os.Signal) error {
 		return err
 	}
 
-	err = sub.ProximaXWsClient.AddCosignatureHandlers(sub.MultisigAccount.Address, func(info *sdk.SignerInfo) bool {
-		txHash := info.ParentHash.String()
-		signerPublicKey := info.Signer
+	// the vaults are read once, a vault registered later is watched after a restart
+	params, err := txs.QueryParams(sub.CliCtx)
+	if err != nil {
+		return err
+	}
+	instance, found := params.GetInstance(sub.Instance)
+	if !found {
+		return fmt.Errorf("bridge instance %s not found", sub.Instance)
+	}
+	for _, vault := range instance.Vaults {
+		if err := sub.subscribeVault(instance, vault); err != nil {
+			return err
+		}
+	}
+
+	go sub.ProximaXWsClient.Listen()
+
+	<-exitSignal
+
+	return nil
+}
 
-		msg := msgTypes.NewMsgNotifyCosigned(sub.ValidatorAddress, txHash, signerPublicKey)
-		err := txs.RelayNotifyCosigned(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
+// subscribeVault relays the cosignatures and the confirmations of the aggregates announced from the multisig of a vault
+func (sub *ProximaXSub) subscribeVault(instance msgTypes.BridgeInstance, vault msgTypes.Vault) error {
+	multisigAddress, err := sdk.NewAddressFromRaw(vault.MainchainMultisigAddress.String())
+	if err != nil {
+		return err
+	}
+
+	err = sub.ProximaXWsClient.AddCosignatureHandlers(multisigAddress, func(info *sdk.SignerInfo) bool {
+		txHash, err := msgTypes.NewMainchainTxHash(info.ParentHash.String())
+		if err != nil {
+			sub.Logger.Error("Failed to parse transaction hash", "err", err)
+			return true
+		}
+		signerPublicKey, err := msgTypes.NewMainchainPublicKey(info.Signer)
+		if err != nil {
+			sub.Logger.Error("Failed to parse signer public key", "err", err)
+			return true
+		}
+		// every relayer reports its own cosignatures only, the zone credits them to the validator reporting them
+		ownPublicKey, err := msgTypes.NewMainchainPublicKey(sub.SignerAccount.PublicAccount.PublicKey)
+		if err != nil || signerPublicKey != ownPublicKey {
+			return true
+		}
+
+		msg := msgTypes.NewMsgNotifyCosigned(sub.ValidatorAddress, sub.Instance, txHash, signerPublicKey)
+		err = txs.RelayNotifyCosigned(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
 		if err != nil {
 			sub.Logger.Error("Failed to Relay NotifyCosigned", "err", err)
 		}
@@ -110,42 +153,137 @@ func (sub *ProximaXSub) Start(exitSignal chan os.Signal) error {
 		return err
 	}
 
-	err = sub.ProximaXWsClient.AddConfirmedAddedHandlers(sub.MultisigAccount.Address, func(info sdk.Transaction) bool {
+	// the funds locked for an aggregate are lost when it expires, whichever cosigner of the vault announced it
+	for _, cosigner := range instance.VaultCosigners(vault.Name) {
+		if err := sub.subscribeLockFunds(cosigner.MainchainPublicKey); err != nil {
+			return err
+		}
+	}
+
+	return sub.ProximaXWsClient.AddConfirmedAddedHandlers(multisigAddress, func(info sdk.Transaction) bool {
 		aggregateTx, ok := info.(*sdk.AggregateTransaction)
 		if ok {
-			txHash := aggregateTx.TransactionHash.String()
+			txHash, err := msgTypes.NewMainchainTxHash(aggregateTx.TransactionHash.String())
+			if err != nil {
+				sub.Logger.Error("Failed to parse transaction hash", "err", err)
+				return true
+			}
+
+			// the funds locked for a confirmed aggregate went back to its initiator
+			if initiator, err := msgTypes.NewMainchainPublicKey(aggregateTx.Signer.PublicKey); err == nil {
+				if _, found := instance.GetCosigner(initiator); found {
+					sub.relayLockFunds(txHash, initiator, types.LockFundsOutcomeReturned)
+				}
+			}
 
+			payout := false
 			for _, tx := range aggregateTx.InnerTransactions {
-				_, ok := tx.(*sdk.ModifyMultisigAccountTransaction)
-				if ok {
-					msg := msgTypes.NewMsgConfirmedInvitation(sub.ValidatorAddress, txHash)
+				if transferTx, ok := tx.(*sdk.TransferTransaction); ok {
+					// a transfer to another vault is a rebalancing, single unpegs are settled by their cosignatures
+					recipient, err := msgTypes.NewMainchainAddress(transferTx.Recipient.Address)
+					if err != nil {
+						continue
+					}
+					if to, found := instance.GetVaultByAddress(recipient); !found {
+						payout = true
+						continue
+					} else if to.Name == vault.Name {
+						continue
+					}
+					msg := msgTypes.NewMsgConfirmedVaultTransfer(sub.ValidatorAddress, sub.Instance, txHash)
+					err = txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
+					if err != nil {
+						sub.Logger.Error("Failed to Relay ConfirmedVaultTransfer", "err", err)
+					}
+					continue
+				}
+				modifyMultisigTx, ok := tx.(*sdk.ModifyMultisigAccountTransaction)
+				if !ok {
+					continue
+				}
+				if isRemoval(modifyMultisigTx) {
+					msg := msgTypes.NewMsgConfirmedRemoval(sub.ValidatorAddress, sub.Instance, txHash)
+					err := txs.RelayConfirmedRemoval(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
+					if err != nil {
+						sub.Logger.Error("Failed to Relay ConfirmedRemoval", "err", err)
+					}
+				} else {
+					msg := msgTypes.NewMsgConfirmedInvitation(sub.ValidatorAddress, sub.Instance, txHash)
 					err := txs.RelayConfirmedInvitation(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg)
 					if err != nil {
 						sub.Logger.Error("Failed to Relay ConfirmedInvitation", "err", err)
 					}
 				}
 			}
+			if payout {
+				sub.relayConfirmedUnpegBatch(txHash)
+			}
 		}
 
 		return true
 	})
+}
+
+// relayConfirmedUnpegBatch reports the confirmation of a payout aggregate to the announced unpeg batch it belongs to, if any
+func (sub *ProximaXSub) relayConfirmedUnpegBatch(txHash msgTypes.MainchainTxHash) {
+	batches, err := txs.QueryUnpegBatches(sub.CliCtx, msgTypes.UnpegBatchStatusAnnounced)
 	if err != nil {
-		return err
+		sub.Logger.Error("Failed to query unpeg batches", "err", err)
+		return
 	}
+	for _, batch := range batches {
+		if batch.Instance != sub.Instance || batch.MainchainTxHash != txHash {
+			continue
+		}
+		msg := msgTypes.NewMsgConfirmedUnpegBatch(sub.ValidatorAddress, sub.Instance, txHash)
+		if err := txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg); err != nil {
+			sub.Logger.Error("Failed to Relay ConfirmedUnpegBatch", "err", err)
+		}
+		return
+	}
+}
 
-	go sub.ProximaXWsClient.Listen()
+// subscribeLockFunds relays the failures of the aggregates a cosigner announced, whose locked funds it lost
+func (sub *ProximaXSub) subscribeLockFunds(initiator msgTypes.MainchainPublicKey) error {
+	account, err := sub.ProximaXClient.NewAccountFromPublicKey(initiator.String())
+	if err != nil {
+		return err
+	}
 
-	<-exitSignal
+	return sub.ProximaXWsClient.AddStatusHandlers(account.Address, func(info *sdk.StatusInfo) bool {
+		txHash, err := msgTypes.NewMainchainTxHash(info.Hash.String())
+		if err != nil {
+			sub.Logger.Error("Failed to parse transaction hash", "err", err)
+			return false
+		}
+		sub.relayLockFunds(txHash, initiator, types.LockFundsOutcomeExpired)
+		return false
+	})
+}
 
-	return nil
+func (sub *ProximaXSub) relayLockFunds(txHash msgTypes.MainchainTxHash, initiator msgTypes.MainchainPublicKey, outcome string) {
+	msg := msgTypes.NewMsgLockFundsClaim(sub.ValidatorAddress, sub.Instance, txHash, initiator, outcome)
+	if err := txs.RelayMsg(sub.CliCtx, sub.TxBldr, sub.ValidatorMoniker, msg); err != nil {
+		sub.Logger.Error("Failed to Relay LockFundsClaim", "err", err)
+	}
 }
 
-func partialAddedHandler(client *sdk.Client, logger tmLog.Logger, account *sdk.Account, tx *sdk.AggregateTransaction) {
-	if len(tx.InnerTransactions) != 1 {
-		return
+// isCosignable tells whether an aggregate is one the bridge announces:
+// transfers out of a multisig, one per recipient, or a single modification of a multisig
+func isCosignable(tx *sdk.AggregateTransaction) bool {
+	if len(tx.InnerTransactions) == 1 && tx.InnerTransactions[0].GetAbstractTransaction().Type == sdk.ModifyMultisig {
+		return true
+	}
+	for _, inner := range tx.InnerTransactions {
+		if inner.GetAbstractTransaction().Type != sdk.Transfer {
+			return false
+		}
 	}
+	return len(tx.InnerTransactions) > 0
+}
 
-	if txType := tx.InnerTransactions[0].GetAbstractTransaction().Type; txType == sdk.Transfer || txType == sdk.ModifyMultisig {
+func partialAddedHandler(client *sdk.Client, logger tmLog.Logger, account *sdk.Account, tx *sdk.AggregateTransaction) {
+	if isCosignable(tx) {
 		if tx.Signer.PublicKey == account.PublicAccount.PublicKey {
 			return
 		}
@@ -170,3 +308,12 @@ func partialAddedHandler(client *sdk.Client, logger tmLog.Logger, account *sdk.A
 		logger.Info(fmt.Sprintf("Signed Transaction: %s", tx.TransactionHash))
 	}
 }
+
+func isRemoval(tx *sdk.ModifyMultisigAccountTransaction) bool {
+	for _, modification := range tx.Modifications {
+		if modification.Type == sdk.Remove {
+			return true
+		}
+	}
+	return false
+}
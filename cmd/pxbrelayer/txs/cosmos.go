@@ -1,14 +1,45 @@
 package txs
 
 import (
+	"fmt"
+
 	sdkContext "github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	types "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
+	bridgeTypes "github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
 )
 
+// QueryParams returns the current parameters of the bridge module
+func QueryParams(cliCtx sdkContext.CLIContext) (types.Params, error) {
+	var params types.Params
+	route := fmt.Sprintf("custom/%s/%s", bridgeTypes.QuerierRoute, bridgeTypes.QueryParams)
+	res, _, err := cliCtx.QueryWithData(route, nil)
+	if err != nil {
+		return params, err
+	}
+	err = cliCtx.Codec.UnmarshalJSON(res, &params)
+	return params, err
+}
+
+// QueryUnpegBatches returns the unpeg batches of the given status
+func QueryUnpegBatches(cliCtx sdkContext.CLIContext, status string) ([]types.UnpegBatch, error) {
+	var batches []types.UnpegBatch
+	bz, err := cliCtx.Codec.MarshalJSON(bridgeTypes.NewQueryUnpegBatchesParams(status))
+	if err != nil {
+		return nil, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", bridgeTypes.QuerierRoute, bridgeTypes.QueryUnpegBatches)
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		return nil, err
+	}
+	err = cliCtx.Codec.UnmarshalJSON(res, &batches)
+	return batches, err
+}
+
 func RelayMsg(
 	cliCtx sdkContext.CLIContext,
 	txBldr authtypes.TxBuilder,
@@ -112,3 +143,21 @@ func RelayConfirmedInvitation(
 ) error {
 	return RelayMsg(cliCtx, txBldr, validatorMoniker, msg)
 }
+
+func RelayPendingRequestRemoval(
+	cliCtx sdkContext.CLIContext,
+	txBldr authtypes.TxBuilder,
+	validatorMoniker string,
+	msg types.MsgPendingRequestRemoval,
+) error {
+	return RelayMsg(cliCtx, txBldr, validatorMoniker, msg)
+}
+
+func RelayConfirmedRemoval(
+	cliCtx sdkContext.CLIContext,
+	txBldr authtypes.TxBuilder,
+	validatorMoniker string,
+	msg types.MsgConfirmedRemoval,
+) error {
+	return RelayMsg(cliCtx, txBldr, validatorMoniker, msg)
+}
@@ -1,6 +1,7 @@
 package txs
 
 import (
+	"encoding/json"
 	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -9,11 +10,12 @@ import (
 	msgTypes "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
 )
 
-func PegEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgPeg, int64, error) {
+func PegEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgPeg, sdk.Coins, error) {
 	var cosmosReceiver sdk.AccAddress
-	var mainchainTxHash string
+	var instance string
+	var mainchainTxHash msgTypes.MainchainTxHash
 	var amount sdk.Coins
-	var consumed int64
+	var consumed sdk.Coins
 	var err error
 
 	for _, attribute := range attributes {
@@ -23,32 +25,36 @@ func PegEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgPeg, int64, error
 		case "cosmos_receiver":
 			cosmosReceiver, err = sdk.AccAddressFromBech32(val)
 			break
+		case "instance":
+			instance = val
+			break
 		case "mainchain_tx_hash":
-			mainchainTxHash = val
+			mainchainTxHash, err = msgTypes.NewMainchainTxHash(val)
 			break
 		case "amount":
 			amount, err = sdk.ParseCoins(val)
 			break
 		case "consumed":
-			parsedVal, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				parsedVal = 0
-			}
-			consumed = parsedVal
+			consumed, err = sdk.ParseCoins(val)
 		}
 	}
 	if err != nil {
-		return nil, -1, err
+		return nil, nil, err
 	}
-	cosmosMsg := msgTypes.NewMsgPeg(cosmosReceiver, mainchainTxHash, amount)
+	cosmosMsg := msgTypes.NewMsgPeg(cosmosReceiver, instance, mainchainTxHash, amount)
 	return &cosmosMsg, consumed, nil
 }
 
-func UnpegEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgUnpeg, error) {
+// UnpegEventToCosmosMsg parses an unpeg event, along with the vault paying the unpeg and its multisig address
+func UnpegEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgUnpeg, string, msgTypes.MainchainAddress, error) {
 	var address sdk.AccAddress
-	var mainchainAddress string
+	var instance string
+	var vault string
+	var multisigAccountAddress msgTypes.MainchainAddress
+	var mainchainAddress msgTypes.MainchainAddress
 	var amount sdk.Coins
 	var firstCosignerAddress sdk.ValAddress
+	var recipients []msgTypes.UnpegRecipient
 	var err error
 
 	for _, attribute := range attributes {
@@ -58,33 +64,64 @@ func UnpegEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgUnpeg, error) {
 		case "cosmos_sender":
 			address, err = sdk.AccAddressFromBech32(val)
 			if err != nil {
-				return nil, err
+				return nil, "", "", err
+			}
+			break
+		case "instance":
+			instance = val
+			break
+		case "vault":
+			vault = val
+			break
+		case "multisig_address":
+			multisigAccountAddress, err = msgTypes.NewMainchainAddress(val)
+			if err != nil {
+				return nil, "", "", err
 			}
 			break
 		case "mainchain_address":
-			mainchainAddress = val
+			// empty when the unpeg pays out to several recipients
+			if val == "" {
+				break
+			}
+			mainchainAddress, err = msgTypes.NewMainchainAddress(val)
+			if err != nil {
+				return nil, "", "", err
+			}
 			break
 		case "amount":
 			amount, err = sdk.ParseCoins(val)
 			if err != nil {
-				return nil, err
+				return nil, "", "", err
 			}
 			break
 		case "first_cosigner_address":
 			firstCosignerAddress, err = sdk.ValAddressFromBech32(val)
 			if err != nil {
-				return nil, err
+				return nil, "", "", err
+			}
+			break
+		case "recipients":
+			recipients, err = msgTypes.ParseRecipientsAttribute(val)
+			if err != nil {
+				return nil, "", "", err
 			}
 		}
 	}
-	cosmosMsg := msgTypes.NewMsgUnpeg(address, mainchainAddress, amount, firstCosignerAddress)
-	return &cosmosMsg, nil
+	cosmosMsg := msgTypes.NewMsgUnpeg(address, instance, mainchainAddress, amount, firstCosignerAddress)
+	// the recipients carry the net amount each of them is paid out
+	if mainchainAddress.Empty() {
+		cosmosMsg.Recipients = recipients
+	}
+	return &cosmosMsg, vault, multisigAccountAddress, nil
 }
 
-func RequestInvitationEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgRequestInvitation, string, error) {
+func RequestInvitationEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgRequestInvitation, msgTypes.MainchainAddress, error) {
 	var address sdk.ValAddress
-	var multisigAccountPublicKey string
-	var newCosignerPublicKey string
+	var instance string
+	var vault string
+	var multisigAccountAddress msgTypes.MainchainAddress
+	var newCosignerPublicKey msgTypes.MainchainPublicKey
 	var firstCosignerAddress sdk.ValAddress
 	var err error
 
@@ -98,11 +135,23 @@ func RequestInvitationEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgReq
 				break
 			}
 			break
+		case "instance":
+			instance = val
+			break
+		case "vault":
+			vault = val
+			break
 		case "multisig_address":
-			multisigAccountPublicKey = val
+			multisigAccountAddress, err = msgTypes.NewMainchainAddress(val)
+			if err != nil {
+				return nil, "", err
+			}
 			break
 		case "new_cosigner_public_key":
-			newCosignerPublicKey = val
+			newCosignerPublicKey, err = msgTypes.NewMainchainPublicKey(val)
+			if err != nil {
+				return nil, "", err
+			}
 			break
 		case "first_cosigner_address":
 			firstCosignerAddress, err = sdk.ValAddressFromBech32(val)
@@ -114,6 +163,200 @@ func RequestInvitationEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgReq
 	if err != nil {
 		return nil, "", err
 	}
-	cosmosMsg := msgTypes.NewMsgRequestInvitation(address, newCosignerPublicKey, firstCosignerAddress)
-	return &cosmosMsg, multisigAccountPublicKey, nil
+	cosmosMsg := msgTypes.NewMsgRequestInvitation(address, instance, vault, newCosignerPublicKey, firstCosignerAddress)
+	return &cosmosMsg, multisigAccountAddress, nil
+}
+
+func RequestRemovalEventToCosmosMsg(attributes []tmKv.Pair) (*msgTypes.MsgRequestRemoval, msgTypes.MainchainAddress, error) {
+	var address sdk.ValAddress
+	var instance string
+	var multisigAccountAddress msgTypes.MainchainAddress
+	var cosignerPublicKey msgTypes.MainchainPublicKey
+	var firstCosignerAddress sdk.ValAddress
+	var err error
+
+	for _, attribute := range attributes {
+		key := string(attribute.GetKey())
+		val := string(attribute.GetValue())
+		switch key {
+		case "cosmos_account":
+			address, err = sdk.ValAddressFromBech32(val)
+			if err != nil {
+				return nil, "", err
+			}
+		case "instance":
+			instance = val
+		case "multisig_address":
+			multisigAccountAddress, err = msgTypes.NewMainchainAddress(val)
+			if err != nil {
+				return nil, "", err
+			}
+		case "cosigner_public_key":
+			cosignerPublicKey, err = msgTypes.NewMainchainPublicKey(val)
+			if err != nil {
+				return nil, "", err
+			}
+		case "first_cosigner_address":
+			firstCosignerAddress, err = sdk.ValAddressFromBech32(val)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	cosmosMsg := msgTypes.NewMsgRequestRemoval(address, instance, cosignerPublicKey, firstCosignerAddress)
+	return &cosmosMsg, multisigAccountAddress, nil
+}
+
+// CosignerRotationEvent is a cosigner_invitation or cosigner_removal event
+type CosignerRotationEvent struct {
+	Instance             string
+	Vault                string
+	Validator            sdk.ValAddress
+	FirstCosignerAddress sdk.ValAddress
+	Requester            sdk.ValAddress
+	CosignerPublicKey    msgTypes.MainchainPublicKey
+}
+
+func ParseCosignerRotationEvent(attributes []tmKv.Pair) (*CosignerRotationEvent, error) {
+	var event CosignerRotationEvent
+	var err error
+
+	for _, attribute := range attributes {
+		key := string(attribute.GetKey())
+		val := string(attribute.GetValue())
+		switch key {
+		case "instance":
+			event.Instance = val
+		case "vault":
+			event.Vault = val
+		case "validator":
+			event.Validator, err = sdk.ValAddressFromBech32(val)
+		case "first_cosigner_address":
+			event.FirstCosignerAddress, err = sdk.ValAddressFromBech32(val)
+		case "requester":
+			event.Requester, err = sdk.ValAddressFromBech32(val)
+		case "cosigner_public_key":
+			event.CosignerPublicKey, err = msgTypes.NewMainchainPublicKey(val)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// RebalanceMultisigEvent is a rebalance_multisig event of a bridge instance
+type RebalanceMultisigEvent struct {
+	Instance             string
+	MultisigAddress      msgTypes.MainchainAddress
+	FirstCosignerAddress sdk.ValAddress
+	Approval             msgTypes.MultisigApproval
+}
+
+func ParseRebalanceMultisigEvent(attributes []tmKv.Pair) (*RebalanceMultisigEvent, error) {
+	var event RebalanceMultisigEvent
+	var err error
+
+	for _, attribute := range attributes {
+		key := string(attribute.GetKey())
+		val := string(attribute.GetValue())
+		switch key {
+		case "instance":
+			event.Instance = val
+		case "multisig_address":
+			event.MultisigAddress, err = msgTypes.NewMainchainAddress(val)
+		case "first_cosigner_address":
+			event.FirstCosignerAddress, err = sdk.ValAddressFromBech32(val)
+		case "min_approval":
+			event.Approval.MinApproval, err = sdk.NewDecFromStr(val)
+		case "min_removal":
+			event.Approval.MinRemoval, err = sdk.NewDecFromStr(val)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// VaultTransferEvent is a vault_transfer event, a rebalancing transfer between two vaults of a bridge instance
+type VaultTransferEvent struct {
+	Instance             string
+	FromVault            string
+	ToVault              string
+	MultisigAddress      msgTypes.MainchainAddress
+	RecipientAddress     msgTypes.MainchainAddress
+	Amount               sdk.Coins
+	FirstCosignerAddress sdk.ValAddress
+}
+
+func ParseVaultTransferEvent(attributes []tmKv.Pair) (*VaultTransferEvent, error) {
+	var event VaultTransferEvent
+	var err error
+
+	for _, attribute := range attributes {
+		key := string(attribute.GetKey())
+		val := string(attribute.GetValue())
+		switch key {
+		case "instance":
+			event.Instance = val
+		case "from_vault":
+			event.FromVault = val
+		case "to_vault":
+			event.ToVault = val
+		case "multisig_address":
+			event.MultisigAddress, err = msgTypes.NewMainchainAddress(val)
+		case "recipient_address":
+			event.RecipientAddress, err = msgTypes.NewMainchainAddress(val)
+		case "amount":
+			event.Amount, err = sdk.ParseCoins(val)
+		case "first_cosigner_address":
+			event.FirstCosignerAddress, err = sdk.ValAddressFromBech32(val)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// UnpegBatchEvent is an unpeg_batch event, the unpegs a vault pays out in a single aggregate
+type UnpegBatchEvent struct {
+	BatchID              uint64
+	Instance             string
+	Vault                string
+	MultisigAddress      msgTypes.MainchainAddress
+	Amount               sdk.Coins
+	FirstCosignerAddress sdk.ValAddress
+	Unpegs               []msgTypes.BatchedUnpeg
+}
+
+func ParseUnpegBatchEvent(attributes []tmKv.Pair) (*UnpegBatchEvent, error) {
+	var event UnpegBatchEvent
+	var err error
+
+	for _, attribute := range attributes {
+		key := string(attribute.GetKey())
+		val := string(attribute.GetValue())
+		switch key {
+		case "batch_id":
+			event.BatchID, err = strconv.ParseUint(val, 10, 64)
+		case "instance":
+			event.Instance = val
+		case "vault":
+			event.Vault = val
+		case "multisig_address":
+			event.MultisigAddress, err = msgTypes.NewMainchainAddress(val)
+		case "amount":
+			event.Amount, err = sdk.ParseCoins(val)
+		case "first_cosigner_address":
+			event.FirstCosignerAddress, err = sdk.ValAddressFromBech32(val)
+		case "unpegs":
+			err = json.Unmarshal([]byte(val), &event.Unpegs)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
 }
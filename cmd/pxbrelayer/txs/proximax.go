@@ -3,15 +3,19 @@ package txs
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
+	"strconv"
 	"time"
 
+	cosmosSdk "github.com/cosmos/cosmos-sdk/types"
 	msgTypes "github.com/lcnem/proximax-pegzone/x/proximax-bridge"
 	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
 )
 
-func getAccountByAddress(client *sdk.Client, address string) (*sdk.PublicAccount, error) {
-	multisigAddress, err := sdk.NewAddressFromRaw(address)
+func getAccountByAddress(client *sdk.Client, address msgTypes.MainchainAddress) (*sdk.PublicAccount, error) {
+	multisigAddress, err := sdk.NewAddressFromRaw(address.String())
 	if err != nil {
 		return nil, err
 	}
@@ -22,45 +26,164 @@ func getAccountByAddress(client *sdk.Client, address string) (*sdk.PublicAccount
 	return client.NewAccountFromPublicKey(multisigAccountInfo.PublicKey)
 }
 
-func getApprovalDelta(originalNum int32, addedNumber int32) int8 {
-	originalMajority := originalNum / 2
-	newMajority := (originalNum + addedNumber) / 2
-	delta := newMajority - originalMajority
-	if delta <= math.MaxInt8 {
-		return int8(delta)
-	} else {
+// MainchainMosaic returns the mosaic transferred on ProximaX for an amount of a denom pegged by the instance
+func MainchainMosaic(instance msgTypes.BridgeInstance, denom string, amount cosmosSdk.Int) (*sdk.Mosaic, error) {
+	mapping, found := instance.GetDenom(denom)
+	if !found {
+		return nil, fmt.Errorf("%s is not pegged by instance %s", denom, instance.Name)
+	}
+
+	assetID, err := MainchainAssetID(mapping)
+	if err != nil {
+		return nil, err
+	}
+	absolute, err := mapping.MainchainAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewMosaic(assetID, sdk.Amount(absolute))
+}
+
+// MainchainAssetID returns the mosaic id or the namespace alias the denom is pegged to
+func MainchainAssetID(mapping msgTypes.DenomMapping) (sdk.AssetId, error) {
+	if mapping.IsMosaicID() {
+		id, err := strconv.ParseUint(mapping.MainchainMosaic, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		return sdk.NewMosaicId(id)
+	}
+	return sdk.NewNamespaceIdFromName(mapping.MainchainMosaic)
+}
+
+// getApprovalDelta returns the change from the current min approval or min removal to the required one
+func getApprovalDelta(current int32, required int) int8 {
+	delta := int32(required) - current
+	if delta > math.MaxInt8 {
 		return math.MaxInt8
 	}
+	if delta < math.MinInt8 {
+		return math.MinInt8
+	}
+	return int8(delta)
 }
 
-func RelayUnpeg(client *sdk.Client, firstCosignatoryPrivateKey, multisigPublicKey string, msg *msgTypes.MsgUnpeg) (string, error) {
-	multisigAccount, err := sdk.NewAccountFromPublicKey(multisigPublicKey, client.NetworkType())
-	firstCosignatory, err := client.NewAccountFromPrivateKey(firstCosignatoryPrivateKey)
+// mainchainMosaics returns the mosaics transferred on ProximaX for an amount of denoms pegged by the instance
+func mainchainMosaics(instance msgTypes.BridgeInstance, amount cosmosSdk.Coins) ([]*sdk.Mosaic, error) {
+	mosaics := make([]*sdk.Mosaic, 0, len(amount))
+	for _, coin := range amount {
+		mosaic, err := MainchainMosaic(instance, coin.Denom, coin.Amount)
+		if err != nil {
+			return nil, err
+		}
+		mosaics = append(mosaics, mosaic)
+	}
+	return mosaics, nil
+}
+
+// mainchainTransfer is an inner transfer of an aggregate announced from a multisig account
+type mainchainTransfer struct {
+	recipient msgTypes.MainchainAddress
+	mosaics   []*sdk.Mosaic
+	message   string
+}
+
+// RelayUnpeg announces the transfers of an unpeg from the multisig of the vault paying it,
+// one inner transfer per recipient in a single aggregate
+func RelayUnpeg(client *sdk.Client, firstCosignatoryPrivateKey string, multisigAccountAddress msgTypes.MainchainAddress, instance msgTypes.BridgeInstance, msg *msgTypes.MsgUnpeg) (msgTypes.MainchainTxHash, error) {
+	transfers := make([]mainchainTransfer, 0, len(msg.GetRecipients()))
+	for _, recipient := range msg.GetRecipients() {
+		// each transfer carries the part of the unpeg its recipient receives
+		unpeg := *msg
+		unpeg.MainchainAddress = recipient.MainchainAddress
+		unpeg.Amount = recipient.Amount
+		unpeg.Recipients = nil
+		txMsg, err := json.Marshal(unpeg)
+		if err != nil {
+			return "", err
+		}
+
+		mosaics, err := mainchainMosaics(instance, recipient.Amount)
+		if err != nil {
+			return "", err
+		}
+		transfers = append(transfers, mainchainTransfer{recipient: recipient.MainchainAddress, mosaics: mosaics, message: string(txMsg)})
+	}
+
+	return announceMultisigTransfers(client, firstCosignatoryPrivateKey, multisigAccountAddress, transfers)
+}
+
+// RelayUnpegBatch announces a single aggregate paying out every unpeg of a batch, one transfer per recipient
+func RelayUnpegBatch(client *sdk.Client, firstCosignatoryPrivateKey string, instance msgTypes.BridgeInstance, event *UnpegBatchEvent) (msgTypes.MainchainTxHash, error) {
+	transfers := []mainchainTransfer{}
+	for _, batched := range event.Unpegs {
+		for _, recipient := range batched.Recipients {
+			// each transfer carries the part of the unpeg its recipient receives, as a single unpeg would
+			unpeg := msgTypes.NewMsgUnpeg(batched.Address, event.Instance, recipient.MainchainAddress, recipient.Amount, event.FirstCosignerAddress)
+			txMsg, err := json.Marshal(unpeg)
+			if err != nil {
+				return "", err
+			}
+
+			mosaics, err := mainchainMosaics(instance, recipient.Amount)
+			if err != nil {
+				return "", err
+			}
+			transfers = append(transfers, mainchainTransfer{recipient: recipient.MainchainAddress, mosaics: mosaics, message: string(txMsg)})
+		}
+	}
+
+	return announceMultisigTransfers(client, firstCosignatoryPrivateKey, event.MultisigAddress, transfers)
+}
+
+// RelayVaultTransfer announces a rebalancing transfer from the multisig of a vault to the multisig of another vault
+func RelayVaultTransfer(client *sdk.Client, firstCosignatoryPrivateKey string, instance msgTypes.BridgeInstance, event *VaultTransferEvent) (msgTypes.MainchainTxHash, error) {
+	mosaics, err := mainchainMosaics(instance, event.Amount)
 	if err != nil {
 		return "", err
 	}
 
-	txMsg, err := json.Marshal(msg)
+	message := fmt.Sprintf("vault transfer of %s from %s to %s", instance.Name, event.FromVault, event.ToVault)
+	return announceMultisigTransfer(client, firstCosignatoryPrivateKey, event.MultisigAddress, event.RecipientAddress, mosaics, message)
+}
+
+// announceMultisigTransfer announces an aggregate bonded transaction transferring mosaics from the multisig account,
+// signed by the first cosignatory, after locking funds for it
+func announceMultisigTransfer(client *sdk.Client, firstCosignatoryPrivateKey string, multisigAccountAddress, recipient msgTypes.MainchainAddress, mosaics []*sdk.Mosaic, message string) (msgTypes.MainchainTxHash, error) {
+	return announceMultisigTransfers(client, firstCosignatoryPrivateKey, multisigAccountAddress, []mainchainTransfer{{recipient: recipient, mosaics: mosaics, message: message}})
+}
+
+// announceMultisigTransfers announces a single aggregate bonded transaction with an inner transfer from the multisig account per transfer,
+// signed by the first cosignatory, after locking funds for it
+func announceMultisigTransfers(client *sdk.Client, firstCosignatoryPrivateKey string, multisigAccountAddress msgTypes.MainchainAddress, transfers []mainchainTransfer) (msgTypes.MainchainTxHash, error) {
+	multisigAccount, err := getAccountByAddress(client, multisigAccountAddress)
 	if err != nil {
 		return "", err
 	}
-
-	amount := msg.Amount[0].Amount.BigInt().Uint64()
-	transferTx, err := client.NewTransferTransaction(
-		sdk.NewDeadline(time.Hour*1),
-		sdk.NewAddress(msg.MainchainAddress, client.NetworkType()),
-		[]*sdk.Mosaic{sdk.XpxRelative(amount)},
-		sdk.NewPlainMessage(string(txMsg)),
-	)
+	firstCosignatory, err := client.NewAccountFromPrivateKey(firstCosignatoryPrivateKey)
 	if err != nil {
 		return "", err
 	}
 
-	transferTx.ToAggregate(multisigAccount)
+	innerTxs := make([]sdk.Transaction, 0, len(transfers))
+	for _, transfer := range transfers {
+		transferTx, err := client.NewTransferTransaction(
+			sdk.NewDeadline(time.Hour*1),
+			sdk.NewAddress(transfer.recipient.String(), client.NetworkType()),
+			transfer.mosaics,
+			sdk.NewPlainMessage(transfer.message),
+		)
+		if err != nil {
+			return "", err
+		}
+
+		transferTx.ToAggregate(multisigAccount)
+		innerTxs = append(innerTxs, transferTx)
+	}
 
 	aggregateBoundedTx, err := client.NewBondedAggregateTransaction(
 		sdk.NewDeadline(time.Hour*1),
-		[]sdk.Transaction{transferTx},
+		innerTxs,
 	)
 	if err != nil {
 		return "", err
@@ -98,10 +221,10 @@ func RelayUnpeg(client *sdk.Client, firstCosignatoryPrivateKey, multisigPublicKe
 		return "", err
 	}
 
-	return signedAggregateBoundedTx.Hash.String(), nil
+	return msgTypes.NewMainchainTxHash(signedAggregateBoundedTx.Hash.String())
 }
 
-func RelayInvitation(client *sdk.Client, firstCosignatoryPrivateKey string, msg *msgTypes.MsgRequestInvitation, multisigAccountAddress string) (string, error) {
+func RelayInvitation(client *sdk.Client, firstCosignatoryPrivateKey string, msg *msgTypes.MsgRequestInvitation, multisigAccountAddress msgTypes.MainchainAddress, approval msgTypes.MultisigApproval) (msgTypes.MainchainTxHash, error) {
 	multisigAccount, err := getAccountByAddress(client, multisigAccountAddress)
 	if err != nil {
 		return "", err
@@ -110,7 +233,7 @@ func RelayInvitation(client *sdk.Client, firstCosignatoryPrivateKey string, msg
 	if err != nil {
 		return "", err
 	}
-	newCosignerAccount, err := client.NewAccountFromPublicKey(msg.NewCosignerPublicKey)
+	newCosignerAccount, err := client.NewAccountFromPublicKey(msg.NewCosignerPublicKey.String())
 	if err != nil {
 		return "", err
 	}
@@ -120,15 +243,78 @@ func RelayInvitation(client *sdk.Client, firstCosignatoryPrivateKey string, msg
 		return "", err
 	}
 
-	// Update majority
-	minApprovalDelta := getApprovalDelta(multisigAccountInfo.MinApproval, 1)
-	minRemovalDelta := getApprovalDelta(multisigAccountInfo.MinRemoval, 1)
+	// Keep the approval policy with the new cosigner
+	cosigners := len(multisigAccountInfo.Cosignatories) + 1
+	minApprovalDelta := getApprovalDelta(multisigAccountInfo.MinApproval, approval.MinApprovalFor(cosigners))
+	minRemovalDelta := getApprovalDelta(multisigAccountInfo.MinRemoval, approval.MinRemovalFor(cosigners))
+
+	return announceMultisigModification(client, firstCosignatory, multisigAccount, minApprovalDelta, minRemovalDelta, &sdk.MultisigCosignatoryModification{Type: sdk.Add, PublicAccount: newCosignerAccount})
+}
+
+func RelayRemoval(client *sdk.Client, firstCosignatoryPrivateKey string, msg *msgTypes.MsgRequestRemoval, multisigAccountAddress msgTypes.MainchainAddress, approval msgTypes.MultisigApproval) (msgTypes.MainchainTxHash, error) {
+	multisigAccount, err := getAccountByAddress(client, multisigAccountAddress)
+	if err != nil {
+		return "", err
+	}
+	firstCosignatory, err := client.NewAccountFromPrivateKey(firstCosignatoryPrivateKey)
+	if err != nil {
+		return "", err
+	}
+	cosignerAccount, err := client.NewAccountFromPublicKey(msg.CosignerPublicKey.String())
+	if err != nil {
+		return "", err
+	}
+
+	multisigAccountInfo, err := client.Account.GetMultisigAccountInfo(context.Background(), multisigAccount.Address)
+	if err != nil {
+		return "", err
+	}
+	cosigners := len(multisigAccountInfo.Cosignatories) - 1
+	if cosigners < 1 {
+		return "", errors.New("cannot remove the last cosigner of the multisig account")
+	}
+
+	// Keep the approval policy within the remaining cosigners
+	minApprovalDelta := getApprovalDelta(multisigAccountInfo.MinApproval, approval.MinApprovalFor(cosigners))
+	minRemovalDelta := getApprovalDelta(multisigAccountInfo.MinRemoval, approval.MinRemovalFor(cosigners))
+
+	return announceMultisigModification(client, firstCosignatory, multisigAccount, minApprovalDelta, minRemovalDelta, &sdk.MultisigCosignatoryModification{Type: sdk.Remove, PublicAccount: cosignerAccount})
+}
+
+// RelayRebalance re-balances the min approval and min removal of the multisig account to the approval policy
+func RelayRebalance(client *sdk.Client, firstCosignatoryPrivateKey string, multisigAccountAddress msgTypes.MainchainAddress, approval msgTypes.MultisigApproval) (msgTypes.MainchainTxHash, error) {
+	multisigAccount, err := getAccountByAddress(client, multisigAccountAddress)
+	if err != nil {
+		return "", err
+	}
+	firstCosignatory, err := client.NewAccountFromPrivateKey(firstCosignatoryPrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	multisigAccountInfo, err := client.Account.GetMultisigAccountInfo(context.Background(), multisigAccount.Address)
+	if err != nil {
+		return "", err
+	}
+
+	cosigners := len(multisigAccountInfo.Cosignatories)
+	minApprovalDelta := getApprovalDelta(multisigAccountInfo.MinApproval, approval.MinApprovalFor(cosigners))
+	minRemovalDelta := getApprovalDelta(multisigAccountInfo.MinRemoval, approval.MinRemovalFor(cosigners))
+	if minApprovalDelta == 0 && minRemovalDelta == 0 {
+		return "", errors.New("multisig account already follows the approval policy")
+	}
+
+	return announceMultisigModification(client, firstCosignatory, multisigAccount, minApprovalDelta, minRemovalDelta)
+}
 
+// announceMultisigModification announces an aggregate bonded transaction modifying the multisig account,
+// signed by the first cosignatory, after locking funds for it
+func announceMultisigModification(client *sdk.Client, firstCosignatory *sdk.Account, multisigAccount *sdk.PublicAccount, minApprovalDelta, minRemovalDelta int8, modifications ...*sdk.MultisigCosignatoryModification) (msgTypes.MainchainTxHash, error) {
 	modifyMultisigTx, err := client.NewModifyMultisigAccountTransaction(
 		sdk.NewDeadline(time.Hour*1),
 		minApprovalDelta,
 		minRemovalDelta,
-		[]*sdk.MultisigCosignatoryModification{{sdk.Add, newCosignerAccount}},
+		modifications,
 	)
 	if err != nil {
 		return "", err
@@ -175,5 +361,5 @@ func RelayInvitation(client *sdk.Client, firstCosignatoryPrivateKey string, msg
 		return "", err
 	}
 
-	return signedAggregateBoundedTx.Hash.String(), nil
+	return msgTypes.NewMainchainTxHash(signedAggregateBoundedTx.Hash.String())
 }
@@ -1,18 +1,32 @@
 package proximax_bridge
 
 import (
-
-	abci "github.com/tendermint/tendermint/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 // BeginBlocker check for infraction evidence or downtime of validators
 // on every begin block
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) {
-// 	TODO: fill out if your application requires beginblock, if not you can delete this function
+	// 	TODO: fill out if your application requires beginblock, if not you can delete this function
 }
 
-// EndBlocker called every block, process inflation, update validator set.
+// EndBlocker expires the prophecies which did not reach consensus in time,
+// asks for cosigner set changes when the stake distribution moved
+// and for sweeps when a hot vault left its limits, queues the unpegs whose time lock is over
+// and releases the queued pegs and the withdrawal queue as the volume caps free up, closes the unpeg batches whose window is over
+// and hands the ones and the cosigner removals not announced in time to another cosigner,
+// and pays the fee pool out to the validators who did the work
 func EndBlocker(ctx sdk.Context, k Keeper) {
-// 	TODO: fill out if your application requires endblock, if not you can delete this function
-}
\ No newline at end of file
+	k.ExpireProphecies(ctx)
+	k.RotateCosigners(ctx)
+	k.ExpireCosignerRemovals(ctx)
+	k.PruneVolume(ctx)
+	k.UnlockUnpegs(ctx)
+	k.ReleaseQueuedPegs(ctx)
+	k.ReleaseQueuedUnpegs(ctx)
+	k.CloseUnpegBatches(ctx)
+	k.ExpireUnpegBatches(ctx)
+	k.ProposeSweeps(ctx)
+	k.DistributeFees(ctx)
+}
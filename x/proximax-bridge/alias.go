@@ -8,21 +8,33 @@ import (
 const (
 	// TODO: define constants that you would like exposed from the internal package
 
-	ModuleName        = types.ModuleName
-	RouterKey         = types.RouterKey
-	StoreKey          = types.StoreKey
-	StoreKeyForPeg    = types.StoreKeyForPeg
-	StoreKeyForUnpeg  = types.StoreKeyForUnpeg
-	StoreKeyForCosign = types.StoreKeyForCosign
-	StoreKeyForInvite = types.StoreKeyForInvite
-	DefaultParamspace = types.DefaultParamspace
-	QuerierRoute      = types.QuerierRoute
+	ModuleName          = types.ModuleName
+	RouterKey           = types.RouterKey
+	StoreKey            = types.StoreKey
+	StoreKeyForPeg      = types.StoreKeyForPeg
+	StoreKeyForUnpeg    = types.StoreKeyForUnpeg
+	StoreKeyForCosign   = types.StoreKeyForCosign
+	StoreKeyForInvite   = types.StoreKeyForInvite
+	StoreKeyForRemoval  = types.StoreKeyForRemoval
+	StoreKeyForProphecy = types.StoreKeyForProphecy
+	FeePoolName         = types.FeePoolName
+	DefaultParamspace   = types.DefaultParamspace
+	QuerierRoute        = types.QuerierRoute
+	DefaultInstanceName = types.DefaultInstanceName
+	MaxUnpegRecipients  = types.MaxUnpegRecipients
+
+	UnpegBatchStatusOpen      = types.UnpegBatchStatusOpen
+	UnpegBatchStatusClosed    = types.UnpegBatchStatusClosed
+	UnpegBatchStatusAnnounced = types.UnpegBatchStatusAnnounced
+	UnpegBatchStatusCompleted = types.UnpegBatchStatusCompleted
+	UnpegBatchStatusFailed    = types.UnpegBatchStatusFailed
 )
 
 var (
 	// functions aliases
 	NewKeeper           = keeper.NewKeeper
 	NewQuerier          = keeper.NewQuerier
+	NewOracleKeeper     = keeper.NewOracleKeeper
 	RegisterCodec       = types.RegisterCodec
 	NewGenesisState     = types.NewGenesisState
 	DefaultGenesisState = types.DefaultGenesisState
@@ -31,12 +43,43 @@ var (
 	NewMsgPeg                      = types.NewMsgPeg
 	NewMsgPegClaim                 = types.NewMsgPegClaim
 	NewMsgUnpeg                    = types.NewMsgUnpeg
+	NewMsgUnpegToRecipients        = types.NewMsgUnpegToRecipients
 	NewMsgRecordUnpeg              = types.NewMsgRecordUnpeg
 	NewMsgNotifyCosigned           = types.NewMsgNotifyCosigned
 	NewMsgNotCosignedClaim         = types.NewMsgNotCosignedClaim
 	NewMsgRequestInvitation        = types.NewMsgRequestInvitation
 	NewMsgPendingRequestInvitation = types.NewMsgPendingRequestInvitation
 	NewMsgConfirmedInvitation      = types.NewMsgConfirmedInvitation
+	NewMsgRequestRemoval           = types.NewMsgRequestRemoval
+	NewMsgPendingRequestRemoval    = types.NewMsgPendingRequestRemoval
+	NewMsgConfirmedRemoval         = types.NewMsgConfirmedRemoval
+	NewMsgRequestVaultTransfer     = types.NewMsgRequestVaultTransfer
+	NewMsgRecordVaultTransfer      = types.NewMsgRecordVaultTransfer
+	NewMsgConfirmedVaultTransfer   = types.NewMsgConfirmedVaultTransfer
+	NewMsgLockFundsClaim           = types.NewMsgLockFundsClaim
+	NewMsgCancelUnpeg              = types.NewMsgCancelUnpeg
+	NewMsgVetoUnpeg                = types.NewMsgVetoUnpeg
+	NewMsgRecordUnpegBatch         = types.NewMsgRecordUnpegBatch
+	NewMsgConfirmedUnpegBatch      = types.NewMsgConfirmedUnpegBatch
+
+	NewMainchainNetworkType = types.NewMainchainNetworkType
+	NewMainchainAddress     = types.NewMainchainAddress
+	NewMainchainPublicKey   = types.NewMainchainPublicKey
+	NewMainchainTxHash      = types.NewMainchainTxHash
+
+	NewUnpegRecipient        = types.NewUnpegRecipient
+	SumRecipients            = types.SumRecipients
+	RecipientsAttribute      = types.RecipientsAttribute
+	ParseRecipientsAttribute = types.ParseRecipientsAttribute
+
+	NewBridgeInstance    = types.NewBridgeInstance
+	NewVault             = types.NewVault
+	ValidateInstanceName = types.ValidateInstanceName
+
+	NewChangeMultisigAddressProposal  = types.NewChangeMultisigAddressProposal
+	NewAddCosignerProposal            = types.NewAddCosignerProposal
+	NewRemoveCosignerProposal         = types.NewRemoveCosignerProposal
+	NewChangeMultisigApprovalProposal = types.NewChangeMultisigApprovalProposal
 
 	// variable aliases
 	ModuleCdc = types.ModuleCdc
@@ -58,6 +101,54 @@ type (
 	MsgRequestInvitation        = types.MsgRequestInvitation
 	MsgPendingRequestInvitation = types.MsgPendingRequestInvitation
 	MsgConfirmedInvitation      = types.MsgConfirmedInvitation
+	MsgRequestRemoval           = types.MsgRequestRemoval
+	MsgPendingRequestRemoval    = types.MsgPendingRequestRemoval
+	MsgConfirmedRemoval         = types.MsgConfirmedRemoval
+	MsgRequestVaultTransfer     = types.MsgRequestVaultTransfer
+	MsgRecordVaultTransfer      = types.MsgRecordVaultTransfer
+	MsgConfirmedVaultTransfer   = types.MsgConfirmedVaultTransfer
+	MsgLockFundsClaim           = types.MsgLockFundsClaim
+	MsgCancelUnpeg              = types.MsgCancelUnpeg
+	MsgVetoUnpeg                = types.MsgVetoUnpeg
+	MsgRecordUnpegBatch         = types.MsgRecordUnpegBatch
+	MsgConfirmedUnpegBatch      = types.MsgConfirmedUnpegBatch
+
+	MainchainNetworkType = types.MainchainNetworkType
+	MainchainAddress     = types.MainchainAddress
+	MainchainPublicKey   = types.MainchainPublicKey
+	MainchainTxHash      = types.MainchainTxHash
+
+	BridgeInstance    = types.BridgeInstance
+	DenomMapping      = types.DenomMapping
+	Cosigner          = types.Cosigner
+	ConsensusNeeded   = types.ConsensusNeeded
+	CosignerSetChange = types.CosignerSetChange
+	Vault             = types.Vault
+	VaultBalance      = types.VaultBalance
+	VaultTransfer     = types.VaultTransfer
+	HotVaultLimits    = types.HotVaultLimits
+	SweepRequest      = types.SweepRequest
+	FeeRate           = types.FeeRate
+	DenomFee          = types.DenomFee
+	FeeDistribution   = types.FeeDistribution
+	RewardPoints      = types.RewardPoints
+
+	LockFundsReimbursement = types.LockFundsReimbursement
+	TransferLimit          = types.TransferLimit
+	DenomLimit             = types.DenomLimit
+	VolumeEntry            = types.VolumeEntry
+	QueuedUnpeg            = types.QueuedUnpeg
+	QueuePosition          = types.QueuePosition
+	TimeLock               = types.TimeLock
+	TimeLockedUnpeg        = types.TimeLockedUnpeg
+	AddressFilter          = types.AddressFilter
+	UnpegRecipient         = types.UnpegRecipient
+	UnpegBatch             = types.UnpegBatch
+	BatchedUnpeg           = types.BatchedUnpeg
 
-	Cosigner = types.Cosigner
+	ChangeMultisigAddressProposal  = types.ChangeMultisigAddressProposal
+	AddCosignerProposal            = types.AddCosignerProposal
+	RemoveCosignerProposal         = types.RemoveCosignerProposal
+	ChangeMultisigApprovalProposal = types.ChangeMultisigApprovalProposal
+	MultisigApproval               = types.MultisigApproval
 )
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+type (
+	// ChangeMultisigAddressProposalJSON defines a ChangeMultisigAddressProposal with a deposit
+	ChangeMultisigAddressProposalJSON struct {
+		Title                    string                 `json:"title" yaml:"title"`
+		Description              string                 `json:"description" yaml:"description"`
+		Instance                 string                 `json:"instance" yaml:"instance"`
+		Vault                    string                 `json:"vault" yaml:"vault"`
+		MainchainMultisigAddress types.MainchainAddress `json:"mainchain_multisig_address" yaml:"mainchain_multisig_address"`
+		Cold                     bool                   `json:"cold" yaml:"cold"`
+		Deposit                  sdk.Coins              `json:"deposit" yaml:"deposit"`
+	}
+
+	// AddCosignerProposalJSON defines a AddCosignerProposal with a deposit
+	AddCosignerProposalJSON struct {
+		Title              string                   `json:"title" yaml:"title"`
+		Description        string                   `json:"description" yaml:"description"`
+		Instance           string                   `json:"instance" yaml:"instance"`
+		Vault              string                   `json:"vault" yaml:"vault"`
+		ValidatorAddress   sdk.ValAddress           `json:"validator_address" yaml:"validator_address"`
+		MainchainPublicKey types.MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+		Deposit            sdk.Coins                `json:"deposit" yaml:"deposit"`
+	}
+
+	// RemoveCosignerProposalJSON defines a RemoveCosignerProposal with a deposit
+	RemoveCosignerProposalJSON struct {
+		Title              string                   `json:"title" yaml:"title"`
+		Description        string                   `json:"description" yaml:"description"`
+		Instance           string                   `json:"instance" yaml:"instance"`
+		MainchainPublicKey types.MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+		Deposit            sdk.Coins                `json:"deposit" yaml:"deposit"`
+	}
+
+	// ChangeMultisigApprovalProposalJSON defines a ChangeMultisigApprovalProposal with a deposit
+	ChangeMultisigApprovalProposalJSON struct {
+		Title            string                 `json:"title" yaml:"title"`
+		Description      string                 `json:"description" yaml:"description"`
+		MultisigApproval types.MultisigApproval `json:"multisig_approval" yaml:"multisig_approval"`
+		Cold             bool                   `json:"cold" yaml:"cold"`
+		Deposit          sdk.Coins              `json:"deposit" yaml:"deposit"`
+	}
+)
+
+func parseProposalJSON(cdc *codec.Codec, proposalFile string, proposal interface{}) error {
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return err
+	}
+
+	return cdc.UnmarshalJSON(contents, proposal)
+}
+
+func submitProposal(cmd *cobra.Command, cdc *codec.Codec, content gov.Content, deposit sdk.Coins) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+	cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+}
+
+// GetCmdSubmitChangeMultisigAddressProposal is the CLI command for submitting a ChangeMultisigAddressProposal
+func GetCmdSubmitChangeMultisigAddressProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "change-multisig-address [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to change the mainchain multisig address of a vault of a bridge instance",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to change the mainchain multisig address of a vault along with an initial deposit.
+A vault which does not exist yet is added to the instance. A cold vault holds the reserves beyond the hot vault limits.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal change-multisig-address <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Change Multisig Address",
+  "description": "Move the bridge to the new multisig account",
+  "instance": "sirius",
+  "vault": "vault-1",
+  "mainchain_multisig_address": "VDDPZ7FWDFTTMB6JCNIUTNE3XHQ5RSC2YGQJWL3I",
+  "cold": false,
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal ChangeMultisigAddressProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewChangeMultisigAddressProposal(proposal.Title, proposal.Description, proposal.Instance, proposal.Vault, proposal.MainchainMultisigAddress, proposal.Cold)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
+
+// GetCmdSubmitAddCosignerProposal is the CLI command for submitting a AddCosignerProposal
+func GetCmdSubmitAddCosignerProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-cosigner [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to add a cosigner of the mainchain multisig of a vault of a bridge instance",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to add a cosigner of the mainchain multisig along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal add-cosigner <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Add Cosigner",
+  "description": "Add my validator as a cosigner",
+  "instance": "sirius",
+  "vault": "vault-1",
+  "validator_address": "cosmosvaloper1s5afhd6gxevu37mkqcvvsj8qeylhn0rz46zdlq",
+  "mainchain_public_key": "0E9A8E1F5D4C9B0A1C0F3E1D2C3B4A5968778695A4B3C2D1E0F1A2B3C4D5E6F7",
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal AddCosignerProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewAddCosignerProposal(proposal.Title, proposal.Description, proposal.Instance, proposal.Vault, proposal.ValidatorAddress, proposal.MainchainPublicKey)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
+
+// GetCmdSubmitRemoveCosignerProposal is the CLI command for submitting a RemoveCosignerProposal
+func GetCmdSubmitRemoveCosignerProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-cosigner [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to remove a cosigner of the mainchain multisig of a bridge instance",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to remove a cosigner of the mainchain multisig along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal remove-cosigner <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Remove Cosigner",
+  "description": "The cosigner has been offline for a week",
+  "instance": "sirius",
+  "mainchain_public_key": "0E9A8E1F5D4C9B0A1C0F3E1D2C3B4A5968778695A4B3C2D1E0F1A2B3C4D5E6F7",
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal RemoveCosignerProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewRemoveCosignerProposal(proposal.Title, proposal.Description, proposal.Instance, proposal.MainchainPublicKey)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
+
+// GetCmdSubmitChangeMultisigApprovalProposal is the CLI command for submitting a ChangeMultisigApprovalProposal
+func GetCmdSubmitChangeMultisigApprovalProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "change-multisig-approval [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to change the approval policy of the mainchain multisig of the hot or the cold vaults",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to change the approval policy of the mainchain multisig along with an initial deposit.
+More than the given share of the cosigners will be required to approve a transaction and to remove a cosigner.
+The policy applies to the cold vaults if cold is true, to the hot vaults otherwise, and the cold one cannot be less strict.
+The thresholds of the existing multisig are re-balanced once the proposal passes.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal change-multisig-approval <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Change Multisig Approval",
+  "description": "Require more than two thirds of the cosigners",
+  "multisig_approval": {
+    "min_approval": "0.666666666666666667",
+    "min_removal": "0.666666666666666667"
+  },
+  "cold": false,
+  "deposit": [
+    {
+      "denom": "stake",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal ChangeMultisigApprovalProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewChangeMultisigApprovalProposal(proposal.Title, proposal.Description, proposal.MultisigApproval, proposal.Cold)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
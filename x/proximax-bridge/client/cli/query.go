@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
 )
@@ -28,6 +30,21 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		flags.GetCommands(
 			// TODO: Add query Cmds
 			GetCmdQueryParams(queryRoute, cdc),
+			GetCmdQueryFaultyClaims(queryRoute, cdc),
+			GetCmdQueryProphecy(queryRoute, cdc),
+			GetCmdQueryCosignerSetChanges(queryRoute, cdc),
+			GetCmdQueryCosignerSet(queryRoute, cdc),
+			GetCmdQueryVaults(queryRoute, cdc),
+			GetCmdQueryDepositVault(queryRoute, cdc),
+			GetCmdQueryFeeEstimate(queryRoute, cdc),
+			GetCmdQueryFeePool(queryRoute, cdc),
+			GetCmdQueryRewards(queryRoute, cdc),
+			GetCmdQueryLockFundsReimbursements(queryRoute, cdc),
+			GetCmdQueryQueuedUnpeg(queryRoute, cdc),
+			GetCmdQueryQueuedUnpegs(queryRoute, cdc),
+			GetCmdQueryTimeLockedUnpegs(queryRoute, cdc),
+			GetCmdQueryUnpegBatch(queryRoute, cdc),
+			GetCmdQueryUnpegBatches(queryRoute, cdc),
 		)...,
 	)
 
@@ -56,3 +73,426 @@ func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func GetCmdQueryFaultyClaims(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "faulty-claims [validator_address]",
+		Short: "Get the peg claims which contradicted consensus, of all validators or of the given one",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var validatorAddress sdk.ValAddress
+			if len(args) == 1 {
+				address, err := sdk.ValAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+				validatorAddress = address
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryFaultyClaimsParams(validatorAddress))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFaultyClaims), bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.FaultyClaim
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryProphecy(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prophecy [prophecy_id]",
+		Short: "Get the status of a prophecy, what each validator claimed on it and the consensus reached so far",
+		Long: `Get the status of a prophecy, what each validator claimed on it and the consensus reached so far.
+The id of a peg prophecy is "[instance],[mainchain_tx_hash],[amount],[remaining]",
+the id of a not-cosigned prophecy is "[instance],[mainchain_tx_hash]".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryProphecyParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryProphecy), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResProphecy
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryCosignerSetChanges(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cosigner-set-changes [instance]",
+		Short: "Get the log of cosigner set changes with their height, mainchain tx hash, reason and resulting set, of all instances if none is given",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var instance string
+			if len(args) > 0 {
+				instance = args[0]
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryCosignerSetChangesParams(instance))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCosignerSetChanges), bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.CosignerSetChange
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryCosignerSet(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cosigner-set [instance] [height]",
+		Short: "Get the cosigner set of an instance as of the given block height",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			height, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryCosignerSetParams(args[0], height))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCosignerSet), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.CosignerSetChange
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryVaults(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vaults [instance]",
+		Short: "Get the vaults of an instance with their cosigners and balances, the pending transfers between them and the pegged supply they back",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryVaultsParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryVaults), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResVaults
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryDepositVault(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deposit-vault [instance] [denom]",
+		Short: "Get the vault deposits of a denom should be sent to",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryDepositVaultParams(args[0], args[1]))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryDepositVault), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.Vault
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryFeeEstimate(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-estimate [peg|unpeg] [amount]",
+		Short: "Get the bridge fee on an amount pegged or unpegged, and the net amount the recipient receives",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if err := types.ValidateFeeDirection(args[0]); err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryFeeEstimateParams(args[0], amount))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFeeEstimate), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResFeeEstimate
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryFeePool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fee-pool",
+		Short: "Get the bridge fees collected and not paid out yet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFeePool), nil)
+			if err != nil {
+				return err
+			}
+
+			var out sdk.Coins
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards",
+		Short: "Get the points the validators earned towards the next payout of the fee pool",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryRewards), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResRewards
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryLockFundsReimbursements(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lock-funds-reimbursements",
+		Short: "Get what the bridge owes the validators for the funds their cosigners lost locking aggregates which expired",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryLockFundsReimbursements), nil)
+			if err != nil {
+				return err
+			}
+
+			var out []types.LockFundsReimbursement
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryQueuedUnpeg(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "queued-unpeg [id]",
+		Short: "Get an unpeg of the withdrawal queue with its position and the height it is expected to be released at",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid [id]: %w", err)
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryQueuedUnpegParams(id))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryQueuedUnpeg), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueuePosition
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryQueuedUnpegs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "queued-unpegs [sender]",
+		Short: "Get the withdrawal queue, or the unpegs of the given sender in it, with their positions and the heights they are expected to be released at",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var sender sdk.AccAddress
+			if len(args) == 1 {
+				address, err := sdk.AccAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+				sender = address
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryQueuedUnpegsParams(sender))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryQueuedUnpegs), bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.QueuePosition
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryTimeLockedUnpegs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "time-locked-unpegs [sender]",
+		Short: "Get the unpegs held back by the time lock, or those of the given sender, with the heights they join the withdrawal queue at",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var sender sdk.AccAddress
+			if len(args) == 1 {
+				address, err := sdk.AccAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+				sender = address
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryTimeLockedUnpegsParams(sender))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTimeLockedUnpegs), bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.TimeLockedUnpeg
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryUnpegBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpeg-batch [id]",
+		Short: "Get an unpeg batch with its status and the unpegs it pays out",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid [id]: %w", err)
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryUnpegBatchParams(id))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryUnpegBatch), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.UnpegBatch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+func GetCmdQueryUnpegBatches(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpeg-batches [status]",
+		Short: "Get the unpeg batches, or those of the given status (open, closed, announced, completed or failed)",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var status string
+			if len(args) == 1 {
+				status = args[0]
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryUnpegBatchesParams(status))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryUnpegBatches), bz)
+			if err != nil {
+				return err
+			}
+
+			var out []types.UnpegBatch
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
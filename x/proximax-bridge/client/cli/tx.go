@@ -2,8 +2,8 @@ package cli
 
 import (
 	"bufio"
-	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -22,7 +22,7 @@ import (
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	proximaxbridgeTxCmd := &cobra.Command{
 		Use:                        types.ModuleName,
-		Short:                      fmt.Sprintf("%S transactions subcommands", types.ModuleName),
+		Short:                      fmt.Sprintf("%s transactions subcommands", types.ModuleName),
 		DisableFlagParsing:         true,
 		SuggestionsMinimumDistance: 2,
 		RunE:                       client.ValidateCmd,
@@ -33,7 +33,12 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		// GetCmd<Action>(cdc)
 		GetCmdPeg(cdc),
 		GetCmdUnpeg(cdc),
+		GetCmdUnpegToRecipients(cdc),
 		GetCmdRequestInvitation(cdc),
+		GetCmdRequestRemoval(cdc),
+		GetCmdRequestVaultTransfer(cdc),
+		GetCmdCancelUnpeg(cdc),
+		GetCmdVetoUnpeg(cdc),
 	)...)
 
 	return proximaxbridgeTxCmd
@@ -41,25 +46,25 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdPeg(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "peg [key_or_address] [mainchain_tx_hash] [amount]",
+		Use:   "peg [key_or_address] [instance] [mainchain_tx_hash] [amount]",
 		Short: "Peg",
-		Args:  cobra.ExactArgs(3), // Does your request require arguments
+		Args:  cobra.ExactArgs(4), // Does your request require arguments
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			mainchainTxHash := args[1]
-			if len(strings.Trim(mainchainTxHash, "")) == 0 {
-				return errors.New(fmt.Sprintf("invalid [mainchain_tx_hash]: %s", mainchainTxHash))
+			mainchainTxHash, err := types.NewMainchainTxHash(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid [mainchain_tx_hash]: %w", err)
 			}
 
-			coins, err := sdk.ParseCoins(args[2])
+			coins, err := sdk.ParseCoins(args[3])
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgPeg(cliCtx.FromAddress, mainchainTxHash, coins)
+			msg := types.NewMsgPeg(cliCtx.FromAddress, args[1], mainchainTxHash, coins)
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -72,30 +77,73 @@ func GetCmdPeg(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdUnpeg(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "unpeg [key_or_address] [mainchain_address] [amount] [first_cosigner_address]",
+		Use:   "unpeg [key_or_address] [instance] [mainchain_address] [amount] [first_cosigner_address]",
 		Short: "Unpeg",
-		Args:  cobra.ExactArgs(4), // Does your request require arguments
+		Args:  cobra.ExactArgs(5), // Does your request require arguments
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			mainChainAddress := args[1]
-			if len(strings.Trim(mainChainAddress, "")) == 0 {
-				return errors.New(fmt.Sprintf("invalid [mainchain_address]: %s", mainChainAddress))
+			mainChainAddress, err := types.NewMainchainAddress(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid [mainchain_address]: %w", err)
 			}
 
-			amount, err := sdk.ParseCoins(args[2])
+			amount, err := sdk.ParseCoins(args[3])
 			if err != nil {
 				return err
 			}
 
-			firstCosignerAddress, err := sdk.ValAddressFromBech32(args[3])
+			firstCosignerAddress, err := sdk.ValAddressFromBech32(args[4])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUnpeg(cliCtx.FromAddress, args[1], mainChainAddress, amount, firstCosignerAddress)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdUnpegToRecipients(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpeg-to-recipients [key_or_address] [instance] [first_cosigner_address] [mainchain_address=amount]...",
+		Short: "Unpeg to several ProximaX addresses in a single aggregate transaction",
+		Args:  cobra.MinimumNArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			firstCosignerAddress, err := sdk.ValAddressFromBech32(args[2])
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgUnpeg(cliCtx.FromAddress, mainChainAddress, amount, firstCosignerAddress)
+			recipients := make([]types.UnpegRecipient, 0, len(args)-3)
+			for _, arg := range args[3:] {
+				parts := strings.SplitN(arg, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid [mainchain_address=amount]: %s", arg)
+				}
+				mainchainAddress, err := types.NewMainchainAddress(parts[0])
+				if err != nil {
+					return fmt.Errorf("invalid [mainchain_address]: %w", err)
+				}
+				amount, err := sdk.ParseCoins(parts[1])
+				if err != nil {
+					return err
+				}
+				recipients = append(recipients, types.NewUnpegRecipient(mainchainAddress, amount))
+			}
+
+			msg := types.NewMsgUnpegToRecipients(cliCtx.FromAddress, args[1], recipients, firstCosignerAddress)
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -108,25 +156,134 @@ func GetCmdUnpeg(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdRequestInvitation(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "request-invitation [from_key_or_address] [new_cosigner_public_key] [first_cosigner_address]",
-		Short: "Request invitation for multisig cosigner",
-		Args:  cobra.ExactArgs(3), // Does your request require arguments
+		Use:   "request-invitation [from_key_or_address] [instance] [vault] [new_cosigner_public_key] [first_cosigner_address]",
+		Short: "Request invitation for multisig cosigner of a vault",
+		Args:  cobra.ExactArgs(5), // Does your request require arguments
 		RunE: func(cmd *cobra.Command, args []string) error {
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			newCosignerPublicKey := args[1]
-			if len(strings.Trim(newCosignerPublicKey, "")) == 0 {
-				return errors.New(fmt.Sprintf("invalid [new_cosigner_public_key]: %s", newCosignerPublicKey))
+			newCosignerPublicKey, err := types.NewMainchainPublicKey(args[3])
+			if err != nil {
+				return fmt.Errorf("invalid [new_cosigner_public_key]: %w", err)
 			}
 
-			firstCosignerAddress, err := sdk.ValAddressFromBech32(args[2])
+			firstCosignerAddress, err := sdk.ValAddressFromBech32(args[4])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRequestInvitation(sdk.ValAddress(cliCtx.FromAddress), args[1], args[2], newCosignerPublicKey, firstCosignerAddress)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdRequestRemoval(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "request-removal [from_key_or_address] [instance] [cosigner_public_key] [first_cosigner_address]",
+		Short: "Request removal of a multisig cosigner",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			cosignerPublicKey, err := types.NewMainchainPublicKey(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid [cosigner_public_key]: %w", err)
+			}
+
+			firstCosignerAddress, err := sdk.ValAddressFromBech32(args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRequestRemoval(sdk.ValAddress(cliCtx.FromAddress), args[1], cosignerPublicKey, firstCosignerAddress)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdRequestVaultTransfer(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "request-vault-transfer [from_key_or_address] [instance] [from_vault] [to_vault] [amount]",
+		Short: "Request a rebalancing transfer between two vaults, announced by a cosigner of the source vault",
+		Args:  cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			amount, err := sdk.ParseCoins(args[4])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRequestVaultTransfer(sdk.ValAddress(cliCtx.FromAddress), args[1], args[2], args[3], amount)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdCancelUnpeg(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-unpeg [key_or_address] [id]",
+		Short: "Cancel an unpeg waiting in the withdrawal queue and get its coins back",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			id, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid [id]: %w", err)
+			}
+
+			msg := types.NewMsgCancelUnpeg(cliCtx.FromAddress, id)
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgRequestInvitation(sdk.ValAddress(cliCtx.FromAddress), newCosignerPublicKey, firstCosignerAddress)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdVetoUnpeg(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "veto-unpeg [guardian_key_or_address] [id]",
+		Short: "Veto an unpeg held back by the time lock as a guardian, refunding its sender",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[0]).WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			id, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid [id]: %w", err)
+			}
+
+			msg := types.NewMsgVetoUnpeg(cliCtx.FromAddress, id)
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
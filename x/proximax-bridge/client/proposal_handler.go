@@ -0,0 +1,15 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/client/cli"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/client/rest"
+)
+
+// proximax-bridge proposal handlers
+var (
+	ChangeMultisigAddressProposalHandler  = govclient.NewProposalHandler(cli.GetCmdSubmitChangeMultisigAddressProposal, rest.ChangeMultisigAddressProposalRESTHandler)
+	AddCosignerProposalHandler            = govclient.NewProposalHandler(cli.GetCmdSubmitAddCosignerProposal, rest.AddCosignerProposalRESTHandler)
+	RemoveCosignerProposalHandler         = govclient.NewProposalHandler(cli.GetCmdSubmitRemoveCosignerProposal, rest.RemoveCosignerProposalRESTHandler)
+	ChangeMultisigApprovalProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitChangeMultisigApprovalProposal, rest.ChangeMultisigApprovalProposalRESTHandler)
+)
@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+type (
+	// ChangeMultisigAddressProposalReq defines a change multisig address proposal request body
+	ChangeMultisigAddressProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title                    string                 `json:"title" yaml:"title"`
+		Description              string                 `json:"description" yaml:"description"`
+		Instance                 string                 `json:"instance" yaml:"instance"`
+		Vault                    string                 `json:"vault" yaml:"vault"`
+		MainchainMultisigAddress types.MainchainAddress `json:"mainchain_multisig_address" yaml:"mainchain_multisig_address"`
+		Cold                     bool                   `json:"cold" yaml:"cold"`
+		Proposer                 sdk.AccAddress         `json:"proposer" yaml:"proposer"`
+		Deposit                  sdk.Coins              `json:"deposit" yaml:"deposit"`
+	}
+
+	// AddCosignerProposalReq defines an add cosigner proposal request body
+	AddCosignerProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title              string                   `json:"title" yaml:"title"`
+		Description        string                   `json:"description" yaml:"description"`
+		Instance           string                   `json:"instance" yaml:"instance"`
+		Vault              string                   `json:"vault" yaml:"vault"`
+		ValidatorAddress   sdk.ValAddress           `json:"validator_address" yaml:"validator_address"`
+		MainchainPublicKey types.MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+		Proposer           sdk.AccAddress           `json:"proposer" yaml:"proposer"`
+		Deposit            sdk.Coins                `json:"deposit" yaml:"deposit"`
+	}
+
+	// RemoveCosignerProposalReq defines a remove cosigner proposal request body
+	RemoveCosignerProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title              string                   `json:"title" yaml:"title"`
+		Description        string                   `json:"description" yaml:"description"`
+		Instance           string                   `json:"instance" yaml:"instance"`
+		MainchainPublicKey types.MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+		Proposer           sdk.AccAddress           `json:"proposer" yaml:"proposer"`
+		Deposit            sdk.Coins                `json:"deposit" yaml:"deposit"`
+	}
+
+	// ChangeMultisigApprovalProposalReq defines a change multisig approval proposal request body
+	ChangeMultisigApprovalProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title            string                 `json:"title" yaml:"title"`
+		Description      string                 `json:"description" yaml:"description"`
+		MultisigApproval types.MultisigApproval `json:"multisig_approval" yaml:"multisig_approval"`
+		Cold             bool                   `json:"cold" yaml:"cold"`
+		Proposer         sdk.AccAddress         `json:"proposer" yaml:"proposer"`
+		Deposit          sdk.Coins              `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// ChangeMultisigAddressProposalRESTHandler returns a ProposalRESTHandler that exposes the change multisig address REST handler
+func ChangeMultisigAddressProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "change_multisig_address",
+		Handler:  postChangeMultisigAddressProposalHandlerFn(cliCtx),
+	}
+}
+
+// AddCosignerProposalRESTHandler returns a ProposalRESTHandler that exposes the add cosigner REST handler
+func AddCosignerProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "add_cosigner",
+		Handler:  postAddCosignerProposalHandlerFn(cliCtx),
+	}
+}
+
+// RemoveCosignerProposalRESTHandler returns a ProposalRESTHandler that exposes the remove cosigner REST handler
+func RemoveCosignerProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "remove_cosigner",
+		Handler:  postRemoveCosignerProposalHandlerFn(cliCtx),
+	}
+}
+
+// ChangeMultisigApprovalProposalRESTHandler returns a ProposalRESTHandler that exposes the change multisig approval REST handler
+func ChangeMultisigApprovalProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "change_multisig_approval",
+		Handler:  postChangeMultisigApprovalProposalHandlerFn(cliCtx),
+	}
+}
+
+func postChangeMultisigAddressProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ChangeMultisigAddressProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		content := types.NewChangeMultisigAddressProposal(req.Title, req.Description, req.Instance, req.Vault, req.MainchainMultisigAddress, req.Cold)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit, req.Proposer)
+	}
+}
+
+func postAddCosignerProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req AddCosignerProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		content := types.NewAddCosignerProposal(req.Title, req.Description, req.Instance, req.Vault, req.ValidatorAddress, req.MainchainPublicKey)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit, req.Proposer)
+	}
+}
+
+func postRemoveCosignerProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RemoveCosignerProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		content := types.NewRemoveCosignerProposal(req.Title, req.Description, req.Instance, req.MainchainPublicKey)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit, req.Proposer)
+	}
+}
+
+func postChangeMultisigApprovalProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ChangeMultisigApprovalProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		content := types.NewChangeMultisigApprovalProposal(req.Title, req.Description, req.MultisigApproval, req.Cold)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Deposit, req.Proposer)
+	}
+}
+
+func writeProposalResponse(w http.ResponseWriter, cliCtx context.CLIContext, baseReq rest.BaseReq, content gov.Content, deposit sdk.Coins, proposer sdk.AccAddress) {
+	baseReq = baseReq.Sanitize()
+	if !baseReq.ValidateBasic(w) {
+		return
+	}
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, proposer)
+	if err := msg.ValidateBasic(); err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+}
@@ -3,7 +3,6 @@ package rest
 import (
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -27,16 +26,37 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 
 	r.HandleFunc(
 		"/proximax_bridge/request_invitation",
-		UnpegRequestHandlerFn(cliCtx),
+		RequestInvitationRequestHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/proximax_bridge/request_removal",
+		RequestRemovalRequestHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/proximax_bridge/request_vault_transfer",
+		RequestVaultTransferRequestHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/proximax_bridge/cancel_unpeg",
+		CancelUnpegRequestHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/proximax_bridge/veto_unpeg",
+		VetoUnpegRequestHandlerFn(cliCtx),
 	).Methods("POST")
 }
 
 type PegReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 	// TODO: Define more types if needed
-	Address         string `json:"address" yaml:"address"`
-	MainchainTxHash string `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	Amount          string `json:"amount" yaml:"amount"`
+	Address         string                `json:"address" yaml:"address"`
+	Instance        string                `json:"instance" yaml:"instance"`
+	MainchainTxHash types.MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Amount          string                `json:"amount" yaml:"amount"`
 }
 
 func PegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -60,7 +80,7 @@ func PegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		if len(strings.Trim(req.MainchainTxHash, "")) == 0 {
+		if req.MainchainTxHash.Empty() {
 			msg := fmt.Sprintf("invalid mainchain_tx_hash: %s", req.MainchainTxHash)
 			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
 			return
@@ -73,7 +93,7 @@ func PegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgPeg(address, req.MainchainTxHash, amount)
+		msg := types.NewMsgPeg(address, req.Instance, req.MainchainTxHash, amount)
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
@@ -81,10 +101,12 @@ func PegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 type UnpegReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 	// TODO: Define more types if needed
-	Address              string `json:"address" yaml:"address"`
-	MainchainAddress     string `json:"mainchain_address" yaml:"mainchain_address"`
-	Amount               string `json:"amount" yaml:"amount"`
-	FirstCosignerAddress string `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Address              string                 `json:"address" yaml:"address"`
+	Instance             string                 `json:"instance" yaml:"instance"`
+	MainchainAddress     types.MainchainAddress `json:"mainchain_address" yaml:"mainchain_address"`
+	Amount               string                 `json:"amount" yaml:"amount"`
+	FirstCosignerAddress string                 `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Recipients           []types.UnpegRecipient `json:"recipients" yaml:"recipients"`
 }
 
 func UnpegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -108,22 +130,32 @@ func UnpegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		if len(strings.Trim(req.MainchainAddress, "")) == 0 {
-			msg := fmt.Sprintf("invalid mainchain_address: %s", req.MainchainAddress)
+		firstCosignerAddress, err := sdk.ValAddressFromBech32(req.FirstCosignerAddress)
+		if err != nil {
+			msg := fmt.Sprintf("failed to parse first_cosigner_address: %s", req.FirstCosignerAddress)
 			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
 			return
 		}
 
-		amount, err := sdk.ParseCoins(req.Amount)
-		if err != nil {
-			msg := fmt.Sprintf("failed to parse amount: %s", req.Amount)
+		if len(req.Recipients) > 0 {
+			msg := types.NewMsgUnpegToRecipients(address, req.Instance, req.Recipients, firstCosignerAddress)
+			if err := msg.ValidateBasic(); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+			return
+		}
+
+		if req.MainchainAddress.Empty() {
+			msg := fmt.Sprintf("invalid mainchain_address: %s", req.MainchainAddress)
 			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
 			return
 		}
 
-		firstCosignerAddress, err := sdk.ValAddressFromBech32(req.FirstCosignerAddress)
+		amount, err := sdk.ParseCoins(req.Amount)
 		if err != nil {
-			msg := fmt.Sprintf("failed to parse first_cosigner_address: %s", req.FirstCosignerAddress)
+			msg := fmt.Sprintf("failed to parse amount: %s", req.Amount)
 			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
 			return
 		}
@@ -131,6 +163,7 @@ func UnpegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		// TODO: Define the module tx logic for this action
 		msg := types.NewMsgUnpeg(
 			address,
+			req.Instance,
 			req.MainchainAddress,
 			amount,
 			firstCosignerAddress,
@@ -143,9 +176,11 @@ func UnpegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 type RequestInvitationReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 	// TODO: Define more types if needed
-	Address              string `json:"address" yaml:"address"`
-	NewCosignerPublicKey string `json:"new_cosigner_public_key" yaml:"new_cosigner_public_key"`
-	FirstCosignerAddress string `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Address              string                   `json:"address" yaml:"address"`
+	Instance             string                   `json:"instance" yaml:"instance"`
+	Vault                string                   `json:"vault" yaml:"vault"`
+	NewCosignerPublicKey types.MainchainPublicKey `json:"new_cosigner_public_key" yaml:"new_cosigner_public_key"`
+	FirstCosignerAddress string                   `json:"first_cosigner_address" yaml:"first_cosigner_address"`
 }
 
 func RequestInvitationRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -169,7 +204,7 @@ func RequestInvitationRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFu
 			return
 		}
 
-		if len(strings.Trim(req.NewCosignerPublicKey, "")) == 0 {
+		if req.NewCosignerPublicKey.Empty() {
 			msg := fmt.Sprintf("invalid new_cosigner_public_key: %s", req.NewCosignerPublicKey)
 			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
 			return
@@ -185,6 +220,8 @@ func RequestInvitationRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFu
 		// TODO: Define the module tx logic for this action
 		msg := types.NewMsgRequestInvitation(
 			address,
+			req.Instance,
+			req.Vault,
 			req.NewCosignerPublicKey,
 			firstCosignerAddress,
 		)
@@ -193,6 +230,187 @@ func RequestInvitationRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFu
 	}
 }
 
+type RequestRemovalReq struct {
+	BaseReq              rest.BaseReq             `json:"base_req" yaml:"base_req"`
+	Address              string                   `json:"address" yaml:"address"`
+	Instance             string                   `json:"instance" yaml:"instance"`
+	CosignerPublicKey    types.MainchainPublicKey `json:"cosigner_public_key" yaml:"cosigner_public_key"`
+	FirstCosignerAddress string                   `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+}
+
+func RequestRemovalRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RequestRemovalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		address, err := sdk.ValAddressFromBech32(req.Address)
+		if err != nil {
+			msg := fmt.Sprintf("failed to parse address: %s", req.Address)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		firstCosignerAddress, err := sdk.ValAddressFromBech32(req.FirstCosignerAddress)
+		if err != nil {
+			msg := fmt.Sprintf("failed to parse first_cosigner_address: %s", req.FirstCosignerAddress)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		msg := types.NewMsgRequestRemoval(
+			address,
+			req.Instance,
+			req.CosignerPublicKey,
+			firstCosignerAddress,
+		)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type RequestVaultTransferReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Address   string       `json:"address" yaml:"address"`
+	Instance  string       `json:"instance" yaml:"instance"`
+	FromVault string       `json:"from_vault" yaml:"from_vault"`
+	ToVault   string       `json:"to_vault" yaml:"to_vault"`
+	Amount    string       `json:"amount" yaml:"amount"`
+}
+
+func RequestVaultTransferRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RequestVaultTransferReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		address, err := sdk.ValAddressFromBech32(req.Address)
+		if err != nil {
+			msg := fmt.Sprintf("failed to parse address: %s", req.Address)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		amount, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRequestVaultTransfer(address, req.Instance, req.FromVault, req.ToVault, amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type CancelUnpegReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Address string       `json:"address" yaml:"address"`
+	ID      string       `json:"id" yaml:"id"`
+}
+
+func CancelUnpegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CancelUnpegReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		address, err := sdk.AccAddressFromBech32(req.Address)
+		if err != nil {
+			msg := fmt.Sprintf("failed to parse address: %s", req.Address)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		id, ok := rest.ParseUint64OrReturnBadRequest(w, req.ID)
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgCancelUnpeg(address, id)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type VetoUnpegReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Guardian string       `json:"guardian" yaml:"guardian"`
+	ID       string       `json:"id" yaml:"id"`
+}
+
+func VetoUnpegRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req VetoUnpegReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		guardian, err := sdk.AccAddressFromBech32(req.Guardian)
+		if err != nil {
+			msg := fmt.Sprintf("failed to parse guardian: %s", req.Guardian)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, msg)
+			return
+		}
+
+		id, ok := rest.ParseUint64OrReturnBadRequest(w, req.ID)
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgVetoUnpeg(guardian, id)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
 /*
 // Action TX body
 type <Action>Req struct {
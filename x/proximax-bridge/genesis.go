@@ -13,7 +13,97 @@ import (
 // and the keeper's address to pubkey map
 func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) []abci.ValidatorUpdate {
 	// TODO: Define logic for when you would like to initalize a new genesis
-	k.SetParams(ctx, types.NewParams(data.MainchainMultisigAddress, data.Cosigners))
+	k.SetParams(ctx, types.NewParams(data.Instances, data.ConsensusNeeded, data.ClaimWeighting, data.ProphecyExpiry, data.FaultyClaimSlashFraction, data.MultisigApproval, data.ColdMultisigApproval, data.HotVaultLimits, data.Fees, data.FeeDistribution, data.LockFundsCost, data.Limits, data.VolumeWindow, data.TimeLock, data.AddressFilter, data.UnpegBatchWindow))
+	// the cosigners are kept out of the params
+	for _, instance := range data.Instances {
+		for _, cosigner := range instance.Cosigners {
+			k.SetCosigner(ctx, instance.Name, cosigner)
+		}
+	}
+
+	// an exported chain carries the cosigner set history of its instances,
+	// a new instance starts it with its genesis set
+	logged := make(map[string]bool)
+	for _, change := range data.CosignerSetChanges {
+		k.SetCosignerSetChange(ctx, change)
+		logged[change.Instance] = true
+	}
+	for _, instance := range data.Instances {
+		if !logged[instance.Name] {
+			k.AppendCosignerSetChange(ctx, instance.Name, types.CosignerSetChangeGenesis, "")
+		}
+	}
+
+	// pending transfers have been deducted from the exported balances of their source vaults already
+	for _, balance := range data.VaultBalances {
+		k.SetVaultBalance(ctx, balance.Instance, balance.Vault, balance.Balance)
+	}
+	for _, transfer := range data.VaultTransfers {
+		k.SetVaultTransfer(ctx, transfer)
+	}
+	for _, request := range data.SweepRequests {
+		k.SetSweepRequest(ctx, request)
+	}
+	for _, entry := range data.RewardPoints {
+		k.SetRewardPoints(ctx, entry.Validator, entry.Points)
+	}
+	for _, reimbursement := range data.LockFundsReimbursements {
+		k.SetLockFundsReimbursement(ctx, reimbursement.Validator, reimbursement.Outstanding)
+	}
+	for _, entry := range data.VolumeEntries {
+		k.SetVolumeEntry(ctx, entry)
+	}
+	// the coins of the queued unpegs are exported with the module account
+	for _, queued := range data.QueuedUnpegs {
+		k.SetQueuedUnpeg(ctx, queued)
+	}
+	for _, locked := range data.TimeLockedUnpegs {
+		k.SetTimeLockedUnpeg(ctx, locked)
+	}
+	for _, batch := range data.UnpegBatches {
+		k.SetUnpegBatch(ctx, batch)
+	}
+	// the queued pegs are minted when released, their deposits are in the exported vault balances already
+	for _, queued := range data.QueuedPegs {
+		k.SetQueuedPeg(ctx, queued)
+	}
+
+	// the cosigner rotation picks up where the exported chain left it
+	for _, set := range data.EligibleCosigners {
+		k.SetEligibleCosigners(ctx, set)
+	}
+	for _, inactive := range data.InactiveCosigners {
+		k.SetInactiveCosigner(ctx, inactive)
+	}
+	for _, invitation := range data.CosignerInvitations {
+		k.SetCosignerInvitation(ctx, invitation)
+	}
+	for _, removal := range data.CosignerRemovals {
+		k.SetCosignerRemoval(ctx, removal)
+	}
+	for _, request := range data.PendingInviteRequests {
+		if err := k.SetPendingInviteRequest(ctx, request.Instance, request.Vault, request.MainchainTxHash, request.Address, request.MainchainPublicKey); err != nil {
+			panic(err)
+		}
+	}
+	for _, request := range data.PendingRemovalRequests {
+		if err := k.SetPendingRemovalRequest(ctx, request.Instance, request.MainchainTxHash, request.Address, request.MainchainPublicKey); err != nil {
+			panic(err)
+		}
+	}
+	// the coins of the pending unpegs were burned when they were requested
+	for _, unpeg := range data.PendingUnpegs {
+		k.SetPendingUnpeg(ctx, unpeg)
+	}
+	// open prophecies keep their claims, so that they can still reach consensus or expire
+	for _, prophecy := range data.OpenProphecies {
+		k.SetOpenProphecy(ctx, prophecy)
+	}
+	for _, claim := range data.FaultyClaims {
+		if err := k.SetFaultyClaim(ctx, claim); err != nil {
+			panic(err)
+		}
+	}
 
 	return []abci.ValidatorUpdate{}
 }
@@ -34,6 +124,8 @@ func ExportGenesis(ctx sdk.Context, k Keeper) (data types.GenesisState) {
 
 	// TODO: Define logic for exporting state
 	return types.NewGenesisState(
-		params.MainchainMultisigAddress, params.Cosigners,
+		params.Instances, params.ConsensusNeeded, params.ClaimWeighting, params.ProphecyExpiry, params.FaultyClaimSlashFraction, params.MultisigApproval, params.ColdMultisigApproval, params.HotVaultLimits, params.Fees, params.FeeDistribution, params.LockFundsCost, params.Limits, params.VolumeWindow, params.TimeLock, params.AddressFilter, params.UnpegBatchWindow,
+		k.GetCosignerSetChanges(ctx, ""), k.GetVaultBalances(ctx, ""), k.GetVaultTransfers(ctx, ""), k.GetSweepRequests(ctx, ""), k.GetAllRewardPoints(ctx), k.GetLockFundsReimbursements(ctx), k.GetVolumeEntries(ctx), k.GetQueuedUnpegs(ctx), k.GetTimeLockedUnpegs(ctx), k.GetUnpegBatches(ctx), k.GetQueuedPegs(ctx),
+		k.GetAllEligibleCosigners(ctx), k.GetInactiveCosigners(ctx), k.GetCosignerInvitations(ctx, ""), k.GetCosignerRemovals(ctx, ""), k.GetPendingInviteRequests(ctx), k.GetPendingRemovalRequests(ctx), k.GetPendingUnpegs(ctx), k.GetOpenProphecies(ctx), k.GetFaultyClaims(ctx, nil),
 	)
 }
@@ -0,0 +1,79 @@
+package proximax_bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	// the set of a chain started from a genesis is logged, an imported chain would log it otherwise
+	input.Keeper.AppendCosignerSetChange(input.Ctx, testutil.TestInstance, types.CosignerSetChangeGenesis, "")
+
+	// the rotation records the eligible set, and the fourth validator has its invitation to the hot vault announced
+	input.Keeper.RotateCosigners(input.Ctx)
+	input.Keeper.SetCosignerInvitation(input.Ctx, types.CosignerInvitation{Instance: testutil.TestInstance, Vault: "hot", ValidatorAddress: v[3], Reason: types.CosignerSetChangeInvitation})
+	newKey := testutil.MainchainPublicKeyFromSeed(4)
+	require.NoError(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[0])))
+	require.NoError(t, deliver(input, types.NewMsgPendingRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[0], cosigners[0].MainchainPublicKey, testutil.MainchainTxHashFromSeed(1))))
+
+	// a cosigner leaves, announced by another one
+	require.NoError(t, deliver(input, types.NewMsgRequestRemoval(v[0], testutil.TestInstance, cosigners[0].MainchainPublicKey, v[1])))
+	require.NoError(t, deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, cosigners[0].MainchainPublicKey, v[1], cosigners[1].MainchainPublicKey, testutil.MainchainTxHashFromSeed(2))))
+
+	// a peg is claimed by a single validator, and an earlier claim was faulty
+	claim := types.NewMsgPegClaim(testutil.AccAddressFromSeed(1), testutil.TestInstance, "hot", testutil.MainchainTxHashFromSeed(3), testutil.MainchainAddressFromSeed(9), xpx(100), 0, v[0])
+	require.NoError(t, deliver(input, claim))
+	faulty := claim
+	faulty.Amount = xpx(200)
+	require.NoError(t, input.Keeper.SetFaultyClaim(input.Ctx, types.FaultyClaim{
+		Instance:         testutil.TestInstance,
+		MainchainTxHash:  testutil.MainchainTxHashFromSeed(4),
+		ValidatorAddress: v[1],
+		Claim:            faulty,
+		Consensus:        claim,
+		SlashFraction:    input.Keeper.GetParams(input.Ctx).FaultyClaimSlashFraction,
+		Height:           input.Ctx.BlockHeight(),
+	}))
+
+	// an unpeg is paid out and waits to be announced
+	sender := testutil.AccAddressFromSeed(2)
+	input.Keeper.SetVaultBalance(input.Ctx, testutil.TestInstance, "hot", xpx(1000))
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, xpx(100)))
+	require.NoError(t, input.SupplyKeeper.SendCoinsFromModuleToAccount(input.Ctx, types.ModuleName, sender, xpx(100)))
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(100), v[0])))
+	input.Keeper.ReleaseQueuedUnpegs(input.Ctx)
+
+	// a cosigner leaves the bonded set
+	input.Keeper.Hooks().AfterValidatorRemoved(input.Ctx, nil, v[2])
+
+	exported := ExportGenesis(input.Ctx, input.Keeper)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.NotEmpty(t, exported.EligibleCosigners)
+	require.Equal(t, []types.InactiveCosigner{{ValidatorAddress: v[2], Height: input.Ctx.BlockHeight()}}, exported.InactiveCosigners)
+	require.NotEmpty(t, exported.CosignerInvitations)
+	require.Len(t, exported.CosignerRemovals, 2)
+	require.Len(t, exported.PendingInviteRequests, 1)
+	require.Len(t, exported.PendingRemovalRequests, 1)
+	require.Len(t, exported.PendingUnpegs, 1)
+	require.NotEmpty(t, exported.OpenProphecies)
+	require.Len(t, exported.FaultyClaims, 1)
+
+	bz := types.ModuleCdc.MustMarshalJSON(exported)
+	var imported types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(bz, &imported)
+
+	// the state is the same once imported into a new chain
+	other := testutil.CreateTestInput(t, 25, 25, 25, 25)
+	InitGenesis(other.Ctx, other.Keeper, imported)
+	require.Equal(t, string(bz), string(types.ModuleCdc.MustMarshalJSON(ExportGenesis(other.Ctx, other.Keeper))))
+	require.False(t, other.Keeper.IsCosignerActive(other.Ctx, v[2]))
+	prophecy, found := other.Keeper.GetProphecyStatus(other.Ctx, types.GetPegClaimProphecyID(claim))
+	require.True(t, found)
+	require.Len(t, prophecy.Claims, 1)
+}
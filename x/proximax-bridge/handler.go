@@ -3,7 +3,6 @@ package proximax_bridge
 import (
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -40,6 +39,28 @@ func NewHandler(cdc *codec.Codec, accountKeeper auth.AccountKeeper, bridgeKeeper
 			return handleMsgConfirmedInvitation(ctx, cdc, bridgeKeeper, msg)
 		case MsgNotCosignedClaim:
 			return handleMsgNotCosignedClaim(ctx, cdc, accountKeeper, bridgeKeeper, msg)
+		case MsgRequestRemoval:
+			return handleMsgRequestRemoval(ctx, cdc, bridgeKeeper, msg)
+		case MsgPendingRequestRemoval:
+			return handleMsgPendingRequestRemoval(ctx, cdc, bridgeKeeper, msg)
+		case MsgConfirmedRemoval:
+			return handleMsgConfirmedRemoval(ctx, cdc, bridgeKeeper, msg)
+		case MsgRequestVaultTransfer:
+			return handleMsgRequestVaultTransfer(ctx, cdc, bridgeKeeper, msg)
+		case MsgRecordVaultTransfer:
+			return handleMsgRecordVaultTransfer(ctx, cdc, bridgeKeeper, msg)
+		case MsgConfirmedVaultTransfer:
+			return handleMsgConfirmedVaultTransfer(ctx, cdc, bridgeKeeper, msg)
+		case MsgLockFundsClaim:
+			return handleMsgLockFundsClaim(ctx, cdc, bridgeKeeper, msg)
+		case MsgCancelUnpeg:
+			return handleMsgCancelUnpeg(ctx, cdc, bridgeKeeper, msg)
+		case MsgVetoUnpeg:
+			return handleMsgVetoUnpeg(ctx, cdc, bridgeKeeper, msg)
+		case MsgRecordUnpegBatch:
+			return handleMsgRecordUnpegBatch(ctx, cdc, bridgeKeeper, msg)
+		case MsgConfirmedUnpegBatch:
+			return handleMsgConfirmedUnpegBatch(ctx, cdc, bridgeKeeper, msg)
 
 		//Example:
 		// case MsgSet<Action>:
@@ -52,12 +73,49 @@ func NewHandler(cdc *codec.Codec, accountKeeper auth.AccountKeeper, bridgeKeeper
 	}
 }
 
+// getInstance returns the bridge instance a message is sent to
+func getInstance(ctx sdk.Context, bridgeKeeper Keeper, name string) (types.BridgeInstance, error) {
+	instance, found := bridgeKeeper.GetInstance(ctx, name)
+	if !found {
+		return types.BridgeInstance{}, sdkerrors.Wrap(types.ErrInvalidInstance, name)
+	}
+	return instance, nil
+}
+
+// checkFirstCosigner checks that the validator announcing a multisig modification is an active cosigner
+// of the vault, and that it announces with the mainchain public key of its own cosigner
+func checkFirstCosigner(ctx sdk.Context, bridgeKeeper Keeper, instance types.BridgeInstance, vault string, address sdk.ValAddress, mainchainPublicKey types.MainchainPublicKey) error {
+	if !bridgeKeeper.IsActiveVaultCosigner(ctx, instance.Name, vault, address) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not an active cosigner of vault %s", address, vault)
+	}
+	if cosigner, _ := instance.GetCosignerOf(address.String()); cosigner.MainchainPublicKey != mainchainPublicKey {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the public key of the cosigner of %s", mainchainPublicKey, address)
+	}
+	return nil
+}
+
+// getVault returns the vault of the bridge instance a message refers to
+func getVault(instance types.BridgeInstance, name string) (types.Vault, error) {
+	vault, found := instance.GetVault(name)
+	if !found {
+		return types.Vault{}, sdkerrors.Wrapf(types.ErrInvalidVault, "instance %s has no vault %s", instance.Name, name)
+	}
+	return vault, nil
+}
+
 func handleMsgPeg(
 	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgPeg,
 ) (*sdk.Result, error) {
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if err := instance.ValidateCoins(msg.Amount); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrUnmappedDenom, err.Error())
+	}
 
-	var consumed int64 = 0
-	pegRecord, err := bridgeKeeper.GetPegRecord(ctx, msg.MainchainTxHash)
+	consumed := sdk.Coins{}
+	pegRecord, err := bridgeKeeper.GetPegRecord(ctx, msg.Instance, msg.MainchainTxHash)
 	if err == nil {
 		var totalAmount int64 = 0
 		for _, coin := range msg.Amount {
@@ -68,9 +126,7 @@ func handleMsgPeg(
 			err = errors.New(fmt.Sprintf("Full amount of transaction has been pegged: %s", msg.MainchainTxHash))
 			return nil, err
 		}
-		for _, coin := range pegRecord.Consumed {
-			consumed += coin.Amount.Int64()
-		}
+		consumed = pegRecord.Consumed
 	}
 
 	// Send to relayer
@@ -82,10 +138,11 @@ func handleMsgPeg(
 		),
 		sdk.NewEvent(
 			types.EventTypePeg,
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
 			sdk.NewAttribute(types.AttributeKeyCosmosReceiver, msg.Address.String()),
-			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, msg.MainchainTxHash),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, msg.MainchainTxHash.String()),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
-			sdk.NewAttribute(types.AttributeKeyConsumed, strconv.FormatInt(consumed, 10)),
+			sdk.NewAttribute(types.AttributeKeyConsumed, consumed.String()),
 		),
 	})
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
@@ -95,7 +152,19 @@ func handleMsgPeg(
 func handleMsgPegClaim(
 	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgPegClaim,
 ) (*sdk.Result, error) {
-
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if err := instance.ValidateCoins(msg.Amount); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrUnmappedDenom, err.Error())
+	}
+	if _, err := getVault(instance, msg.Vault); err != nil {
+		return nil, err
+	}
+	if err := bridgeKeeper.GetParams(ctx).CheckPegClaim(msg); err != nil {
+		return nil, err
+	}
 	status, err := bridgeKeeper.ProcessPegClaim(ctx, msg)
 	if err != nil {
 		return nil, err
@@ -105,32 +174,12 @@ func handleMsgPegClaim(
 			return nil, err
 		}
 
-		// calculate consumed
-		consumed := sdk.Coins{}
-		pegRecord, err := bridgeKeeper.GetPegRecord(ctx, msg.MainchainTxHash)
-		fmt.Printf("GetPegRecord: %s, %+v, %+v\n", msg.MainchainTxHash, pegRecord, err)
-		if err != nil {
-			//first time
-			consumed = msg.Amount
-		} else {
-			m := make(map[string]int64)
-			for _, coin := range pegRecord.Consumed {
-				m[coin.Denom] = coin.Amount.Int64()
-			}
-			for _, coin := range msg.Amount {
-				val, ok := m[coin.Denom]
-				if !ok {
-					val = 0
-				}
-				m[coin.Denom] = val + coin.Amount.Int64()
-			}
-			for denom, amount := range m {
-				coin := sdk.Coin{Denom: denom, Amount: sdk.NewInt(amount)}
-				consumed = append(consumed, coin)
-			}
+		// consumed per denom, sorted so the relayers can parse it back from the peg event
+		consumed := msg.Amount
+		if pegRecord, err := bridgeKeeper.GetPegRecord(ctx, msg.Instance, msg.MainchainTxHash); err == nil {
+			consumed = pegRecord.Consumed.Add(msg.Amount...)
 		}
-		fmt.Printf("SetPegRecord: %s, %+v, %d\n", msg.MainchainTxHash, consumed, msg.Remainning)
-		bridgeKeeper.SetPegRecord(ctx, msg.MainchainTxHash, consumed, msg.Remainning)
+		bridgeKeeper.SetPegRecord(ctx, msg.Instance, msg.MainchainTxHash, consumed, msg.Remainning)
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
@@ -141,8 +190,11 @@ func handleMsgPegClaim(
 		),
 		sdk.NewEvent(
 			types.EventTypeCreateClaim,
-			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, msg.MainchainTxHash),
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, msg.Vault),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, msg.MainchainTxHash.String()),
 			sdk.NewAttribute(types.AttributeKeyCosmosReceiver, msg.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyProphecyID, types.GetPegClaimProphecyID(msg)),
 		),
 		sdk.NewEvent(
 			types.EventTypeProphecyStatus,
@@ -157,43 +209,123 @@ func handleMsgUnpeg(
 	ctx sdk.Context, cdc *codec.Codec, accountKeeper auth.AccountKeeper,
 	bridgeKeeper Keeper, msg MsgUnpeg,
 ) (*sdk.Result, error) {
-	err := bridgeKeeper.ProcessUnpeg(ctx, msg)
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
 	if err != nil {
 		return nil, err
 	}
-	ctx.EventManager().EmitEvents(sdk.Events{
+	if err := instance.ValidateCoins(msg.Amount); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrUnmappedDenom, err.Error())
+	}
+	// the relayers have to be able to announce the unpeg in mosaic amounts
+	if err := instance.CheckMainchainAmounts(msg.Amount); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+	for _, recipient := range msg.GetRecipients() {
+		if err := recipient.MainchainAddress.ValidateFor(instance.MainchainNetworkType); err != nil {
+			return nil, sdkerrors.Wrap(types.ErrInvalidMainchainAddress, err.Error())
+		}
+	}
+	if err := bridgeKeeper.GetParams(ctx).CheckUnpeg(msg); err != nil {
+		return nil, err
+	}
+	// the vault pays out to each recipient what it receives less the bridge fee
+	if _, _, err := bridgeKeeper.UnpegRecipientsFee(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	if err := bridgeKeeper.EscrowUnpeg(ctx, msg); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
 		),
-		sdk.NewEvent(
-			types.EventTypeUnpeg,
-			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.Address.String()),
-			sdk.NewAttribute(types.AttributeKeyMainchainAddress, msg.MainchainAddress),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
-			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, msg.FirstCosignerAddress.String()),
-		),
-	})
+	)
+	// large unpegs are held back for the guardians to veto before they join the withdrawal queue,
+	// which the end blocker releases in order, as far as the volume caps allow
+	if bridgeKeeper.GetParams(ctx).TimeLock.Applies(msg.Amount) {
+		bridgeKeeper.TimeLockUnpeg(ctx, msg)
+	} else {
+		bridgeKeeper.EnqueueUnpeg(ctx, msg)
+	}
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
 func handleMsgRecordUnpeg(ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRecordUnpeg) (*sdk.Result, error) {
-	bridgeKeeper.SetUnpegRecord(ctx, msg.MainchainTxHash, msg.Address, msg.Amount)
-	bridgeKeeper.SetCosigners(ctx, msg.MainchainTxHash, msg.FirstCosignerPublicKey)
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getVault(instance, msg.Vault); err != nil {
+		return nil, err
+	}
+	if _, err := bridgeKeeper.GetUnpegRecord(ctx, msg.Instance, msg.MainchainTxHash); err == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unpeg %s is recorded already", msg.MainchainTxHash)
+	}
+	// the first cosigner announces the aggregate with its own key
+	if cosigner, found := instance.GetCosigner(msg.FirstCosignerPublicKey); !found || cosigner.Vault != msg.Vault || cosigner.ValidatorAddress != msg.ValidatorAddress.String() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the key of a cosigner of %s in vault %s", msg.FirstCosignerPublicKey, msg.ValidatorAddress, msg.Vault)
+	}
+	// only an unpeg the zone asked the validator to announce is recorded, and its initiator rewarded once
+	if _, found := bridgeKeeper.ResolvePendingUnpeg(ctx, msg); !found {
+		return nil, sdkerrors.Wrapf(types.ErrPendingUnpegNotFound, "%s announced by %s", msg.Amount, msg.ValidatorAddress)
+	}
+	bridgeKeeper.CreditInitiator(ctx, msg.ValidatorAddress)
+	if err := bridgeKeeper.SetUnpegRecord(ctx, msg.Instance, msg.Vault, msg.MainchainTxHash, msg.Address, msg.Amount, msg.Recipients); err != nil {
+		return nil, err
+	}
+	if err := bridgeKeeper.SetCosigners(ctx, msg.Instance, msg.MainchainTxHash, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
 func handleMsgNotifyCosigned(ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgNotifyCosigned) (*sdk.Result, error) {
-	bridgeKeeper.SetCosigners(ctx, msg.MainchainTxHash, msg.CosignerPublicKey)
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	// a validator reports its own cosignatures, which are credited once per transaction the zone asked for
+	cosigner, found := instance.GetCosigner(msg.CosignerPublicKey)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrCosignerNotFound, msg.CosignerPublicKey.String())
+	}
+	if cosigner.ValidatorAddress != msg.Address.String() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "cosigner %s belongs to %s", msg.CosignerPublicKey, cosigner.ValidatorAddress)
+	}
+	if err := bridgeKeeper.SetCosigners(ctx, msg.Instance, msg.MainchainTxHash, msg.CosignerPublicKey); err != nil {
+		return nil, err
+	}
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
 func handleMsgRequestInvitation(
 	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRequestInvitation,
 ) (*sdk.Result, error) {
-	param := bridgeKeeper.GetParams(ctx)
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	vault, err := getVault(instance, msg.Vault)
+	if err != nil {
+		return nil, err
+	}
+	// only a validator invited by the rotation or by governance can request to join, to the vault it was invited to
+	invitation, found := bridgeKeeper.GetCosignerInvitation(ctx, msg.Instance, msg.Address)
+	if !found || invitation.Vault != vault.Name {
+		return nil, sdkerrors.Wrapf(types.ErrInvitationNotFound, "%s is not invited to vault %s", msg.Address, vault.Name)
+	}
+	if !invitation.MainchainPublicKey.Empty() && invitation.MainchainPublicKey != msg.NewCosignerPublicKey {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is invited with public key %s", msg.Address, invitation.MainchainPublicKey)
+	}
+	if !bridgeKeeper.IsActiveVaultCosigner(ctx, msg.Instance, vault.Name, msg.FirstCosignerAddress) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not an active cosigner of vault %s", msg.FirstCosignerAddress, vault.Name)
+	}
+	invitation.MainchainPublicKey = msg.NewCosignerPublicKey
+	bridgeKeeper.SetCosignerInvitation(ctx, invitation)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
@@ -203,9 +335,11 @@ func handleMsgRequestInvitation(
 		),
 		sdk.NewEvent(
 			types.EventTypeInvitation,
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, vault.Name),
 			sdk.NewAttribute(types.AttributeKeyCosmosAccount, msg.Address.String()),
-			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, param.MainchainMultisigAddress),
-			sdk.NewAttribute(types.AttributeKeyNewCosignerPublicKey, msg.NewCosignerPublicKey),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyNewCosignerPublicKey, msg.NewCosignerPublicKey.String()),
 			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, msg.FirstCosignerAddress.String()),
 		),
 	})
@@ -215,15 +349,33 @@ func handleMsgRequestInvitation(
 func handleMsgPendingRequestInvitation(
 	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgPendingRequestInvitation,
 ) (*sdk.Result, error) {
-	fmt.Printf("handleMsgPendingRequestInvitation %+v\n", msg)
-	bridgeKeeper.SetPendingInviteRequest(ctx, msg.TxHash, msg.Address, msg.NewCosignerPublicKey)
-	bridgeKeeper.SetCosigners(ctx, msg.TxHash, msg.FirstCosignerPublicKey)
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getVault(instance, msg.Vault); err != nil {
+		return nil, err
+	}
+	if err := checkFirstCosigner(ctx, bridgeKeeper, instance, msg.Vault, msg.FirstCosignerAddress, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
+	// the announced modification has to invite the key the validator requested to join with
+	invitation, found := bridgeKeeper.GetCosignerInvitation(ctx, msg.Instance, msg.Address)
+	if !found || invitation.Vault != msg.Vault || invitation.MainchainPublicKey.Empty() || invitation.MainchainPublicKey != msg.NewCosignerPublicKey {
+		return nil, sdkerrors.Wrapf(types.ErrInvitationNotFound, "%s did not request to join vault %s with public key %s", msg.Address, msg.Vault, msg.NewCosignerPublicKey)
+	}
+	if err := bridgeKeeper.SetPendingInviteRequest(ctx, msg.Instance, msg.Vault, msg.TxHash, msg.Address, msg.NewCosignerPublicKey); err != nil {
+		return nil, err
+	}
+	if err := bridgeKeeper.SetCosigners(ctx, msg.Instance, msg.TxHash, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.FirstCosignerAddress.String()),
 		),
 	})
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
@@ -232,9 +384,156 @@ func handleMsgPendingRequestInvitation(
 func handleMsgConfirmedInvitation(
 	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgConfirmedInvitation,
 ) (*sdk.Result, error) {
-	request, err := bridgeKeeper.GetPendingRequest(ctx, msg.TxHash)
+	if _, err := bridgeKeeper.GetPendingRequest(ctx, msg.Instance, msg.TxHash); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvitationNotFound, err.Error())
+	}
+	// every relayer reports the confirmation, the cosigner is added once they reach consensus
+	claim := types.NewConfirmedClaim(types.ClaimTypeConfirmedInvitation, msg.Instance, msg.TxHash)
+	status, err := bridgeKeeper.ProcessConfirmedClaim(ctx, claim, msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if status.Text == oracle.SuccessStatusText {
+		if err := bridgeKeeper.ProcessSuccessfulConfirmedInvitation(ctx, msg.Instance, msg.TxHash); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRequestRemoval(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRequestRemoval,
+) (*sdk.Result, error) {
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	cosigner, found := instance.GetCosigner(msg.CosignerPublicKey)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrCosignerNotFound, msg.CosignerPublicKey.String())
+	}
+	if cosigner.ValidatorAddress != msg.Address.String() {
+		// an inactive cosigner may be offline and one the zone scheduled to remove may not cooperate,
+		// any active cosigner can request their removal
+		validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		removal, requested := bridgeKeeper.GetCosignerRemoval(ctx, msg.Instance, cosigner.MainchainPublicKey)
+		scheduled := requested && !removal.Requester.Empty()
+		if (bridgeKeeper.IsCosignerActive(ctx, validator) && !scheduled) || !bridgeKeeper.IsActiveCosigner(ctx, msg.Instance, msg.Address) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "cosigner %s belongs to %s", msg.CosignerPublicKey, cosigner.ValidatorAddress)
+		}
+	}
+	vault, err := getVault(instance, cosigner.Vault)
+	if err != nil {
+		return nil, err
+	}
+	if !bridgeKeeper.IsActiveVaultCosigner(ctx, msg.Instance, vault.Name, msg.FirstCosignerAddress) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not an active cosigner of vault %s", msg.FirstCosignerAddress, vault.Name)
+	}
+	// a removal scheduled by the zone keeps its reason and its expiry
+	removal, found := bridgeKeeper.GetCosignerRemoval(ctx, msg.Instance, cosigner.MainchainPublicKey)
+	if !found {
+		removal.Reason = types.CosignerSetChangeRemoval
+	}
+	validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	removal.Instance = msg.Instance
+	removal.Vault = vault.Name
+	removal.ValidatorAddress = validator
+	removal.MainchainPublicKey = cosigner.MainchainPublicKey
+	removal.MainchainMultisigAddress = vault.MainchainMultisigAddress
+	bridgeKeeper.SetCosignerRemoval(ctx, removal)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeRemoval,
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, vault.Name),
+			sdk.NewAttribute(types.AttributeKeyCosmosAccount, msg.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyCosignerPublicKey, msg.CosignerPublicKey.String()),
+			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, msg.FirstCosignerAddress.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgPendingRequestRemoval(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgPendingRequestRemoval,
+) (*sdk.Result, error) {
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	cosigner, found := instance.GetCosigner(msg.CosignerPublicKey)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrCosignerNotFound, msg.CosignerPublicKey.String())
+	}
+	vault, err := getVault(instance, cosigner.Vault)
 	if err != nil {
-		bridgeKeeper.AddNewCosigner(ctx, request.Address, request.MainchainPublicKey)
+		return nil, err
+	}
+	if err := checkFirstCosigner(ctx, bridgeKeeper, instance, vault.Name, msg.FirstCosignerAddress, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
+	// the announced modification has to remove a cosigner whose removal was requested, from the multisig it was requested from
+	removal, found := bridgeKeeper.GetCosignerRemoval(ctx, msg.Instance, msg.CosignerPublicKey)
+	if !found || removal.Vault != vault.Name || removal.MainchainMultisigAddress != vault.MainchainMultisigAddress || removal.ValidatorAddress.String() != cosigner.ValidatorAddress {
+		return nil, sdkerrors.Wrapf(types.ErrRemovalNotFound, "removal of %s from %s was not requested", msg.CosignerPublicKey, vault.MainchainMultisigAddress)
+	}
+	if err := bridgeKeeper.SetPendingRemovalRequest(ctx, msg.Instance, msg.TxHash, removal.ValidatorAddress, msg.CosignerPublicKey); err != nil {
+		return nil, err
+	}
+	// the removal was announced, it is not handed over to another cosigner anymore
+	removal.ExpiryHeight = 0
+	bridgeKeeper.SetCosignerRemoval(ctx, removal)
+	if err := bridgeKeeper.SetCosigners(ctx, msg.Instance, msg.TxHash, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.FirstCosignerAddress.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgConfirmedRemoval(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgConfirmedRemoval,
+) (*sdk.Result, error) {
+	if _, err := bridgeKeeper.GetPendingRemovalRequest(ctx, msg.Instance, msg.TxHash); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrRemovalNotFound, err.Error())
+	}
+	// every relayer reports the confirmation, the cosigner is removed once they reach consensus
+	claim := types.NewConfirmedClaim(types.ClaimTypeConfirmedRemoval, msg.Instance, msg.TxHash)
+	status, err := bridgeKeeper.ProcessConfirmedClaim(ctx, claim, msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if status.Text == oracle.SuccessStatusText {
+		if err := bridgeKeeper.ProcessSuccessfulConfirmedRemoval(ctx, msg.Instance, msg.TxHash); err != nil {
+			return nil, err
+		}
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
@@ -251,6 +550,9 @@ func handleMsgNotCosignedClaim(
 	ctx sdk.Context, cdc *codec.Codec, accountKeeper auth.AccountKeeper,
 	bridgeKeeper Keeper, msg MsgNotCosignedClaim,
 ) (*sdk.Result, error) {
+	if _, err := getInstance(ctx, bridgeKeeper, msg.Instance); err != nil {
+		return nil, err
+	}
 	status, err := bridgeKeeper.ProcessNotCosignedClaim(ctx, msg)
 	if err != nil {
 		return nil, err
@@ -272,3 +574,261 @@ func handleMsgNotCosignedClaim(
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 
 }
+
+func handleMsgRequestVaultTransfer(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRequestVaultTransfer,
+) (*sdk.Result, error) {
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if err := instance.ValidateCoins(msg.Amount); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrUnmappedDenom, err.Error())
+	}
+	if err := instance.CheckMainchainAmounts(msg.Amount); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+	fromVault, err := getVault(instance, msg.FromVault)
+	if err != nil {
+		return nil, err
+	}
+	toVault, err := getVault(instance, msg.ToVault)
+	if err != nil {
+		return nil, err
+	}
+	// the transfer is announced from the source multisig, so one of its cosigners requests it
+	if !bridgeKeeper.IsActiveVaultCosigner(ctx, msg.Instance, fromVault.Name, msg.Address) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not an active cosigner of vault %s", msg.Address, fromVault.Name)
+	}
+	if !bridgeKeeper.GetVaultBalance(ctx, msg.Instance, fromVault.Name).IsAllGTE(msg.Amount) {
+		return nil, sdkerrors.Wrapf(types.ErrInsufficientVault, "vault %s of instance %s holds less than %s", fromVault.Name, msg.Instance, msg.Amount)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeVaultTransfer,
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyFromVault, fromVault.Name),
+			sdk.NewAttribute(types.AttributeKeyToVault, toVault.Name),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, fromVault.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipientAddress, toVault.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, msg.Address.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRecordVaultTransfer(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRecordVaultTransfer,
+) (*sdk.Result, error) {
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getVault(instance, msg.ToVault); err != nil {
+		return nil, err
+	}
+	if cosigner, found := instance.GetCosignerOf(msg.Address.String()); !found || cosigner.Vault != msg.FromVault {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not a cosigner of vault %s", msg.Address, msg.FromVault)
+	}
+	// a sweep aggregate has to be the one the zone asked for
+	if msg.Sweep {
+		request, found := bridgeKeeper.GetSweepRequest(ctx, msg.Instance, msg.FromVault)
+		if !found || request.ToVault != msg.ToVault || !request.Amount.IsEqual(msg.Amount) {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "no sweep of %s from vault %s to vault %s was asked for", msg.Amount, msg.FromVault, msg.ToVault)
+		}
+	}
+
+	transfer := types.VaultTransfer{
+		Instance:        msg.Instance,
+		MainchainTxHash: msg.MainchainTxHash,
+		FromVault:       msg.FromVault,
+		ToVault:         msg.ToVault,
+		Amount:          msg.Amount,
+		Sweep:           msg.Sweep,
+	}
+	if err := bridgeKeeper.RecordVaultTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+	if msg.Sweep {
+		bridgeKeeper.DeleteSweepRequest(ctx, msg.Instance, msg.FromVault)
+	}
+	if err := bridgeKeeper.SetCosigners(ctx, msg.Instance, msg.MainchainTxHash, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgConfirmedVaultTransfer(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgConfirmedVaultTransfer,
+) (*sdk.Result, error) {
+	if !bridgeKeeper.IsActiveCosigner(ctx, msg.Instance, msg.Address) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not an active cosigner of instance %s", msg.Address, msg.Instance)
+	}
+	if _, found := bridgeKeeper.GetVaultTransfer(ctx, msg.Instance, msg.TxHash); !found {
+		return nil, sdkerrors.Wrap(types.ErrVaultTransferNotFound, msg.TxHash.String())
+	}
+	// every relayer reports the confirmation, the destination vault is credited once they reach consensus
+	claim := types.NewConfirmedClaim(types.ClaimTypeConfirmedTransfer, msg.Instance, msg.TxHash)
+	status, err := bridgeKeeper.ProcessConfirmedClaim(ctx, claim, msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if status.Text == oracle.SuccessStatusText {
+		bridgeKeeper.CompleteVaultTransfer(ctx, msg.Instance, msg.TxHash, true)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgLockFundsClaim(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgLockFundsClaim,
+) (*sdk.Result, error) {
+	if _, err := getInstance(ctx, bridgeKeeper, msg.Instance); err != nil {
+		return nil, err
+	}
+	status, err := bridgeKeeper.ProcessLockFundsClaim(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if status.Text == oracle.SuccessStatusText {
+		if err := bridgeKeeper.ProcessSuccessfulLockFundsClaim(ctx, status.FinalClaim); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+		sdk.NewEvent(
+			types.EventTypeCreateClaim,
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, msg.TxHash.String()),
+			sdk.NewAttribute(types.AttributeKeyClaimType, types.ClaimTypeLockFunds),
+			sdk.NewAttribute(types.AttributeKeyOutcome, msg.Outcome),
+		),
+		sdk.NewEvent(
+			types.EventTypeProphecyStatus,
+			sdk.NewAttribute(types.AttributeKeyStatus, status.Text.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelUnpeg(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgCancelUnpeg,
+) (*sdk.Result, error) {
+	if err := bridgeKeeper.CancelQueuedUnpeg(ctx, msg.Address, msg.ID); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgVetoUnpeg(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgVetoUnpeg,
+) (*sdk.Result, error) {
+	if err := bridgeKeeper.VetoUnpeg(ctx, msg.Guardian, msg.ID); err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Guardian.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRecordUnpegBatch(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgRecordUnpegBatch,
+) (*sdk.Result, error) {
+	instance, err := getInstance(ctx, bridgeKeeper, msg.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if batch, found := bridgeKeeper.GetUnpegBatch(ctx, msg.BatchID); !found || batch.Instance != msg.Instance {
+		return nil, sdkerrors.Wrapf(types.ErrUnpegBatchNotFound, "%d in instance %s", msg.BatchID, msg.Instance)
+	}
+	batch, err := bridgeKeeper.RecordUnpegBatch(ctx, msg.BatchID, msg.ValidatorAddress, msg.MainchainTxHash)
+	if err != nil {
+		return nil, err
+	}
+	// the initiator is rewarded once per batch, and only when it announced the aggregate with its own key
+	if cosigner, found := instance.GetCosigner(msg.FirstCosignerPublicKey); found && cosigner.Vault == batch.Vault && cosigner.ValidatorAddress == msg.ValidatorAddress.String() {
+		bridgeKeeper.CreditInitiator(ctx, msg.ValidatorAddress)
+	}
+	if err := bridgeKeeper.SetCosigners(ctx, msg.Instance, msg.MainchainTxHash, msg.FirstCosignerPublicKey); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddress.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgConfirmedUnpegBatch(
+	ctx sdk.Context, cdc *codec.Codec, bridgeKeeper Keeper, msg MsgConfirmedUnpegBatch,
+) (*sdk.Result, error) {
+	if !bridgeKeeper.IsActiveCosigner(ctx, msg.Instance, msg.Address) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not an active cosigner of instance %s", msg.Address, msg.Instance)
+	}
+	if batch, found := bridgeKeeper.GetUnpegBatchByTx(ctx, msg.Instance, msg.TxHash); !found || batch.Status != types.UnpegBatchStatusAnnounced {
+		return nil, sdkerrors.Wrapf(types.ErrUnpegBatchNotFound, "announced in %s", msg.TxHash)
+	}
+	// every relayer reports the confirmation, the batch is completed once they reach consensus
+	claim := types.NewConfirmedClaim(types.ClaimTypeConfirmedUnpegBatch, msg.Instance, msg.TxHash)
+	status, err := bridgeKeeper.ProcessConfirmedClaim(ctx, claim, msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	if status.Text == oracle.SuccessStatusText {
+		bridgeKeeper.CompleteUnpegBatch(ctx, msg.Instance, msg.TxHash)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,415 @@
+package proximax_bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// deliver runs a message through the handler the way a transaction does, its writes are dropped when it fails
+func deliver(input testutil.TestInput, msg sdk.Msg) error {
+	ctx, write := input.Ctx.CacheContext()
+	_, err := NewHandler(codec.New(), auth.AccountKeeper{}, input.Keeper)(ctx, msg)
+	if err == nil {
+		write()
+	}
+	return err
+}
+
+// createCosignerInput has four validators of equal power, the first three cosigning for the hot vault
+func createCosignerInput(t *testing.T) (testutil.TestInput, []types.Cosigner) {
+	input := testutil.CreateTestInput(t, 25, 25, 25, 25)
+	input.SetVaults(input.Validators[0], input.Validators[1], input.Validators[2])
+	instance, _ := input.Keeper.GetInstance(input.Ctx, testutil.TestInstance)
+	return input, instance.Cosigners
+}
+
+func TestRequestRemovalAuthorization(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+
+	// a validator cannot have an active cosigner removed
+	err := deliver(input, types.NewMsgRequestRemoval(v[3], testutil.TestInstance, cosigners[0].MainchainPublicKey, v[1]))
+	require.Error(t, err)
+	err = deliver(input, types.NewMsgRequestRemoval(v[1], testutil.TestInstance, cosigners[0].MainchainPublicKey, v[1]))
+	require.Error(t, err)
+
+	// nor pick a first cosigner from outside the vault
+	err = deliver(input, types.NewMsgRequestRemoval(v[0], testutil.TestInstance, cosigners[0].MainchainPublicKey, v[3]))
+	require.Error(t, err)
+	_, found := input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, cosigners[0].MainchainPublicKey)
+	require.False(t, found)
+
+	// a cosigner can leave
+	err = deliver(input, types.NewMsgRequestRemoval(v[0], testutil.TestInstance, cosigners[0].MainchainPublicKey, v[1]))
+	require.NoError(t, err)
+	removal, found := input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, cosigners[0].MainchainPublicKey)
+	require.True(t, found)
+	require.Equal(t, "hot", removal.Vault)
+	require.Equal(t, v[0], removal.ValidatorAddress)
+	require.Equal(t, testutil.MainchainAddressFromSeed(1), removal.MainchainMultisigAddress)
+	require.Equal(t, types.CosignerSetChangeRemoval, removal.Reason)
+}
+
+func TestPendingRemovalAuthorization(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	txHash := testutil.MainchainTxHashFromSeed(1)
+	removed := cosigners[0].MainchainPublicKey
+
+	// nothing can be announced before the removal is requested
+	err := deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, removed, v[1], cosigners[1].MainchainPublicKey, txHash))
+	require.Error(t, err)
+
+	require.NoError(t, deliver(input, types.NewMsgRequestRemoval(v[0], testutil.TestInstance, removed, v[1])))
+
+	// only an active cosigner of the vault announces, with its own key
+	err = deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, removed, v[3], testutil.MainchainPublicKeyFromSeed(4), txHash))
+	require.Error(t, err)
+	err = deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, removed, v[1], cosigners[2].MainchainPublicKey, txHash))
+	require.Error(t, err)
+	// the requested removal does not cover another cosigner
+	err = deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, cosigners[2].MainchainPublicKey, v[1], cosigners[1].MainchainPublicKey, txHash))
+	require.Error(t, err)
+	_, err = input.Keeper.GetPendingRemovalRequest(input.Ctx, testutil.TestInstance, txHash)
+	require.Error(t, err)
+
+	err = deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, removed, v[1], cosigners[1].MainchainPublicKey, txHash))
+	require.NoError(t, err)
+	request, err := input.Keeper.GetPendingRemovalRequest(input.Ctx, testutil.TestInstance, txHash)
+	require.NoError(t, err)
+	require.Equal(t, v[0], request.Address)
+	require.Equal(t, removed, request.MainchainPublicKey)
+}
+
+func TestPendingRemovalRejectedAfterMultisigChange(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	removed := cosigners[0].MainchainPublicKey
+
+	require.NoError(t, deliver(input, types.NewMsgRequestRemoval(v[0], testutil.TestInstance, removed, v[1])))
+	require.NoError(t, input.Keeper.SetMainchainMultisigAddress(input.Ctx, testutil.TestInstance, "hot", testutil.MainchainAddressFromSeed(3), false))
+
+	err := deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, removed, v[1], cosigners[1].MainchainPublicKey, testutil.MainchainTxHashFromSeed(1)))
+	require.Error(t, err)
+}
+
+func TestConfirmedRemovalNeedsConsensus(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	txHash := testutil.MainchainTxHashFromSeed(1)
+	removed := cosigners[0].MainchainPublicKey
+
+	// a confirmation of a transaction which was never announced is rejected
+	require.Error(t, deliver(input, types.NewMsgConfirmedRemoval(v[1], testutil.TestInstance, txHash)))
+
+	require.NoError(t, deliver(input, types.NewMsgRequestRemoval(v[0], testutil.TestInstance, removed, v[1])))
+	require.NoError(t, deliver(input, types.NewMsgPendingRequestRemoval(v[0], testutil.TestInstance, removed, v[1], cosigners[1].MainchainPublicKey, txHash)))
+
+	// a single relayer cannot evict the cosigner
+	require.NoError(t, deliver(input, types.NewMsgConfirmedRemoval(v[1], testutil.TestInstance, txHash)))
+	require.Error(t, deliver(input, types.NewMsgConfirmedRemoval(v[1], testutil.TestInstance, txHash)))
+	require.NoError(t, deliver(input, types.NewMsgConfirmedRemoval(v[2], testutil.TestInstance, txHash)))
+	_, found := input.Keeper.GetCosigner(input.Ctx, testutil.TestInstance, removed)
+	require.True(t, found)
+
+	require.NoError(t, deliver(input, types.NewMsgConfirmedRemoval(v[3], testutil.TestInstance, txHash)))
+	_, found = input.Keeper.GetCosigner(input.Ctx, testutil.TestInstance, removed)
+	require.False(t, found)
+	_, found = input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, removed)
+	require.False(t, found)
+	_, err := input.Keeper.GetPendingRemovalRequest(input.Ctx, testutil.TestInstance, txHash)
+	require.Error(t, err)
+
+	changes := input.Keeper.GetCosignerSetChanges(input.Ctx, testutil.TestInstance)
+	require.Equal(t, types.CosignerSetChangeRemoval, changes[len(changes)-1].Reason)
+	require.Equal(t, txHash, changes[len(changes)-1].MainchainTxHash)
+}
+
+func TestInvitationAuthorization(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	txHash := testutil.MainchainTxHashFromSeed(1)
+	newKey := testutil.MainchainPublicKeyFromSeed(4)
+
+	// a validator cannot invite itself
+	require.Error(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[0])))
+
+	input.Keeper.SetCosignerInvitation(input.Ctx, types.CosignerInvitation{Instance: testutil.TestInstance, Vault: "hot", ValidatorAddress: v[3], Reason: types.CosignerSetChangeInvitation})
+
+	// an invited validator joins the vault it is invited to, announced by one of its active cosigners
+	require.Error(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "cold", newKey, v[0])))
+	require.Error(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[3])))
+	require.NoError(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[0])))
+	// the key it requested with is fixed
+	require.Error(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", testutil.MainchainPublicKeyFromSeed(5), v[0])))
+
+	// the announced modification has to invite that key, announced by an active cosigner of the vault
+	err := deliver(input, types.NewMsgPendingRequestInvitation(v[3], testutil.TestInstance, "hot", testutil.MainchainPublicKeyFromSeed(5), v[0], cosigners[0].MainchainPublicKey, txHash))
+	require.Error(t, err)
+	err = deliver(input, types.NewMsgPendingRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[3], newKey, txHash))
+	require.Error(t, err)
+	err = deliver(input, types.NewMsgPendingRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[0], cosigners[0].MainchainPublicKey, txHash))
+	require.NoError(t, err)
+
+	// the cosigner joins once the confirmation reaches consensus
+	require.NoError(t, deliver(input, types.NewMsgConfirmedInvitation(v[0], testutil.TestInstance, txHash)))
+	require.NoError(t, deliver(input, types.NewMsgConfirmedInvitation(v[1], testutil.TestInstance, txHash)))
+	_, found := input.Keeper.GetCosigner(input.Ctx, testutil.TestInstance, newKey)
+	require.False(t, found)
+	require.NoError(t, deliver(input, types.NewMsgConfirmedInvitation(v[2], testutil.TestInstance, txHash)))
+	cosigner, found := input.Keeper.GetCosigner(input.Ctx, testutil.TestInstance, newKey)
+	require.True(t, found)
+	require.Equal(t, v[3].String(), cosigner.ValidatorAddress)
+	require.Equal(t, "hot", cosigner.Vault)
+	_, found = input.Keeper.GetCosignerInvitation(input.Ctx, testutil.TestInstance, v[3])
+	require.False(t, found)
+}
+
+func TestConfirmedVaultTransferNeedsConsensus(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	txHash := testutil.MainchainTxHashFromSeed(1)
+	amount := sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, 100))
+
+	// a confirmation of a transfer which was never recorded is rejected
+	require.Error(t, deliver(input, types.NewMsgConfirmedVaultTransfer(v[0], testutil.TestInstance, txHash)))
+
+	input.Keeper.SetVaultBalance(input.Ctx, testutil.TestInstance, "hot", amount)
+	require.NoError(t, input.Keeper.RecordVaultTransfer(input.Ctx, types.VaultTransfer{Instance: testutil.TestInstance, MainchainTxHash: txHash, FromVault: "hot", ToVault: "cold", Amount: amount}))
+
+	// a single relayer cannot credit the destination vault
+	require.NoError(t, deliver(input, types.NewMsgConfirmedVaultTransfer(v[0], testutil.TestInstance, txHash)))
+	require.NoError(t, deliver(input, types.NewMsgConfirmedVaultTransfer(v[1], testutil.TestInstance, txHash)))
+	require.True(t, input.Keeper.GetVaultBalance(input.Ctx, testutil.TestInstance, "cold").IsZero())
+
+	require.NoError(t, deliver(input, types.NewMsgConfirmedVaultTransfer(v[2], testutil.TestInstance, txHash)))
+	require.Equal(t, amount, input.Keeper.GetVaultBalance(input.Ctx, testutil.TestInstance, "cold"))
+	_, found := input.Keeper.GetVaultTransfer(input.Ctx, testutil.TestInstance, txHash)
+	require.False(t, found)
+}
+
+func TestUnpegOutOfMosaicRange(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	sender := testutil.AccAddressFromSeed(1)
+
+	// an amount of xpx with divisibility 6 which does not fit the uint64 amount of a mosaic
+	amount := sdk.NewCoins(sdk.NewCoin(types.DefaultDenom, sdk.NewIntFromUint64(^uint64(0)/1000000+1)))
+	require.NoError(t, input.BankKeeper.SetCoins(input.Ctx, sender, amount))
+
+	err := deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), amount, v[0]))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInvalidCoins.Is(err))
+	require.Equal(t, amount, input.BankKeeper.GetCoins(input.Ctx, sender))
+
+	err = deliver(input, types.NewMsgRequestVaultTransfer(v[0], testutil.TestInstance, "hot", "cold", amount))
+	require.Error(t, err)
+	require.True(t, sdkerrors.ErrInvalidCoins.Is(err))
+}
+
+func TestConfirmedUnpegBatchNeedsConsensus(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	txHash := testutil.MainchainTxHashFromSeed(1)
+
+	// a confirmation of an aggregate no batch was announced in is rejected
+	require.Error(t, deliver(input, types.NewMsgConfirmedUnpegBatch(v[0], testutil.TestInstance, txHash)))
+
+	input.Keeper.SetUnpegBatch(input.Ctx, types.UnpegBatch{
+		ID:                   1,
+		Instance:             testutil.TestInstance,
+		Vault:                "hot",
+		FirstCosignerAddress: v[0],
+		Unpegs: []types.BatchedUnpeg{{ID: 1, Address: testutil.AccAddressFromSeed(1), Recipients: []types.UnpegRecipient{
+			types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(3), sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, 100))),
+		}}},
+		Status:          types.UnpegBatchStatusAnnounced,
+		MainchainTxHash: txHash,
+	})
+
+	// a single relayer cannot complete the batch
+	require.NoError(t, deliver(input, types.NewMsgConfirmedUnpegBatch(v[0], testutil.TestInstance, txHash)))
+	require.NoError(t, deliver(input, types.NewMsgConfirmedUnpegBatch(v[1], testutil.TestInstance, txHash)))
+	batch, _ := input.Keeper.GetUnpegBatch(input.Ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusAnnounced, batch.Status)
+
+	require.NoError(t, deliver(input, types.NewMsgConfirmedUnpegBatch(v[2], testutil.TestInstance, txHash)))
+	batch, _ = input.Keeper.GetUnpegBatch(input.Ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusCompleted, batch.Status)
+}
+
+func xpx(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, amount))
+}
+
+func TestLargeUnpegVetoedByGuardian(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	sender := testutil.AccAddressFromSeed(1)
+	guardian := testutil.AccAddressFromSeed(9)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.TimeLock = types.NewTimeLock(xpx(500), 10, []sdk.AccAddress{guardian})
+	input.Keeper.SetParams(input.Ctx, params)
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, xpx(2100)))
+	require.NoError(t, input.SupplyKeeper.SendCoinsFromModuleToAccount(input.Ctx, types.ModuleName, sender, xpx(2100)))
+
+	// an unpeg over the threshold is held back, one within it joins the withdrawal queue
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(1000), v[0])))
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(100), v[0])))
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(1000), v[0])))
+	locked := input.Keeper.GetTimeLockedUnpegs(input.Ctx)
+	require.Len(t, locked, 2)
+	require.Equal(t, int64(11), locked[0].UnlockHeight)
+	require.Len(t, input.Keeper.GetQueuedUnpegs(input.Ctx), 1)
+	require.True(t, input.BankKeeper.GetCoins(input.Ctx, sender).Empty())
+
+	// only a guardian can veto, not even the sender
+	require.Error(t, deliver(input, types.NewMsgVetoUnpeg(sender, locked[0].ID)))
+	require.Error(t, deliver(input, types.NewMsgVetoUnpeg(testutil.AccAddressFromSeed(8), locked[0].ID)))
+
+	// the sender gets the whole amount back
+	require.NoError(t, deliver(input, types.NewMsgVetoUnpeg(guardian, locked[0].ID)))
+	require.Equal(t, xpx(1000), input.BankKeeper.GetCoins(input.Ctx, sender))
+	require.Len(t, input.Keeper.GetTimeLockedUnpegs(input.Ctx), 1)
+	require.Error(t, deliver(input, types.NewMsgVetoUnpeg(guardian, locked[0].ID)))
+
+	// the other one joins the queue once the delay is over, and can no longer be vetoed
+	input.Keeper.UnlockUnpegs(input.Ctx.WithBlockHeight(10))
+	require.Len(t, input.Keeper.GetTimeLockedUnpegs(input.Ctx), 1)
+	input.Keeper.UnlockUnpegs(input.Ctx.WithBlockHeight(11))
+	require.Empty(t, input.Keeper.GetTimeLockedUnpegs(input.Ctx))
+	queued := input.Keeper.GetQueuedUnpegs(input.Ctx)
+	require.Len(t, queued, 2)
+	require.Equal(t, locked[1].ID, queued[1].ID)
+	require.Error(t, deliver(input, types.NewMsgVetoUnpeg(guardian, locked[1].ID)))
+}
+
+func TestUnpegAddressFilter(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	sender := testutil.AccAddressFromSeed(1)
+	blocked := testutil.MainchainAddressFromSeed(5)
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, xpx(1000)))
+	require.NoError(t, input.SupplyKeeper.SendCoinsFromModuleToAccount(input.Ctx, types.ModuleName, sender, xpx(1000)))
+	setFilter := func(filter types.AddressFilter) {
+		params := input.Keeper.GetParams(input.Ctx)
+		params.AddressFilter = filter
+		input.Keeper.SetParams(input.Ctx, params)
+	}
+
+	// a blocked recipient fails the whole unpeg, wherever it is among the recipients
+	setFilter(types.NewAddressFilter(types.AddressFilterModeBlocklist, []sdk.AccAddress{testutil.AccAddressFromSeed(2)}, []types.MainchainAddress{blocked}))
+	err := deliver(input, types.NewMsgUnpegToRecipients(sender, testutil.TestInstance, []types.UnpegRecipient{
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(3), xpx(100)),
+		types.NewUnpegRecipient(blocked, xpx(100)),
+	}, v[0]))
+	require.True(t, types.ErrAddressNotPermitted.Is(err))
+	require.Equal(t, xpx(1000), input.BankKeeper.GetCoins(input.Ctx, sender))
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(100), v[0])))
+
+	// in the allowlist mode only the listed addresses bridge
+	setFilter(types.NewAddressFilter(types.AddressFilterModeAllowlist, []sdk.AccAddress{sender}, []types.MainchainAddress{testutil.MainchainAddressFromSeed(3)}))
+	err = deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(4), xpx(100), v[0]))
+	require.True(t, types.ErrAddressNotPermitted.Is(err))
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(100), v[0])))
+	require.Equal(t, xpx(800), input.BankKeeper.GetCoins(input.Ctx, sender))
+
+	// an unpeg whose sender was blocked while it waited in the queue is refunded on release
+	setFilter(types.NewAddressFilter(types.AddressFilterModeBlocklist, []sdk.AccAddress{sender}, []types.MainchainAddress{}))
+	input.Keeper.ReleaseQueuedUnpegs(input.Ctx)
+	require.Empty(t, input.Keeper.GetQueuedUnpegs(input.Ctx))
+	require.Equal(t, xpx(1000), input.BankKeeper.GetCoins(input.Ctx, sender))
+}
+
+func TestPegClaimAddressFilter(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	params := input.Keeper.GetParams(input.Ctx)
+	params.AddressFilter = types.NewAddressFilter(types.AddressFilterModeBlocklist, []sdk.AccAddress{testutil.AccAddressFromSeed(2)}, []types.MainchainAddress{testutil.MainchainAddressFromSeed(5)})
+	input.Keeper.SetParams(input.Ctx, params)
+	claim := func(receiver sdk.AccAddress, mainchainSender types.MainchainAddress) types.MsgPegClaim {
+		return types.NewMsgPegClaim(receiver, testutil.TestInstance, "hot", testutil.MainchainTxHashFromSeed(1), mainchainSender, xpx(100), 0, v[0])
+	}
+
+	id := types.GetPegClaimProphecyID(claim(testutil.AccAddressFromSeed(1), testutil.MainchainAddressFromSeed(9)))
+
+	// neither a blocked ProximaX sender nor a blocked Cosmos receiver is pegged
+	err := deliver(input, claim(testutil.AccAddressFromSeed(1), testutil.MainchainAddressFromSeed(5)))
+	require.True(t, types.ErrAddressNotPermitted.Is(err))
+	err = deliver(input, claim(testutil.AccAddressFromSeed(2), testutil.MainchainAddressFromSeed(9)))
+	require.True(t, types.ErrAddressNotPermitted.Is(err))
+	require.Empty(t, input.Keeper.GetValidatorClaims(input.Ctx, id))
+
+	require.NoError(t, deliver(input, claim(testutil.AccAddressFromSeed(1), testutil.MainchainAddressFromSeed(9))))
+	require.Len(t, input.Keeper.GetValidatorClaims(input.Ctx, id), 1)
+}
+
+func TestRecordUnpegRewardsFirstCosignerOnce(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	sender := testutil.AccAddressFromSeed(1)
+	input.Keeper.SetVaultBalance(input.Ctx, testutil.TestInstance, "hot", xpx(1000))
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, xpx(100)))
+	require.NoError(t, input.SupplyKeeper.SendCoinsFromModuleToAccount(input.Ctx, types.ModuleName, sender, xpx(100)))
+	require.NoError(t, deliver(input, types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(100), v[0])))
+	input.Keeper.ReleaseQueuedUnpegs(input.Ctx)
+	pending := input.Keeper.GetPendingUnpegs(input.Ctx)
+	require.Len(t, pending, 1)
+	record := func(txHash types.MainchainTxHash, key types.MainchainPublicKey, validator sdk.ValAddress) types.MsgRecordUnpeg {
+		return types.NewMsgRecordUnpeg(sender, testutil.TestInstance, "hot", txHash, pending[0].Amount, pending[0].Recipients, key, validator)
+	}
+	distribution := input.Keeper.GetParams(input.Ctx).FeeDistribution
+
+	// an unpeg the zone did not ask the validator to announce earns nothing
+	err := deliver(input, record(testutil.MainchainTxHashFromSeed(1), cosigners[1].MainchainPublicKey, v[1]))
+	require.True(t, types.ErrPendingUnpegNotFound.Is(err))
+	require.True(t, input.Keeper.GetRewardPoints(input.Ctx, v[1]).IsZero())
+	// nor does an announcement with the key of another cosigner
+	err = deliver(input, record(testutil.MainchainTxHashFromSeed(1), cosigners[1].MainchainPublicKey, v[0]))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	require.NoError(t, deliver(input, record(testutil.MainchainTxHashFromSeed(1), cosigners[0].MainchainPublicKey, v[0])))
+	require.Equal(t, distribution.Initiator.Add(distribution.Cosigner), input.Keeper.GetRewardPoints(input.Ctx, v[0]))
+	require.Empty(t, input.Keeper.GetPendingUnpegs(input.Ctx))
+
+	// neither a replay nor an unknown hash earns more
+	require.Error(t, deliver(input, record(testutil.MainchainTxHashFromSeed(1), cosigners[0].MainchainPublicKey, v[0])))
+	err = deliver(input, record(testutil.MainchainTxHashFromSeed(2), cosigners[0].MainchainPublicKey, v[0]))
+	require.True(t, types.ErrPendingUnpegNotFound.Is(err))
+	_, err = input.Keeper.GetUnpegRecord(input.Ctx, testutil.TestInstance, testutil.MainchainTxHashFromSeed(2))
+	require.Error(t, err)
+	require.Equal(t, distribution.Initiator.Add(distribution.Cosigner), input.Keeper.GetRewardPoints(input.Ctx, v[0]))
+}
+
+func TestNotifyCosignedCreditsOwnCosignatureOnce(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	txHash := testutil.MainchainTxHashFromSeed(1)
+	weight := input.Keeper.GetParams(input.Ctx).FeeDistribution.Cosigner
+
+	// a cosignature of a transaction the zone did not ask for earns nothing
+	err := deliver(input, types.NewMsgNotifyCosigned(v[1], testutil.TestInstance, txHash, cosigners[1].MainchainPublicKey))
+	require.True(t, types.ErrMultisigTxNotFound.Is(err))
+	require.True(t, input.Keeper.GetRewardPoints(input.Ctx, v[1]).IsZero())
+
+	input.Keeper.SetVaultTransfer(input.Ctx, types.VaultTransfer{Instance: testutil.TestInstance, MainchainTxHash: txHash, FromVault: "hot", ToVault: "cold", Amount: xpx(100)})
+
+	// a validator cannot report the cosignature of another one
+	err = deliver(input, types.NewMsgNotifyCosigned(v[1], testutil.TestInstance, txHash, cosigners[2].MainchainPublicKey))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	require.True(t, input.Keeper.GetRewardPoints(input.Ctx, v[2]).IsZero())
+
+	// its own cosignature is credited once
+	require.NoError(t, deliver(input, types.NewMsgNotifyCosigned(v[1], testutil.TestInstance, txHash, cosigners[1].MainchainPublicKey)))
+	require.NoError(t, deliver(input, types.NewMsgNotifyCosigned(v[1], testutil.TestInstance, txHash, cosigners[1].MainchainPublicKey)))
+	require.Equal(t, weight, input.Keeper.GetRewardPoints(input.Ctx, v[1]))
+}
@@ -0,0 +1,319 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetUnpegBatch returns an unpeg batch
+func (k Keeper) GetUnpegBatch(ctx sdk.Context, id uint64) (types.UnpegBatch, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetUnpegBatchKey(id))
+	if bz == nil {
+		return types.UnpegBatch{}, false
+	}
+	var batch types.UnpegBatch
+	if err := json.Unmarshal(bz, &batch); err != nil {
+		panic(err)
+	}
+	return batch, true
+}
+
+// SetUnpegBatch stores an unpeg batch, indexed as the open batch of its vault while it is open,
+// among the batches to announce while it is closed, among the batches to confirm while it is announced
+// and by its mainchain transaction once announced.
+// Ids issued later are kept clear of its id.
+func (k Keeper) SetUnpegBatch(ctx sdk.Context, batch types.UnpegBatch) {
+	bz, err := json.Marshal(batch)
+	if err != nil {
+		panic(err)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetUnpegBatchKey(batch.ID), bz)
+
+	openKey := types.GetOpenUnpegBatchKey(batch.Instance, batch.Vault)
+	if batch.Status == types.UnpegBatchStatusOpen {
+		store.Set(openKey, sdk.Uint64ToBigEndian(batch.ID))
+	} else if id := store.Get(openKey); id != nil && binary.BigEndian.Uint64(id) == batch.ID {
+		store.Delete(openKey)
+	}
+	if batch.Status == types.UnpegBatchStatusClosed {
+		store.Set(types.GetClosedUnpegBatchKey(batch.ID), sdk.Uint64ToBigEndian(batch.ID))
+	} else {
+		store.Delete(types.GetClosedUnpegBatchKey(batch.ID))
+	}
+	if batch.Status == types.UnpegBatchStatusAnnounced {
+		store.Set(types.GetAnnouncedUnpegBatchKey(batch.ID), sdk.Uint64ToBigEndian(batch.ID))
+	} else {
+		store.Delete(types.GetAnnouncedUnpegBatchKey(batch.ID))
+	}
+	if !batch.MainchainTxHash.Empty() {
+		store.Set(types.GetUnpegBatchTxKey(batch.Instance, batch.MainchainTxHash), sdk.Uint64ToBigEndian(batch.ID))
+	}
+
+	if batch.ID >= k.getNextUnpegBatchID(ctx) {
+		store.Set(types.NextUnpegBatchIDKey, sdk.Uint64ToBigEndian(batch.ID+1))
+	}
+}
+
+// GetUnpegBatches returns every unpeg batch, oldest first
+func (k Keeper) GetUnpegBatches(ctx sdk.Context) []types.UnpegBatch {
+	batches := []types.UnpegBatch{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.UnpegBatchPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var batch types.UnpegBatch
+		if err := json.Unmarshal(iterator.Value(), &batch); err != nil {
+			panic(err)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// GetUnpegBatchByTx returns the batch announced in a mainchain transaction of an instance
+func (k Keeper) GetUnpegBatchByTx(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash) (types.UnpegBatch, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetUnpegBatchTxKey(instance, mainchainTxHash))
+	if bz == nil {
+		return types.UnpegBatch{}, false
+	}
+	return k.GetUnpegBatch(ctx, binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) getOpenUnpegBatch(ctx sdk.Context, instance, vault string) (types.UnpegBatch, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetOpenUnpegBatchKey(instance, vault))
+	if bz == nil {
+		return types.UnpegBatch{}, false
+	}
+	return k.GetUnpegBatch(ctx, binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) getNextUnpegBatchID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.NextUnpegBatchIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// BatchUnpeg adds an unpeg dispatched from a vault to the open batch of the vault,
+// opening one for the current window when there is none or the open one is full
+func (k Keeper) BatchUnpeg(ctx sdk.Context, id uint64, msg types.MsgUnpeg, vault string, recipients []types.UnpegRecipient) {
+	batch, found := k.getOpenUnpegBatch(ctx, msg.Instance, vault)
+	if found && batch.Transfers()+len(recipients) > types.MaxUnpegBatchTransfers {
+		k.closeUnpegBatch(ctx, batch)
+		found = false
+	}
+	if !found {
+		batch = types.UnpegBatch{
+			ID:                   k.getNextUnpegBatchID(ctx),
+			Instance:             msg.Instance,
+			Vault:                vault,
+			Height:               ctx.BlockHeight(),
+			CloseHeight:          types.UnpegBatchCloseHeight(ctx.BlockHeight(), k.GetParams(ctx).UnpegBatchWindow),
+			FirstCosignerAddress: msg.FirstCosignerAddress,
+			Unpegs:               []types.BatchedUnpeg{},
+			Status:               types.UnpegBatchStatusOpen,
+		}
+	}
+	batch.Unpegs = append(batch.Unpegs, types.BatchedUnpeg{ID: id, Address: msg.Address, Recipients: recipients})
+	k.SetUnpegBatch(ctx, batch)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpegBatched,
+			sdk.NewAttribute(types.AttributeKeyBatchID, fmt.Sprintf("%d", batch.ID)),
+			sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", id)),
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, vault),
+			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.Address.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, types.SumRecipients(recipients).String()),
+			sdk.NewAttribute(types.AttributeKeyRecipients, types.RecipientsAttribute(recipients)),
+		),
+	)
+}
+
+// CloseUnpegBatches closes the batches whose window is over, and every open batch once batching is turned off
+func (k Keeper) CloseUnpegBatches(ctx sdk.Context) {
+	window := k.GetParams(ctx).UnpegBatchWindow
+	for _, id := range k.getUnpegBatchIDs(ctx, types.OpenUnpegBatchPrefix) {
+		batch, found := k.GetUnpegBatch(ctx, id)
+		if !found || (window > 0 && batch.CloseHeight > ctx.BlockHeight()) {
+			continue
+		}
+		k.closeUnpegBatch(ctx, batch)
+	}
+}
+
+// ExpireUnpegBatches hands the closed batches whose first cosigner did not announce the aggregate by the expiry height
+// over to another active cosigner of the vault. A batch is refunded once no other cosigner is left
+// or it was handed over MaxUnpegBatchReassignments times.
+// An announced batch whose aggregate was not confirmed by the expiry height is refunded too:
+// the relayers give the aggregate an hour on ProximaX, so by then it can no longer be paid out.
+func (k Keeper) ExpireUnpegBatches(ctx sdk.Context) {
+	for _, id := range k.getUnpegBatchIDs(ctx, types.AnnouncedUnpegBatchPrefix) {
+		batch, found := k.GetUnpegBatch(ctx, id)
+		if !found || batch.ExpiryHeight > ctx.BlockHeight() {
+			continue
+		}
+		k.failUnpegBatch(ctx, batch, "not confirmed")
+	}
+
+	for _, id := range k.getUnpegBatchIDs(ctx, types.ClosedUnpegBatchPrefix) {
+		batch, found := k.GetUnpegBatch(ctx, id)
+		if !found || batch.ExpiryHeight > ctx.BlockHeight() {
+			continue
+		}
+		if batch.Reassignments >= types.MaxUnpegBatchReassignments {
+			k.failUnpegBatch(ctx, batch, "not announced")
+			continue
+		}
+		firstCosignerAddress, found := k.GetActiveCosigner(ctx, batch.Instance, batch.Vault, batch.FirstCosignerAddress)
+		if !found {
+			k.failUnpegBatch(ctx, batch, "not announced")
+			continue
+		}
+		batch.FirstCosignerAddress = firstCosignerAddress
+		batch.Reassignments++
+		k.closeUnpegBatch(ctx, batch)
+	}
+}
+
+func (k Keeper) getUnpegBatchIDs(ctx sdk.Context, prefix []byte) []uint64 {
+	var ids []uint64
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, binary.BigEndian.Uint64(iterator.Value()))
+	}
+	return ids
+}
+
+// closeUnpegBatch asks the first cosigner of a batch to announce its aggregate within ProphecyExpiry blocks,
+// handing the batch over to another active cosigner of the vault when the first one is no longer active
+func (k Keeper) closeUnpegBatch(ctx sdk.Context, batch types.UnpegBatch) {
+	instance, found := k.GetInstance(ctx, batch.Instance)
+	if !found {
+		k.failUnpegBatch(ctx, batch, fmt.Sprintf("unknown instance %s", batch.Instance))
+		return
+	}
+	vault, found := instance.GetVault(batch.Vault)
+	if !found {
+		k.failUnpegBatch(ctx, batch, fmt.Sprintf("unknown vault %s", batch.Vault))
+		return
+	}
+	if !k.IsActiveVaultCosigner(ctx, batch.Instance, batch.Vault, batch.FirstCosignerAddress) {
+		firstCosignerAddress, found := k.GetActiveCosigner(ctx, batch.Instance, batch.Vault, batch.FirstCosignerAddress)
+		if !found {
+			k.failUnpegBatch(ctx, batch, "no cosigner can initiate the batch")
+			return
+		}
+		batch.FirstCosignerAddress = firstCosignerAddress
+	}
+	batch.Status = types.UnpegBatchStatusClosed
+	batch.ExpiryHeight = ctx.BlockHeight() + k.GetParams(ctx).ProphecyExpiry
+	k.SetUnpegBatch(ctx, batch)
+
+	unpegs, err := json.Marshal(batch.Unpegs)
+	if err != nil {
+		panic(err)
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpegBatch,
+			sdk.NewAttribute(types.AttributeKeyBatchID, fmt.Sprintf("%d", batch.ID)),
+			sdk.NewAttribute(types.AttributeKeyInstance, batch.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, batch.Vault),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, batch.Amount().String()),
+			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, batch.FirstCosignerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, fmt.Sprintf("%d", batch.ExpiryHeight)),
+			sdk.NewAttribute(types.AttributeKeyUnpegs, string(unpegs)),
+		),
+	)
+	k.emitUnpegBatchStatus(ctx, batch)
+}
+
+// RecordUnpegBatch records the aggregate the first cosigner of a closed batch announced on ProximaX,
+// which has ProphecyExpiry blocks to be confirmed
+func (k Keeper) RecordUnpegBatch(ctx sdk.Context, id uint64, validator sdk.ValAddress, mainchainTxHash types.MainchainTxHash) (types.UnpegBatch, error) {
+	batch, found := k.GetUnpegBatch(ctx, id)
+	if !found {
+		return batch, sdkerrors.Wrapf(types.ErrUnpegBatchNotFound, "%d", id)
+	}
+	if batch.Status != types.UnpegBatchStatusClosed {
+		return batch, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unpeg batch %d is %s", id, batch.Status)
+	}
+	if !batch.FirstCosignerAddress.Equals(validator) {
+		return batch, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "unpeg batch %d is announced by %s", id, batch.FirstCosignerAddress)
+	}
+	batch.Status = types.UnpegBatchStatusAnnounced
+	batch.MainchainTxHash = mainchainTxHash
+	batch.ExpiryHeight = ctx.BlockHeight() + k.GetParams(ctx).ProphecyExpiry
+	k.SetUnpegBatch(ctx, batch)
+	k.emitUnpegBatchStatus(ctx, batch)
+	return batch, nil
+}
+
+// CompleteUnpegBatch settles the batch announced in a mainchain transaction once consensus is reached
+// on the confirmation of the aggregate, and reports whether there was such a batch waiting
+func (k Keeper) CompleteUnpegBatch(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash) bool {
+	batch, found := k.GetUnpegBatchByTx(ctx, instance, mainchainTxHash)
+	if !found || batch.Status != types.UnpegBatchStatusAnnounced {
+		return false
+	}
+	batch.Status = types.UnpegBatchStatusCompleted
+	k.SetUnpegBatch(ctx, batch)
+	k.emitUnpegBatchStatus(ctx, batch)
+	return true
+}
+
+// failUnpegBatch refunds the net amount of each unpeg of a batch which was not paid out to its sender.
+// The funds never left the vault, and the bridge fees are kept as for an unpeg which is not cosigned.
+func (k Keeper) failUnpegBatch(ctx sdk.Context, batch types.UnpegBatch, reason string) {
+	if batch.IsSettled() {
+		return
+	}
+	amount := batch.Amount()
+	if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, amount); err != nil {
+		panic(err)
+	}
+	for _, unpeg := range batch.Unpegs {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, unpeg.Address, unpeg.Amount()); err != nil {
+			panic(err)
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeUnpegRefund,
+				sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", unpeg.ID)),
+				sdk.NewAttribute(types.AttributeKeyBatchID, fmt.Sprintf("%d", batch.ID)),
+				sdk.NewAttribute(types.AttributeKeyInstance, batch.Instance),
+				sdk.NewAttribute(types.AttributeKeyCosmosSender, unpeg.Address.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, unpeg.Amount().String()),
+				sdk.NewAttribute(types.AttributeKeyReason, reason),
+			),
+		)
+	}
+	k.addToVault(ctx, batch.Instance, batch.Vault, amount)
+
+	batch.Status = types.UnpegBatchStatusFailed
+	k.SetUnpegBatch(ctx, batch)
+	k.emitUnpegBatchStatus(ctx, batch)
+}
+
+func (k Keeper) emitUnpegBatchStatus(ctx sdk.Context, batch types.UnpegBatch) {
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyBatchID, fmt.Sprintf("%d", batch.ID)),
+		sdk.NewAttribute(types.AttributeKeyInstance, batch.Instance),
+		sdk.NewAttribute(types.AttributeKeyVault, batch.Vault),
+		sdk.NewAttribute(types.AttributeKeyStatus, batch.Status),
+	}
+	if !batch.MainchainTxHash.Empty() {
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyMainchainTxHash, batch.MainchainTxHash.String()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeUnpegBatchStatus, attributes...))
+}
@@ -0,0 +1,147 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// closeTestBatch dispatches an unpeg of 100 and 300 xpx from the hot vault into a batch and closes it
+func closeTestBatch(t *testing.T, input testutil.TestInput) (sdk.Context, types.UnpegBatch) {
+	setUnpegFee(input)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.UnpegBatchWindow = 10
+	input.Keeper.SetParams(input.Ctx, params)
+	input.Keeper.SetVaultBalance(input.Ctx, testutil.TestInstance, "hot", xpx(1000))
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, xpx(400)))
+
+	msg := types.NewMsgUnpegToRecipients(testutil.AccAddressFromSeed(1), testutil.TestInstance, []types.UnpegRecipient{
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(3), xpx(100)),
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(4), xpx(300)),
+	}, input.Validators[2])
+	require.NoError(t, input.Keeper.DispatchUnpeg(input.Ctx, 1, msg))
+
+	ctx := input.Ctx.WithBlockHeight(9).WithEventManager(sdk.NewEventManager())
+	input.Keeper.CloseUnpegBatches(ctx)
+	batch, found := input.Keeper.GetUnpegBatch(ctx, 1)
+	require.True(t, found)
+	require.Equal(t, types.UnpegBatchStatusClosed, batch.Status)
+	return ctx, batch
+}
+
+func TestUnpegBatchNetAmount(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	ctx, batch := closeTestBatch(t, input)
+
+	// the batch pays out the net of every share, the fees stay in the pool
+	require.Equal(t, xpx(394), batch.Amount())
+	require.Equal(t, 2, batch.Transfers())
+	require.Equal(t, xpx(606), input.Keeper.GetVaultBalance(ctx, testutil.TestInstance, "hot"))
+	require.Equal(t, xpx(6), input.Keeper.GetFeePool(ctx))
+
+	closed := eventsOfType(ctx, types.EventTypeUnpegBatch)
+	require.Len(t, closed, 1)
+	require.Equal(t, xpx(394).String(), closed[0][sdk.AttributeKeyAmount])
+	require.Equal(t, input.Validators[2].String(), closed[0][types.AttributeKeyFirstCosignerAddress])
+}
+
+func TestExpireUnpegBatchesReassigns(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	ctx, batch := closeTestBatch(t, input)
+	require.Equal(t, 9+input.Keeper.GetParams(ctx).ProphecyExpiry, batch.ExpiryHeight)
+
+	// the first cosigner has until the expiry height to announce the aggregate
+	input.Keeper.ExpireUnpegBatches(ctx.WithBlockHeight(batch.ExpiryHeight - 1))
+	batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+	require.Equal(t, input.Validators[2], batch.FirstCosignerAddress)
+
+	ctx = ctx.WithBlockHeight(batch.ExpiryHeight).WithEventManager(sdk.NewEventManager())
+	input.Keeper.ExpireUnpegBatches(ctx)
+	batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusClosed, batch.Status)
+	require.NotEqual(t, input.Validators[2], batch.FirstCosignerAddress)
+	require.Equal(t, uint32(1), batch.Reassignments)
+	require.Equal(t, ctx.BlockHeight()+input.Keeper.GetParams(ctx).ProphecyExpiry, batch.ExpiryHeight)
+
+	// the new first cosigner is asked to announce it
+	closed := eventsOfType(ctx, types.EventTypeUnpegBatch)
+	require.Len(t, closed, 1)
+	require.Equal(t, batch.FirstCosignerAddress.String(), closed[0][types.AttributeKeyFirstCosignerAddress])
+
+	// the old one can no longer record it
+	_, err := input.Keeper.RecordUnpegBatch(ctx, 1, input.Validators[2], testutil.MainchainTxHashFromSeed(1))
+	require.Error(t, err)
+}
+
+func TestExpireUnpegBatchesRefunds(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	ctx, batch := closeTestBatch(t, input)
+
+	for i := 0; i < types.MaxUnpegBatchReassignments; i++ {
+		ctx = ctx.WithBlockHeight(batch.ExpiryHeight)
+		input.Keeper.ExpireUnpegBatches(ctx)
+		batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+		require.Equal(t, types.UnpegBatchStatusClosed, batch.Status)
+	}
+
+	ctx = ctx.WithBlockHeight(batch.ExpiryHeight).WithEventManager(sdk.NewEventManager())
+	input.Keeper.ExpireUnpegBatches(ctx)
+	batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusFailed, batch.Status)
+
+	// the sender gets the net back, the vault its funds and the fees stay in the pool
+	require.Equal(t, xpx(394), input.BankKeeper.GetCoins(ctx, testutil.AccAddressFromSeed(1)))
+	require.Equal(t, xpx(1000), input.Keeper.GetVaultBalance(ctx, testutil.TestInstance, "hot"))
+	require.Equal(t, xpx(6), input.Keeper.GetFeePool(ctx))
+	require.Len(t, eventsOfType(ctx, types.EventTypeUnpegRefund), 1)
+
+	// a failed batch is no longer expired
+	input.Keeper.ExpireUnpegBatches(ctx.WithBlockHeight(batch.ExpiryHeight + 1000))
+	require.Equal(t, xpx(394), input.BankKeeper.GetCoins(ctx, testutil.AccAddressFromSeed(1)))
+}
+
+func TestExpireUnpegBatchesRefundsWithoutCosigner(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators[2])
+	ctx, batch := closeTestBatch(t, input)
+
+	// nobody else cosigns for the vault
+	input.Keeper.ExpireUnpegBatches(ctx.WithBlockHeight(batch.ExpiryHeight))
+	batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusFailed, batch.Status)
+	require.Equal(t, xpx(394), input.BankKeeper.GetCoins(ctx, testutil.AccAddressFromSeed(1)))
+}
+
+func TestExpireUnpegBatchesRefundsUnconfirmed(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	ctx, _ := closeTestBatch(t, input)
+
+	ctx = ctx.WithBlockHeight(20)
+	batch, err := input.Keeper.RecordUnpegBatch(ctx, 1, input.Validators[2], testutil.MainchainTxHashFromSeed(1))
+	require.NoError(t, err)
+	require.Equal(t, 20+input.Keeper.GetParams(ctx).ProphecyExpiry, batch.ExpiryHeight)
+
+	// the aggregate has until the expiry height to be confirmed
+	input.Keeper.ExpireUnpegBatches(ctx.WithBlockHeight(batch.ExpiryHeight - 1))
+	batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusAnnounced, batch.Status)
+
+	ctx = ctx.WithBlockHeight(batch.ExpiryHeight).WithEventManager(sdk.NewEventManager())
+	input.Keeper.ExpireUnpegBatches(ctx)
+	batch, _ = input.Keeper.GetUnpegBatch(ctx, 1)
+	require.Equal(t, types.UnpegBatchStatusFailed, batch.Status)
+	require.Equal(t, xpx(394), input.BankKeeper.GetCoins(ctx, testutil.AccAddressFromSeed(1)))
+	require.Equal(t, xpx(1000), input.Keeper.GetVaultBalance(ctx, testutil.TestInstance, "hot"))
+
+	// a late confirmation no longer completes it
+	require.False(t, input.Keeper.CompleteUnpegBatch(ctx, testutil.TestInstance, testutil.MainchainTxHashFromSeed(1)))
+}
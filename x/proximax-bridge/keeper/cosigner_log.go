@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// AppendCosignerSetChange logs the current cosigner set of an instance as the result of a change
+func (k Keeper) AppendCosignerSetChange(ctx sdk.Context, name string, reason string, mainchainTxHash types.MainchainTxHash) {
+	var id uint64
+	if last, found := k.getLastCosignerSetChange(ctx, name); found {
+		id = last.ID + 1
+	}
+
+	instance, _ := k.GetInstance(ctx, name)
+	cosigners := append([]types.Cosigner{}, instance.Cosigners...)
+	k.SetCosignerSetChange(ctx, types.CosignerSetChange{
+		Instance:        name,
+		ID:              id,
+		Height:          ctx.BlockHeight(),
+		MainchainTxHash: mainchainTxHash,
+		Reason:          reason,
+		Cosigners:       cosigners,
+	})
+}
+
+// SetCosignerSetChange stores an entry of the cosigner set change log
+func (k Keeper) SetCosignerSetChange(ctx sdk.Context, change types.CosignerSetChange) {
+	bz, err := json.Marshal(change)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetCosignerSetChangeKey(change.Instance, change.ID), bz)
+}
+
+// GetCosignerSetChanges returns the cosigner set change log of an instance, oldest first,
+// or the logs of all instances one after the other if the name is empty
+func (k Keeper) GetCosignerSetChanges(ctx sdk.Context, name string) []types.CosignerSetChange {
+	prefix := types.CosignerSetChangePrefix
+	if name != "" {
+		prefix = types.GetCosignerSetChangesPrefix(name)
+	}
+	changes := []types.CosignerSetChange{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var change types.CosignerSetChange
+		if err := json.Unmarshal(iterator.Value(), &change); err != nil {
+			panic(err)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// GetCosignerSetAtHeight returns the latest change of an instance logged at or before the height,
+// whose cosigners are the ones who could sign for the instance at that height
+func (k Keeper) GetCosignerSetAtHeight(ctx sdk.Context, name string, height int64) (types.CosignerSetChange, bool) {
+	iterator := sdk.KVStoreReversePrefixIterator(ctx.KVStore(k.storeKey), types.GetCosignerSetChangesPrefix(name))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var change types.CosignerSetChange
+		if err := json.Unmarshal(iterator.Value(), &change); err != nil {
+			panic(err)
+		}
+		if change.Height <= height {
+			return change, true
+		}
+	}
+	return types.CosignerSetChange{}, false
+}
+
+func (k Keeper) getLastCosignerSetChange(ctx sdk.Context, name string) (types.CosignerSetChange, bool) {
+	var change types.CosignerSetChange
+	iterator := sdk.KVStoreReversePrefixIterator(ctx.KVStore(k.storeKey), types.GetCosignerSetChangesPrefix(name))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return change, false
+	}
+	if err := json.Unmarshal(iterator.Value(), &change); err != nil {
+		panic(err)
+	}
+	return change, true
+}
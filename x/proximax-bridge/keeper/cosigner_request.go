@@ -0,0 +1,177 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/peggy/x/oracle"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetCosignerInvitation returns the invitation of a validator to join a vault of an instance
+func (k Keeper) GetCosignerInvitation(ctx sdk.Context, instance string, validator sdk.ValAddress) (types.CosignerInvitation, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCosignerInvitationKey(instance, validator))
+	if bz == nil {
+		return types.CosignerInvitation{}, false
+	}
+	var invitation types.CosignerInvitation
+	if err := json.Unmarshal(bz, &invitation); err != nil {
+		panic(err)
+	}
+	return invitation, true
+}
+
+// SetCosignerInvitation stores the invitation of a validator, replacing any earlier one to the same instance
+func (k Keeper) SetCosignerInvitation(ctx sdk.Context, invitation types.CosignerInvitation) {
+	bz, err := json.Marshal(invitation)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetCosignerInvitationKey(invitation.Instance, invitation.ValidatorAddress), bz)
+}
+
+func (k Keeper) deleteCosignerInvitation(ctx sdk.Context, instance string, validator sdk.ValAddress) {
+	ctx.KVStore(k.storeKey).Delete(types.GetCosignerInvitationKey(instance, validator))
+}
+
+// GetCosignerInvitations returns the invitations to join the vaults of an instance,
+// or of all instances if the name is empty
+func (k Keeper) GetCosignerInvitations(ctx sdk.Context, instance string) []types.CosignerInvitation {
+	prefix := types.CosignerInvitationPrefix
+	if instance != "" {
+		prefix = types.GetCosignerInvitationsPrefix(instance)
+	}
+	invitations := []types.CosignerInvitation{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var invitation types.CosignerInvitation
+		if err := json.Unmarshal(iterator.Value(), &invitation); err != nil {
+			panic(err)
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations
+}
+
+// GetCosignerRemoval returns the requested removal of a cosigner of an instance
+func (k Keeper) GetCosignerRemoval(ctx sdk.Context, instance string, mainchainPublicKey types.MainchainPublicKey) (types.CosignerRemoval, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCosignerRemovalKey(instance, mainchainPublicKey))
+	if bz == nil {
+		return types.CosignerRemoval{}, false
+	}
+	var removal types.CosignerRemoval
+	if err := json.Unmarshal(bz, &removal); err != nil {
+		panic(err)
+	}
+	return removal, true
+}
+
+// SetCosignerRemoval stores the requested removal of a cosigner
+func (k Keeper) SetCosignerRemoval(ctx sdk.Context, removal types.CosignerRemoval) {
+	bz, err := json.Marshal(removal)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetCosignerRemovalKey(removal.Instance, removal.MainchainPublicKey), bz)
+}
+
+// GetCosignerRemovals returns the requested removals of cosigners of an instance,
+// or of all instances if the name is empty
+func (k Keeper) GetCosignerRemovals(ctx sdk.Context, instance string) []types.CosignerRemoval {
+	prefix := types.CosignerRemovalPrefix
+	if instance != "" {
+		prefix = types.GetCosignerRemovalsPrefix(instance)
+	}
+	removals := []types.CosignerRemoval{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var removal types.CosignerRemoval
+		if err := json.Unmarshal(iterator.Value(), &removal); err != nil {
+			panic(err)
+		}
+		removals = append(removals, removal)
+	}
+	return removals
+}
+
+func (k Keeper) deleteCosignerRemoval(ctx sdk.Context, instance string, mainchainPublicKey types.MainchainPublicKey) {
+	ctx.KVStore(k.storeKey).Delete(types.GetCosignerRemovalKey(instance, mainchainPublicKey))
+}
+
+// ProcessConfirmedClaim processes a validator's claim that a multisig transaction of an instance was confirmed on the mainchain
+func (k Keeper) ProcessConfirmedClaim(ctx sdk.Context, claim types.ConfirmedClaim, validator sdk.ValAddress) (oracle.Status, error) {
+	oracleClaim, err := types.CreateOracleClaimFromConfirmedClaim(claim, validator)
+	if err != nil {
+		return oracle.Status{}, err
+	}
+
+	status, err := k.oracleWithConsensus(claim.Instance, k.GetParams(ctx).ConsensusNeeded.ConfirmedClaim).ProcessClaim(ctx, oracleClaim)
+	if err != nil {
+		return status, err
+	}
+	return status, k.trackProphecy(ctx, oracleClaim.ID, claim.ClaimType, claim.Instance, claim.MainchainTxHash, status)
+}
+
+// ProcessSuccessfulConfirmedInvitation adds the invited cosigner once consensus is reached on the confirmation
+// of the multisig modification inviting it
+func (k Keeper) ProcessSuccessfulConfirmedInvitation(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash) error {
+	request, err := k.GetPendingRequest(ctx, instance, mainchainTxHash)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrInvitationNotFound, err.Error())
+	}
+	reason := types.CosignerSetChangeInvitation
+	if invitation, found := k.GetCosignerInvitation(ctx, instance, request.Address); found {
+		reason = invitation.Reason
+	}
+	if err := k.AddNewCosigner(ctx, instance, request.Vault, request.Address, request.MainchainPublicKey, reason, mainchainTxHash); err != nil {
+		return err
+	}
+	k.deleteCosignerInvitation(ctx, instance, request.Address)
+	k.DeletePendingInviteRequest(ctx, instance, mainchainTxHash)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAddCosigner,
+			sdk.NewAttribute(types.AttributeKeyInstance, instance),
+			sdk.NewAttribute(types.AttributeKeyVault, request.Vault),
+			sdk.NewAttribute(types.AttributeKeyValidator, request.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyCosignerPublicKey, request.MainchainPublicKey.String()),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, mainchainTxHash.String()),
+		),
+	)
+	return nil
+}
+
+// ProcessSuccessfulConfirmedRemoval removes the cosigner once consensus is reached on the confirmation
+// of the multisig modification removing it
+func (k Keeper) ProcessSuccessfulConfirmedRemoval(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash) error {
+	request, err := k.GetPendingRemovalRequest(ctx, instance, mainchainTxHash)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrRemovalNotFound, err.Error())
+	}
+	reason := types.CosignerSetChangeRemoval
+	if removal, found := k.GetCosignerRemoval(ctx, instance, request.MainchainPublicKey); found {
+		reason = removal.Reason
+	}
+	if _, found := k.GetCosigner(ctx, instance, request.MainchainPublicKey); found {
+		if err := k.RemoveCosigner(ctx, instance, request.MainchainPublicKey, reason, mainchainTxHash); err != nil {
+			return err
+		}
+	}
+	k.deleteCosignerRemoval(ctx, instance, request.MainchainPublicKey)
+	k.DeletePendingRemovalRequest(ctx, instance, mainchainTxHash)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRemoveCosigner,
+			sdk.NewAttribute(types.AttributeKeyInstance, instance),
+			sdk.NewAttribute(types.AttributeKeyValidator, request.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyCosignerPublicKey, request.MainchainPublicKey.String()),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, mainchainTxHash.String()),
+		),
+	)
+	return nil
+}
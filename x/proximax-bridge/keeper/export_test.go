@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// ClaimStakingKeeper exposes the staking keeper claims of an instance are weighed with to the tests
+func (k Keeper) ClaimStakingKeeper(name string) types.StakingKeeper {
+	return k.claimStakingKeeper(name)
+}
+
+// OracleKeeper exposes the oracle keeper to the tests
+func (k Keeper) OracleKeeper() types.OracleKeeper {
+	return k.oracleKeeper
+}
+
+// Paramspace exposes the param subspace to the tests
+func (k Keeper) Paramspace() types.ParamSubspace {
+	return k.paramspace
+}
+
+// DeactivateCosigner exposes the deactivation of a cosigner to the tests
+func (k Keeper) DeactivateCosigner(ctx sdk.Context, validator sdk.ValAddress) {
+	k.deactivateCosigner(ctx, validator)
+}
@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetFeePool returns the bridge fees collected and not paid out yet
+func (k Keeper) GetFeePool(ctx sdk.Context) sdk.Coins {
+	return k.supplyKeeper.GetModuleAccount(ctx, types.FeePoolName).GetCoins()
+}
+
+// EstimateFee splits an amount bridged in a direction into the bridge fee and the net amount
+func (k Keeper) EstimateFee(ctx sdk.Context, direction string, amount sdk.Coins) (fee, net sdk.Coins) {
+	fee = k.GetParams(ctx).ComputeFee(direction, amount)
+	return fee, amount.Sub(fee)
+}
+
+// UnpegFee splits the amount of an unpeg into the bridge fee and the net amount paid out on the mainchain,
+// every coin of the unpeg has to be more than its fee
+func (k Keeper) UnpegFee(ctx sdk.Context, amount sdk.Coins) (fee, net sdk.Coins, err error) {
+	fee, net = k.EstimateFee(ctx, types.FeeDirectionUnpeg, amount)
+	if net.Empty() || !amount.IsAllGT(fee) {
+		return nil, nil, sdkerrors.Wrapf(types.ErrAmountBelowFee, "fee %s on %s", fee, amount)
+	}
+	return fee, net, nil
+}
+
+// UnpegRecipientsFee charges the bridge fee on what each recipient of an unpeg receives,
+// and returns the total fee with the net amount paid out to each of them
+func (k Keeper) UnpegRecipientsFee(ctx sdk.Context, msg types.MsgUnpeg) (sdk.Coins, []types.UnpegRecipient, error) {
+	fee := sdk.NewCoins()
+	recipients := make([]types.UnpegRecipient, 0, len(msg.GetRecipients()))
+	for _, recipient := range msg.GetRecipients() {
+		recipientFee, net, err := k.UnpegFee(ctx, recipient.Amount)
+		if err != nil {
+			return nil, nil, sdkerrors.Wrap(err, recipient.MainchainAddress.String())
+		}
+		fee = fee.Add(recipientFee...)
+		recipients = append(recipients, types.NewUnpegRecipient(recipient.MainchainAddress, net))
+	}
+	return fee, recipients, nil
+}
+
+// collectFee moves a bridge fee from the module account into the fee pool
+func (k Keeper) collectFee(ctx sdk.Context, instance, direction string, mainchainTxHash types.MainchainTxHash, fee sdk.Coins) error {
+	if fee.Empty() {
+		return nil
+	}
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, types.FeePoolName, fee); err != nil {
+		return err
+	}
+
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyInstance, instance),
+		sdk.NewAttribute(types.AttributeKeyDirection, direction),
+		sdk.NewAttribute(types.AttributeKeyFee, fee.String()),
+	}
+	if !mainchainTxHash.Empty() {
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyMainchainTxHash, mainchainTxHash.String()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeBridgeFee, attributes...))
+	return nil
+}
@@ -0,0 +1,86 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/peggy/x/oracle"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// setPegFee charges a flat 2 and 0.5% on pegs of xpx
+func setPegFee(input testutil.TestInput) {
+	params := input.Keeper.GetParams(input.Ctx)
+	params.Fees = []types.DenomFee{{
+		Denom: types.DefaultDenom,
+		Peg:   types.NewFeeRate(sdk.NewInt(2), 50),
+		Unpeg: types.NewFeeRate(sdk.ZeroInt(), 0),
+	}}
+	input.Keeper.SetParams(input.Ctx, params)
+}
+
+func TestEstimateFee(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	setPegFee(input)
+
+	fee, net := input.Keeper.EstimateFee(input.Ctx, types.FeeDirectionPeg, xpx(1000))
+	require.Equal(t, xpx(7), fee)
+	require.Equal(t, xpx(993), net)
+
+	// each direction has its own rate
+	fee, net = input.Keeper.EstimateFee(input.Ctx, types.FeeDirectionUnpeg, xpx(1000))
+	require.True(t, fee.Empty())
+	require.Equal(t, xpx(1000), net)
+
+	// a denom without a fee is bridged for free
+	other := sdk.NewCoins(sdk.NewInt64Coin("other", 1000))
+	fee, net = input.Keeper.EstimateFee(input.Ctx, types.FeeDirectionPeg, other)
+	require.True(t, fee.Empty())
+	require.Equal(t, other, net)
+
+	// the fee never takes more than the amount
+	fee, net = input.Keeper.EstimateFee(input.Ctx, types.FeeDirectionPeg, xpx(1))
+	require.Equal(t, xpx(1), fee)
+	require.True(t, net.Empty())
+}
+
+func TestUnpegFeeBelowAmount(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	setUnpegFee(input)
+
+	fee, net, err := input.Keeper.UnpegFee(input.Ctx, xpx(200))
+	require.NoError(t, err)
+	require.Equal(t, xpx(3), fee)
+	require.Equal(t, xpx(197), net)
+
+	// nothing would be paid out
+	_, _, err = input.Keeper.UnpegFee(input.Ctx, xpx(1))
+	require.True(t, types.ErrAmountBelowFee.Is(err))
+}
+
+func TestPegFeeCollected(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.5", types.ClaimWeightingValidators)
+	setPegFee(input)
+
+	claim := testPegClaim(input.Validators[0])
+	claim.Amount = xpx(1000)
+	_, err := input.Keeper.ProcessPegClaim(input.Ctx, claim)
+	require.NoError(t, err)
+	claim.ValidatorAddress = input.Validators[1]
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, claim)
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+	require.NoError(t, input.Keeper.ProcessSuccessfulPegClaim(input.Ctx, status.FinalClaim))
+
+	// the recipient receives the net, the fee pool the fee and the vault holds the whole deposit
+	require.Equal(t, xpx(993), input.BankKeeper.GetCoins(input.Ctx, testutil.AccAddressFromSeed(1)))
+	require.Equal(t, xpx(7), input.Keeper.GetFeePool(input.Ctx))
+	require.Equal(t, xpx(1000), input.Keeper.GetVaultBalance(input.Ctx, testutil.TestInstance, "hot"))
+	require.True(t, input.SupplyKeeper.GetModuleAccount(input.Ctx, types.ModuleName).GetCoins().IsZero())
+}
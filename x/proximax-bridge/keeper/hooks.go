@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// Hooks wrapper struct for the bridge keeper
+type Hooks struct {
+	k Keeper
+}
+
+var _ stakingtypes.StakingHooks = Hooks{}
+
+// Hooks returns the staking hooks of the bridge keeper
+func (k Keeper) Hooks() Hooks { return Hooks{k} }
+
+// AfterValidatorBeginUnbonding deactivates the cosigner of a validator leaving the bonded set,
+// which happens when it is jailed, tombstoned or unbonded by its delegators
+func (h Hooks) AfterValidatorBeginUnbonding(ctx sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.k.deactivateCosigner(ctx, valAddr)
+}
+
+// AfterValidatorRemoved deactivates the cosigner of a validator which is gone for good
+func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.k.deactivateCosigner(ctx, valAddr)
+}
+
+// AfterValidatorBonded reactivates the cosigner of a validator coming back to the bonded set
+func (h Hooks) AfterValidatorBonded(ctx sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	ctx.KVStore(h.k.storeKey).Delete(types.GetInactiveCosignerKey(valAddr))
+}
+
+// nolint - unused hooks
+func (h Hooks) AfterValidatorCreated(_ sdk.Context, _ sdk.ValAddress)                            {}
+func (h Hooks) BeforeValidatorModified(_ sdk.Context, _ sdk.ValAddress)                          {}
+func (h Hooks) BeforeDelegationCreated(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) BeforeDelegationSharesModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress) {}
+func (h Hooks) BeforeDelegationRemoved(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) AfterDelegationModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) BeforeValidatorSlashed(_ sdk.Context, _ sdk.ValAddress, _ sdk.Dec)                {}
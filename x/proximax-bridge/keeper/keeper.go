@@ -9,37 +9,48 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/peggy/x/oracle"
 	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
 )
 
 // Keeper of the proximax-bridge store
 type Keeper struct {
-	storeKey          sdk.StoreKey
-	storeKeyForPeg    sdk.StoreKey
-	storeKeyForUnpeg  sdk.StoreKey
-	storeKeyForCosign sdk.StoreKey
-	storeKeyForInvite sdk.StoreKey
-	cdc               *codec.Codec
-	paramspace        types.ParamSubspace
-	supplyKeeper      types.SupplyKeeper
-	slashingKeeper    types.SlashingKeeper
-	oracleKeeper      types.OracleKeeper
+	storeKey            sdk.StoreKey
+	storeKeyForPeg      sdk.StoreKey
+	storeKeyForUnpeg    sdk.StoreKey
+	storeKeyForCosign   sdk.StoreKey
+	storeKeyForInvite   sdk.StoreKey
+	storeKeyForRemoval  sdk.StoreKey
+	storeKeyForProphecy sdk.StoreKey
+	oracleStoreKey      sdk.StoreKey
+	cdc                 *codec.Codec
+	paramspace          types.ParamSubspace
+	supplyKeeper        types.SupplyKeeper
+	slashingKeeper      types.SlashingKeeper
+	stakingKeeper       types.StakingKeeper
+	oracleKeeper        types.OracleKeeper
+	oracles             *oracleKeepers
 }
 
 // NewKeeper creates a proximax-bridge keeper
-func NewKeeper(cdc *codec.Codec, key, keyForPeg, keyForUnpeg, keyForCosign, keyForInvite sdk.StoreKey, paramspace types.ParamSubspace, supplyKeeper types.SupplyKeeper, slashingKeeper types.SlashingKeeper, oracleKeeper types.OracleKeeper) Keeper {
+func NewKeeper(cdc *codec.Codec, key, keyForPeg, keyForUnpeg, keyForCosign, keyForInvite, keyForRemoval, keyForProphecy, oracleStoreKey sdk.StoreKey, paramspace types.ParamSubspace, supplyKeeper types.SupplyKeeper, slashingKeeper types.SlashingKeeper, stakingKeeper types.StakingKeeper, oracleKeeper types.OracleKeeper) Keeper {
 	keeper := Keeper{
-		storeKey:          key,
-		storeKeyForPeg:    keyForPeg,
-		storeKeyForUnpeg:  keyForUnpeg,
-		storeKeyForCosign: keyForCosign,
-		storeKeyForInvite: keyForInvite,
-		cdc:               cdc,
-		paramspace:        paramspace.WithKeyTable(types.ParamKeyTable()),
-		supplyKeeper:      supplyKeeper,
-		slashingKeeper:    slashingKeeper,
-		oracleKeeper:      oracleKeeper,
+		storeKey:            key,
+		storeKeyForPeg:      keyForPeg,
+		storeKeyForUnpeg:    keyForUnpeg,
+		storeKeyForCosign:   keyForCosign,
+		storeKeyForInvite:   keyForInvite,
+		storeKeyForRemoval:  keyForRemoval,
+		storeKeyForProphecy: keyForProphecy,
+		oracleStoreKey:      oracleStoreKey,
+		cdc:                 cdc,
+		paramspace:          paramspace.WithKeyTable(types.ParamKeyTable()),
+		supplyKeeper:        supplyKeeper,
+		slashingKeeper:      slashingKeeper,
+		stakingKeeper:       stakingKeeper,
+		oracleKeeper:        oracleKeeper,
+		oracles:             &oracleKeepers{},
 	}
 	return keeper
 }
@@ -50,74 +61,84 @@ func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 }
 
 type PegRecord struct {
-	MainchainTxHash string    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	Consumed        sdk.Coins `json:"consumed" yaml:"consumed"`
-	Remainning      int64     `json:"remainning" yaml:"remainning"`
+	Instance        string                `json:"instance" yaml:"instance"`
+	MainchainTxHash types.MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Consumed        sdk.Coins             `json:"consumed" yaml:"consumed"`
+	Remainning      int64                 `json:"remainning" yaml:"remainning"`
 }
 
-func (k Keeper) SetPegRecord(ctx sdk.Context, txHash string, consumed sdk.Coins, remainning int64) error {
-	peg := PegRecord{MainchainTxHash: txHash, Consumed: consumed, Remainning: remainning}
+func (k Keeper) SetPegRecord(ctx sdk.Context, instance string, txHash types.MainchainTxHash, consumed sdk.Coins, remainning int64) error {
+	peg := PegRecord{Instance: instance, MainchainTxHash: txHash, Consumed: consumed, Remainning: remainning}
 	pegBytes, err := json.Marshal(peg)
 	if err != nil {
 		return err
 	}
-	ctx.KVStore(k.storeKeyForPeg).Set([]byte(txHash), pegBytes)
+	ctx.KVStore(k.storeKeyForPeg).Set(types.GetMainchainTxKey(instance, txHash), pegBytes)
 	return nil
 }
 
-func (k Keeper) GetPegRecord(ctx sdk.Context, txHash string) (PegRecord, error) {
+func (k Keeper) GetPegRecord(ctx sdk.Context, instance string, txHash types.MainchainTxHash) (PegRecord, error) {
 	peg := PegRecord{}
-	if !ctx.KVStore(k.storeKeyForPeg).Has([]byte(txHash)) {
+	if !ctx.KVStore(k.storeKeyForPeg).Has(types.GetMainchainTxKey(instance, txHash)) {
 		return peg, errors.New(fmt.Sprintf("Peg Record is Not Found: %s", txHash))
 	}
-	unpegBytes := ctx.KVStore(k.storeKeyForPeg).Get([]byte(txHash))
+	unpegBytes := ctx.KVStore(k.storeKeyForPeg).Get(types.GetMainchainTxKey(instance, txHash))
 	err := json.Unmarshal(unpegBytes, &peg)
 	return peg, err
 }
 
-func (k Keeper) IsUsedHash(ctx sdk.Context, hash string) bool {
-	return ctx.KVStore(k.storeKeyForPeg).Has([]byte(hash))
+func (k Keeper) IsUsedHash(ctx sdk.Context, instance string, hash types.MainchainTxHash) bool {
+	return ctx.KVStore(k.storeKeyForPeg).Has(types.GetMainchainTxKey(instance, hash))
 }
 
-func (k Keeper) MarkAsUsedHash(ctx sdk.Context, hash string) {
-	ctx.KVStore(k.storeKeyForPeg).Set([]byte(hash), []byte(hash))
+func (k Keeper) MarkAsUsedHash(ctx sdk.Context, instance string, hash types.MainchainTxHash) {
+	ctx.KVStore(k.storeKeyForPeg).Set(types.GetMainchainTxKey(instance, hash), []byte(hash))
 }
 
 type UnpegRecord struct {
-	Address         sdk.AccAddress `json:"address" yaml:"address"`
-	MainchainTxHash string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	Amount          sdk.Coins      `json:"amount" yaml:"amount"`
+	Instance        string                 `json:"instance" yaml:"instance"`
+	Vault           string                 `json:"vault" yaml:"vault"`
+	Address         sdk.AccAddress         `json:"address" yaml:"address"`
+	MainchainTxHash types.MainchainTxHash  `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Amount          sdk.Coins              `json:"amount" yaml:"amount"`
+	Recipients      []types.UnpegRecipient `json:"recipients" yaml:"recipients"`
 }
 
-func (k Keeper) SetUnpegRecord(ctx sdk.Context, mainChainTxHash string, accountAddress sdk.AccAddress, amount sdk.Coins) error {
-	unpeg := UnpegRecord{Address: accountAddress, MainchainTxHash: mainChainTxHash, Amount: amount}
+func (k Keeper) SetUnpegRecord(ctx sdk.Context, instance, vault string, mainChainTxHash types.MainchainTxHash, accountAddress sdk.AccAddress, amount sdk.Coins, recipients []types.UnpegRecipient) error {
+	unpeg := UnpegRecord{Instance: instance, Vault: vault, Address: accountAddress, MainchainTxHash: mainChainTxHash, Amount: amount, Recipients: recipients}
 	unpegBytes, err := json.Marshal(unpeg)
 	if err != nil {
 		return err
 	}
-	ctx.KVStore(k.storeKeyForUnpeg).Set([]byte(mainChainTxHash), unpegBytes)
+	ctx.KVStore(k.storeKeyForUnpeg).Set(types.GetMainchainTxKey(instance, mainChainTxHash), unpegBytes)
 	return nil
 }
 
-func (k Keeper) GetUnpegRecord(ctx sdk.Context, mainChainTxHash string) (UnpegRecord, error) {
+func (k Keeper) GetUnpegRecord(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash) (UnpegRecord, error) {
 	unpeg := UnpegRecord{}
-	if !ctx.KVStore(k.storeKeyForUnpeg).Has([]byte(mainChainTxHash)) {
+	if !ctx.KVStore(k.storeKeyForUnpeg).Has(types.GetMainchainTxKey(instance, mainChainTxHash)) {
 		return unpeg, errors.New(fmt.Sprintf("Unpeg Record is Not Found: %s", mainChainTxHash))
 	}
-	unpegBytes := ctx.KVStore(k.storeKeyForUnpeg).Get([]byte(mainChainTxHash))
+	unpegBytes := ctx.KVStore(k.storeKeyForUnpeg).Get(types.GetMainchainTxKey(instance, mainChainTxHash))
 	err := json.Unmarshal(unpegBytes, &unpeg)
 	return unpeg, err
 }
 
 type CosignersRecord struct {
-	MainchainTxHadh    string   `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	CosignerPublicKeys []string `json:"cosigner_public_keys" yaml:"cosigner_public_keys"`
+	Instance           string                     `json:"instance" yaml:"instance"`
+	MainchainTxHadh    types.MainchainTxHash      `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	CosignerPublicKeys []types.MainchainPublicKey `json:"cosigner_public_keys" yaml:"cosigner_public_keys"`
 }
 
-func (k Keeper) SetCosigners(ctx sdk.Context, mainChainTxHash string, cosignerPublicKey string) error {
-	cosignerRecord, err := k.GetCosignersRecord(ctx, mainChainTxHash)
+// SetCosigners records a cosignature of a multisig transaction the zone asked for
+// and credits the cosigner behind it, once per transaction
+func (k Keeper) SetCosigners(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash, cosignerPublicKey types.MainchainPublicKey) error {
+	if !k.isKnownMultisigTx(ctx, instance, mainChainTxHash) {
+		return sdkerrors.Wrap(types.ErrMultisigTxNotFound, mainChainTxHash.String())
+	}
+	cosignerRecord, err := k.GetCosignersRecord(ctx, instance, mainChainTxHash)
 	if err != nil {
-		cosignerRecord = CosignersRecord{MainchainTxHadh: mainChainTxHash, CosignerPublicKeys: []string{}}
+		cosignerRecord = CosignersRecord{Instance: instance, MainchainTxHadh: mainChainTxHash, CosignerPublicKeys: []types.MainchainPublicKey{}}
 	}
 	for _, key := range cosignerRecord.CosignerPublicKeys {
 		if key == cosignerPublicKey {
@@ -129,29 +150,43 @@ func (k Keeper) SetCosigners(ctx sdk.Context, mainChainTxHash string, cosignerPu
 	if err != nil {
 		return err
 	}
-	ctx.KVStore(k.storeKeyForCosign).Set([]byte(mainChainTxHash), cosignerRecordBytes)
+	ctx.KVStore(k.storeKeyForCosign).Set(types.GetMainchainTxKey(instance, mainChainTxHash), cosignerRecordBytes)
+	k.creditCosigner(ctx, instance, cosignerPublicKey)
 	return nil
 }
 
-func (k Keeper) GetCosignersRecord(ctx sdk.Context, mainChainTxHash string) (CosignersRecord, error) {
+// isKnownMultisigTx returns true when the transaction is an unpeg, an unpeg batch, a cosigner invitation or removal
+// or a vault transfer announced to the zone
+func (k Keeper) isKnownMultisigTx(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash) bool {
+	if _, err := k.GetUnpegRecord(ctx, instance, mainchainTxHash); err == nil {
+		return true
+	}
+	if _, found := k.GetUnpegBatchByTx(ctx, instance, mainchainTxHash); found {
+		return true
+	}
+	if _, err := k.GetPendingRequest(ctx, instance, mainchainTxHash); err == nil {
+		return true
+	}
+	if _, err := k.GetPendingRemovalRequest(ctx, instance, mainchainTxHash); err == nil {
+		return true
+	}
+	_, found := k.GetVaultTransfer(ctx, instance, mainchainTxHash)
+	return found
+}
+
+func (k Keeper) GetCosignersRecord(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash) (CosignersRecord, error) {
 	cosignersRecord := CosignersRecord{}
-	if !ctx.KVStore(k.storeKeyForCosign).Has([]byte(mainChainTxHash)) {
+	if !ctx.KVStore(k.storeKeyForCosign).Has(types.GetMainchainTxKey(instance, mainChainTxHash)) {
 		return cosignersRecord, errors.New(fmt.Sprintf("CosignersRecord Record is Not Found: %s", mainChainTxHash))
 	}
-	unpegBytes := ctx.KVStore(k.storeKeyForCosign).Get([]byte(mainChainTxHash))
+	unpegBytes := ctx.KVStore(k.storeKeyForCosign).Get(types.GetMainchainTxKey(instance, mainChainTxHash))
 	err := json.Unmarshal(unpegBytes, &cosignersRecord)
 	return cosignersRecord, err
 }
 
-type PendingInviteRequest struct {
-	Address            sdk.ValAddress `json:"address" yaml:"address"`
-	MainchainPublicKey string         `json:"mainchain_public_key" yaml:"mainchain_public_key"`
-	MainchainTxHash    string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-}
-
-func (k Keeper) SetPendingInviteRequest(ctx sdk.Context, txHash string, address sdk.ValAddress, mainchainPublicKey string) error {
-	pendingRequest := PendingInviteRequest{Address: address, MainchainPublicKey: mainchainPublicKey, MainchainTxHash: txHash}
-	_, err := k.GetCosignersRecord(ctx, txHash)
+func (k Keeper) SetPendingInviteRequest(ctx sdk.Context, instance, vault string, txHash types.MainchainTxHash, address sdk.ValAddress, mainchainPublicKey types.MainchainPublicKey) error {
+	pendingRequest := types.PendingInviteRequest{Instance: instance, Vault: vault, Address: address, MainchainPublicKey: mainchainPublicKey, MainchainTxHash: txHash}
+	_, err := k.GetCosignersRecord(ctx, instance, txHash)
 	if err == nil {
 		return nil
 	}
@@ -159,20 +194,78 @@ func (k Keeper) SetPendingInviteRequest(ctx sdk.Context, txHash string, address
 	if err != nil {
 		return err
 	}
-	ctx.KVStore(k.storeKeyForInvite).Set([]byte(txHash), reqBytes)
+	ctx.KVStore(k.storeKeyForInvite).Set(types.GetMainchainTxKey(instance, txHash), reqBytes)
 	return nil
 }
 
-func (k Keeper) GetPendingRequest(ctx sdk.Context, mainChainTxHash string) (PendingInviteRequest, error) {
-	pendingInviteRequest := PendingInviteRequest{}
-	if !ctx.KVStore(k.storeKeyForInvite).Has([]byte(mainChainTxHash)) {
+func (k Keeper) GetPendingRequest(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash) (types.PendingInviteRequest, error) {
+	pendingInviteRequest := types.PendingInviteRequest{}
+	if !ctx.KVStore(k.storeKeyForInvite).Has(types.GetMainchainTxKey(instance, mainChainTxHash)) {
 		return pendingInviteRequest, errors.New(fmt.Sprintf("PendingInviteRequest Record is Not Found: %s", mainChainTxHash))
 	}
-	reqBytes := ctx.KVStore(k.storeKeyForInvite).Get([]byte(mainChainTxHash))
+	reqBytes := ctx.KVStore(k.storeKeyForInvite).Get(types.GetMainchainTxKey(instance, mainChainTxHash))
 	err := json.Unmarshal(reqBytes, &pendingInviteRequest)
 	return pendingInviteRequest, err
 }
 
+func (k Keeper) DeletePendingInviteRequest(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash) {
+	ctx.KVStore(k.storeKeyForInvite).Delete(types.GetMainchainTxKey(instance, mainChainTxHash))
+}
+
+// GetPendingInviteRequests returns the announced invitations of all instances waiting to be confirmed
+func (k Keeper) GetPendingInviteRequests(ctx sdk.Context) []types.PendingInviteRequest {
+	requests := []types.PendingInviteRequest{}
+	iterator := ctx.KVStore(k.storeKeyForInvite).Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var request types.PendingInviteRequest
+		if err := json.Unmarshal(iterator.Value(), &request); err != nil {
+			panic(err)
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func (k Keeper) SetPendingRemovalRequest(ctx sdk.Context, instance string, txHash types.MainchainTxHash, address sdk.ValAddress, mainchainPublicKey types.MainchainPublicKey) error {
+	pendingRequest := types.PendingRemovalRequest{Instance: instance, Address: address, MainchainPublicKey: mainchainPublicKey, MainchainTxHash: txHash}
+	reqBytes, err := json.Marshal(pendingRequest)
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKeyForRemoval).Set(types.GetMainchainTxKey(instance, txHash), reqBytes)
+	return nil
+}
+
+func (k Keeper) GetPendingRemovalRequest(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash) (types.PendingRemovalRequest, error) {
+	pendingRemovalRequest := types.PendingRemovalRequest{}
+	if !ctx.KVStore(k.storeKeyForRemoval).Has(types.GetMainchainTxKey(instance, mainChainTxHash)) {
+		return pendingRemovalRequest, errors.New(fmt.Sprintf("PendingRemovalRequest Record is Not Found: %s", mainChainTxHash))
+	}
+	reqBytes := ctx.KVStore(k.storeKeyForRemoval).Get(types.GetMainchainTxKey(instance, mainChainTxHash))
+	err := json.Unmarshal(reqBytes, &pendingRemovalRequest)
+	return pendingRemovalRequest, err
+}
+
+func (k Keeper) DeletePendingRemovalRequest(ctx sdk.Context, instance string, mainChainTxHash types.MainchainTxHash) {
+	ctx.KVStore(k.storeKeyForRemoval).Delete(types.GetMainchainTxKey(instance, mainChainTxHash))
+}
+
+// GetPendingRemovalRequests returns the announced removals of all instances waiting to be confirmed
+func (k Keeper) GetPendingRemovalRequests(ctx sdk.Context) []types.PendingRemovalRequest {
+	requests := []types.PendingRemovalRequest{}
+	iterator := ctx.KVStore(k.storeKeyForRemoval).Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var request types.PendingRemovalRequest
+		if err := json.Unmarshal(iterator.Value(), &request); err != nil {
+			panic(err)
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
 // ProcessClaim processes a new claim coming in from a validator
 func (k Keeper) ProcessPegClaim(ctx sdk.Context, claim types.MsgPegClaim) (oracle.Status, error) {
 	oracleClaim, err := types.CreateOracleClaimFromMsgPegClaim(k.cdc, claim)
@@ -180,44 +273,133 @@ func (k Keeper) ProcessPegClaim(ctx sdk.Context, claim types.MsgPegClaim) (oracl
 		return oracle.Status{}, err
 	}
 
-	return k.oracleKeeper.ProcessClaim(ctx, oracleClaim)
+	status, err := k.oracleWithConsensus(claim.Instance, k.GetParams(ctx).ConsensusNeeded.PegClaim).ProcessClaim(ctx, oracleClaim)
+	if err != nil {
+		return status, err
+	}
+	if err := k.setValidatorClaim(ctx, oracleClaim); err != nil {
+		return status, err
+	}
+	if status.Text == oracle.SuccessStatusText {
+		if err := k.processFaultyClaims(ctx, claim.Instance, claim.MainchainTxHash, oracleClaim.ID, status.FinalClaim); err != nil {
+			return status, err
+		}
+	}
+	return status, k.trackProphecy(ctx, oracleClaim.ID, types.ClaimTypePeg, claim.Instance, claim.MainchainTxHash, status)
 }
 
-// ProcessSuccessfulClaim processes a claim that has just completed successfully with consensus
+// ProcessSuccessfulClaim processes a claim that has just completed successfully with consensus.
+// The deposit is minted at once within the volume caps, or queued to be minted once they allow.
 func (k Keeper) ProcessSuccessfulPegClaim(ctx sdk.Context, claim string) error {
 	oracleClaim, err := types.CreateMsgPegClaimFromOracleString(claim)
 	if err != nil {
 		return err
 	}
 
+	// the whole deposit is held by the vault it was sent to
+	k.addToVault(ctx, oracleClaim.Instance, oracleClaim.Vault, oracleClaim.Amount)
+
+	// pegs are minted first in first out, a peg waits behind the queued ones
+	if len(k.GetQueuedPegs(ctx)) > 0 || !k.WithinVolumeCap(ctx, types.FeeDirectionPeg, oracleClaim.Amount) {
+		k.enqueuePeg(ctx, oracleClaim)
+		return nil
+	}
+	return k.mintPeg(ctx, oracleClaim)
+}
+
+// mintPeg mints a deposit, pays the recipient less the bridge fee and counts the peg in the volume
+func (k Keeper) mintPeg(ctx sdk.Context, oracleClaim types.MsgPegClaim) error {
 	if err := k.supplyKeeper.MintCoins(
 		ctx, types.ModuleName, oracleClaim.Amount,
 	); err != nil {
 		return err
 	}
 
-	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(
-		ctx, types.ModuleName, oracleClaim.Address, oracleClaim.Amount,
-	); err != nil {
+	// the recipient receives the deposit less the bridge fee, which goes to the fee pool
+	fee, net := k.EstimateFee(ctx, types.FeeDirectionPeg, oracleClaim.Amount)
+	if !net.Empty() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(
+			ctx, types.ModuleName, oracleClaim.Address, net,
+		); err != nil {
+			panic(err)
+		}
+	}
+	if err := k.collectFee(ctx, oracleClaim.Instance, types.FeeDirectionPeg, oracleClaim.MainchainTxHash, fee); err != nil {
 		panic(err)
 	}
+	k.addVolume(ctx, types.FeeDirectionPeg, oracleClaim.Amount)
 
 	return nil
 }
 
-func (k Keeper) ProcessUnpeg(ctx sdk.Context, msg types.MsgUnpeg) error {
-	if err := k.supplyKeeper.SendCoinsFromAccountToModule(
-		ctx, msg.Address, types.ModuleName, msg.Amount,
-	); err != nil {
+// EscrowUnpeg moves the coins of an unpeg from the sender to the module, which holds them until the unpeg is dispatched or refunded
+func (k Keeper) EscrowUnpeg(ctx sdk.Context, msg types.MsgUnpeg) error {
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Address, types.ModuleName, msg.Amount)
+}
+
+// DispatchUnpeg pays an unpeg whose coins the module holds out of a vault: it collects the bridge fee into the fee pool,
+// burns the rest, deducts it from the vault, counts the unpeg in the volume and emits the event the relayers act on.
+// With batching on, the unpeg joins the open batch of the vault instead, which the relayers act on once it closes.
+func (k Keeper) DispatchUnpeg(ctx sdk.Context, id uint64, msg types.MsgUnpeg) error {
+	fee, recipients, err := k.UnpegRecipientsFee(ctx, msg)
+	if err != nil {
+		return err
+	}
+	net := types.SumRecipients(recipients)
+	vault, err := k.RouteUnpeg(ctx, msg.Instance, net)
+	if err != nil {
+		return err
+	}
+	// only a cosigner of the vault paying the unpeg can announce it
+	if !k.IsActiveVaultCosigner(ctx, msg.Instance, vault.Name, msg.FirstCosignerAddress) {
+		firstCosignerAddress, found := k.GetActiveCosigner(ctx, msg.Instance, vault.Name, msg.FirstCosignerAddress)
+		if !found {
+			return sdkerrors.Wrap(types.ErrNoActiveCosigner, "no cosigner can initiate the unpeg")
+		}
+		msg.FirstCosignerAddress = firstCosignerAddress
+	}
+	if err := k.subtractFromVault(ctx, msg.Instance, vault.Name, net); err != nil {
+		return err
+	}
+
+	if err := k.collectFee(ctx, msg.Instance, types.FeeDirectionUnpeg, "", fee); err != nil {
 		return err
 	}
 
 	if err := k.supplyKeeper.BurnCoins(
-		ctx, types.ModuleName, msg.Amount,
+		ctx, types.ModuleName, net,
 	); err != nil {
 		return err
 	}
+	k.addVolume(ctx, types.FeeDirectionUnpeg, msg.Amount)
 
+	if k.GetParams(ctx).UnpegBatchWindow > 0 {
+		k.BatchUnpeg(ctx, id, msg, vault.Name, recipients)
+		return nil
+	}
+	k.SetPendingUnpeg(ctx, types.PendingUnpeg{
+		ID:                   id,
+		Instance:             msg.Instance,
+		Vault:                vault.Name,
+		Address:              msg.Address,
+		Amount:               net,
+		Recipients:           recipients,
+		FirstCosignerAddress: msg.FirstCosignerAddress,
+	})
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpeg,
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, vault.Name),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyMainchainAddress, msg.MainchainAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, net.String()),
+			sdk.NewAttribute(types.AttributeKeyFee, fee.String()),
+			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, msg.FirstCosignerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipients, types.RecipientsAttribute(recipients)),
+		),
+	)
 	return nil
 }
 
@@ -228,10 +410,14 @@ func (k Keeper) ProcessNotCosignedClaim(ctx sdk.Context, claim types.MsgNotCosig
 		return oracle.Status{}, err
 	}
 
-	return k.oracleKeeper.ProcessClaim(ctx, oracleClaim)
+	status, err := k.oracleWithConsensus(claim.Instance, k.GetParams(ctx).ConsensusNeeded.NotCosignedClaim).ProcessClaim(ctx, oracleClaim)
+	if err != nil {
+		return status, err
+	}
+	return status, k.trackProphecy(ctx, oracleClaim.ID, types.ClaimTypeNotCosigned, claim.Instance, claim.TxHash, status)
 }
 
-func searchStringFromArray(values []string, key string) bool {
+func searchStringFromArray(values []types.MainchainPublicKey, key types.MainchainPublicKey) bool {
 	for _, value := range values {
 		if value == key {
 			return true
@@ -248,7 +434,7 @@ func (k Keeper) ProcessSuccessfulNotCosignedClaim(ctx sdk.Context, claim string)
 	}
 
 	//unpeg
-	unpegRecord, err := k.GetUnpegRecord(ctx, oracleClaim.TxHash)
+	unpegRecord, err := k.GetUnpegRecord(ctx, oracleClaim.Instance, oracleClaim.TxHash)
 	if err == nil {
 		if err := k.supplyKeeper.MintCoins(
 			ctx, types.ModuleName, unpegRecord.Amount,
@@ -261,17 +447,47 @@ func (k Keeper) ProcessSuccessfulNotCosignedClaim(ctx sdk.Context, claim string)
 		); err != nil {
 			panic(err)
 		}
+		// the funds never left the vault
+		k.addToVault(ctx, oracleClaim.Instance, unpegRecord.Vault, unpegRecord.Amount)
+		// the inner transfers of the aggregate fail together, each recipient's share goes back to the sender
+		for _, recipient := range unpegRecord.Recipients {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeUnpegRefund,
+					sdk.NewAttribute(types.AttributeKeyInstance, unpegRecord.Instance),
+					sdk.NewAttribute(types.AttributeKeyMainchainTxHash, unpegRecord.MainchainTxHash.String()),
+					sdk.NewAttribute(types.AttributeKeyCosmosSender, unpegRecord.Address.String()),
+					sdk.NewAttribute(types.AttributeKeyMainchainAddress, recipient.MainchainAddress.String()),
+					sdk.NewAttribute(sdk.AttributeKeyAmount, recipient.Amount.String()),
+					sdk.NewAttribute(types.AttributeKeyReason, "not cosigned"),
+				),
+			)
+		}
+	}
+	// a batch refunds each of its unpegs
+	if batch, found := k.GetUnpegBatchByTx(ctx, oracleClaim.Instance, oracleClaim.TxHash); found {
+		k.failUnpegBatch(ctx, batch, "not cosigned")
 	}
 
 	// slash
-	cosignerRecord, err := k.GetCosignersRecord(ctx, oracleClaim.TxHash)
+	cosignerRecord, err := k.GetCosignersRecord(ctx, oracleClaim.Instance, oracleClaim.TxHash)
 	if err != nil {
 		return err
 	}
 
-	param := k.GetParams(ctx)
+	instance, found := k.GetInstance(ctx, oracleClaim.Instance)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, oracleClaim.Instance)
+	}
+	// only the cosigners of the vault the transaction was announced from could cosign it
+	cosigners := instance.Cosigners
+	if vault, found := k.getMainchainTxVault(ctx, instance, oracleClaim.TxHash); found {
+		cosigners = instance.VaultCosigners(vault)
+	}
+	k.CompleteVaultTransfer(ctx, oracleClaim.Instance, oracleClaim.TxHash, false)
+
 	notCosignedValidatorAddrs := []sdk.ValAddress{}
-	for _, cosigner := range param.Cosigners {
+	for _, cosigner := range cosigners {
 		if !searchStringFromArray(cosignerRecord.CosignerPublicKeys, cosigner.MainchainPublicKey) {
 			valAddress, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
 			if err == nil {
@@ -0,0 +1,82 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func xpx(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, amount))
+}
+
+// setUnpegFee charges a flat 1 and 1% on unpegs of xpx
+func setUnpegFee(input testutil.TestInput) {
+	params := input.Keeper.GetParams(input.Ctx)
+	params.Fees = []types.DenomFee{{
+		Denom: types.DefaultDenom,
+		Peg:   types.NewFeeRate(sdk.ZeroInt(), 0),
+		Unpeg: types.NewFeeRate(sdk.OneInt(), 100),
+	}}
+	input.Keeper.SetParams(input.Ctx, params)
+}
+
+func TestUnpegRecipientsFee(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	setUnpegFee(input)
+
+	msg := types.NewMsgUnpegToRecipients(testutil.AccAddressFromSeed(1), testutil.TestInstance, []types.UnpegRecipient{
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(3), xpx(100)),
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(4), xpx(300)),
+	}, input.Validators[0])
+	fee, recipients, err := input.Keeper.UnpegRecipientsFee(input.Ctx, msg)
+	require.NoError(t, err)
+
+	// every recipient pays the fee on its own share
+	require.Equal(t, xpx(6), fee)
+	require.Equal(t, xpx(98), recipients[0].Amount)
+	require.Equal(t, testutil.MainchainAddressFromSeed(3), recipients[0].MainchainAddress)
+	require.Equal(t, xpx(296), recipients[1].Amount)
+	require.Equal(t, testutil.MainchainAddressFromSeed(4), recipients[1].MainchainAddress)
+
+	// a share which does not cover the fee fails the whole unpeg
+	msg.Recipients[0].Amount = xpx(1)
+	msg.Amount = xpx(301)
+	_, _, err = input.Keeper.UnpegRecipientsFee(input.Ctx, msg)
+	require.Error(t, err)
+}
+
+func TestDispatchUnpegSplitsRecipients(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	input.SetVaults(input.Validators...)
+	setUnpegFee(input)
+	input.Keeper.SetVaultBalance(input.Ctx, testutil.TestInstance, "hot", xpx(1000))
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, xpx(400)))
+
+	msg := types.NewMsgUnpegToRecipients(testutil.AccAddressFromSeed(1), testutil.TestInstance, []types.UnpegRecipient{
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(3), xpx(100)),
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(4), xpx(300)),
+	}, input.Validators[0])
+	ctx := input.Ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, input.Keeper.DispatchUnpeg(ctx, 1, msg))
+
+	// the vault pays out the net of the shares, the fees go to the pool and the rest is burned
+	require.Equal(t, xpx(606), input.Keeper.GetVaultBalance(ctx, testutil.TestInstance, "hot"))
+	require.Equal(t, xpx(6), input.Keeper.GetFeePool(ctx))
+	require.True(t, input.SupplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins().IsZero())
+
+	unpegs := eventsOfType(ctx, types.EventTypeUnpeg)
+	require.Len(t, unpegs, 1)
+	require.Equal(t, xpx(394).String(), unpegs[0][sdk.AttributeKeyAmount])
+	recipients, err := types.ParseRecipientsAttribute(unpegs[0][types.AttributeKeyRecipients])
+	require.NoError(t, err)
+	require.Equal(t, []types.UnpegRecipient{
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(3), xpx(98)),
+		types.NewUnpegRecipient(testutil.MainchainAddressFromSeed(4), xpx(296)),
+	}, recipients)
+}
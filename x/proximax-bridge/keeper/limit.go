@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"encoding/json"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetVolume returns the amount of a denom bridged in the direction within the volume window
+func (k Keeper) GetVolume(ctx sdk.Context, direction, denom string) sdk.Int {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetVolumeTotalKey(direction, denom))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var volume sdk.Int
+	if err := json.Unmarshal(bz, &volume); err != nil {
+		panic(err)
+	}
+	return volume
+}
+
+func (k Keeper) setVolume(ctx sdk.Context, direction, denom string, volume sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	if !volume.IsPositive() {
+		store.Delete(types.GetVolumeTotalKey(direction, denom))
+		return
+	}
+	bz, err := json.Marshal(volume)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.GetVolumeTotalKey(direction, denom), bz)
+}
+
+func (k Keeper) getVolumeEntry(ctx sdk.Context, t time.Time, direction, denom string) (types.VolumeEntry, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetVolumeEntryKey(t, direction, denom))
+	if bz == nil {
+		return types.VolumeEntry{}, false
+	}
+	var entry types.VolumeEntry
+	if err := json.Unmarshal(bz, &entry); err != nil {
+		panic(err)
+	}
+	return entry, true
+}
+
+// SetVolumeEntry stores the amount bridged at a block time and counts it in the volume of the window
+func (k Keeper) SetVolumeEntry(ctx sdk.Context, entry types.VolumeEntry) {
+	volume := k.GetVolume(ctx, entry.Direction, entry.Denom).Add(entry.Amount)
+	if previous, found := k.getVolumeEntry(ctx, entry.Time, entry.Direction, entry.Denom); found {
+		volume = volume.Sub(previous.Amount)
+	}
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetVolumeEntryKey(entry.Time, entry.Direction, entry.Denom), bz)
+	k.setVolume(ctx, entry.Direction, entry.Denom, volume)
+}
+
+// GetVolumeEntries returns the amounts bridged within the volume window, oldest first
+func (k Keeper) GetVolumeEntries(ctx sdk.Context) []types.VolumeEntry {
+	entries := []types.VolumeEntry{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.VolumeEntryPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.VolumeEntry
+		if err := json.Unmarshal(iterator.Value(), &entry); err != nil {
+			panic(err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// addVolume counts the coins bridged in the direction at the current block time, for the denoms which have a limit
+func (k Keeper) addVolume(ctx sdk.Context, direction string, amount sdk.Coins) {
+	params := k.GetParams(ctx)
+	for _, coin := range amount {
+		if _, found := params.GetLimit(coin.Denom); !found {
+			continue
+		}
+		entry, found := k.getVolumeEntry(ctx, ctx.BlockTime(), direction, coin.Denom)
+		if !found {
+			entry = types.VolumeEntry{Direction: direction, Denom: coin.Denom, Time: ctx.BlockTime(), Amount: sdk.ZeroInt()}
+		}
+		entry.Amount = entry.Amount.Add(coin.Amount)
+		k.SetVolumeEntry(ctx, entry)
+	}
+}
+
+// WithinVolumeCap tells whether the coins can be bridged in the direction without exceeding the volume cap of their denoms
+func (k Keeper) WithinVolumeCap(ctx sdk.Context, direction string, amount sdk.Coins) bool {
+	params := k.GetParams(ctx)
+	for _, coin := range amount {
+		limit, found := params.GetLimit(coin.Denom)
+		if !found {
+			continue
+		}
+		if !limit.LimitOf(direction).WithinVolumeCap(k.GetVolume(ctx, direction, coin.Denom), coin.Amount) {
+			return false
+		}
+	}
+	return true
+}
+
+// PruneVolume drops the amounts bridged a volume window or longer before the block time from the volume
+func (k Keeper) PruneVolume(ctx sdk.Context) {
+	cutoff := ctx.BlockTime().Add(-k.GetParams(ctx).VolumeWindow)
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.VolumeEntryPrefix, sdk.PrefixEndBytes(types.GetVolumeEntriesTimePrefix(cutoff)))
+	var expired []types.VolumeEntry
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.VolumeEntry
+		if err := json.Unmarshal(iterator.Value(), &entry); err != nil {
+			panic(err)
+		}
+		expired = append(expired, entry)
+	}
+	iterator.Close()
+	for _, entry := range expired {
+		store.Delete(types.GetVolumeEntryKey(entry.Time, entry.Direction, entry.Denom))
+		k.setVolume(ctx, entry.Direction, entry.Denom, k.GetVolume(ctx, entry.Direction, entry.Denom).Sub(entry.Amount))
+	}
+}
@@ -0,0 +1,138 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/peggy/x/oracle"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetLockFundsReimbursement returns what the bridge owes a validator for lost lock funds
+func (k Keeper) GetLockFundsReimbursement(ctx sdk.Context, validator sdk.ValAddress) sdk.Coins {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetLockFundsReimbursementKey(validator))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	var reimbursement types.LockFundsReimbursement
+	if err := json.Unmarshal(bz, &reimbursement); err != nil {
+		panic(err)
+	}
+	return reimbursement.Outstanding
+}
+
+// SetLockFundsReimbursement stores what the bridge owes a validator, forgetting it once nothing is owed
+func (k Keeper) SetLockFundsReimbursement(ctx sdk.Context, validator sdk.ValAddress, outstanding sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	if outstanding.Empty() {
+		store.Delete(types.GetLockFundsReimbursementKey(validator))
+		return
+	}
+	bz, err := json.Marshal(types.LockFundsReimbursement{Validator: validator, Outstanding: outstanding})
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.GetLockFundsReimbursementKey(validator), bz)
+}
+
+// GetLockFundsReimbursements returns the outstanding reimbursements of every validator
+func (k Keeper) GetLockFundsReimbursements(ctx sdk.Context) []types.LockFundsReimbursement {
+	reimbursements := []types.LockFundsReimbursement{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.LockFundsReimbursementPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var reimbursement types.LockFundsReimbursement
+		if err := json.Unmarshal(iterator.Value(), &reimbursement); err != nil {
+			panic(err)
+		}
+		reimbursements = append(reimbursements, reimbursement)
+	}
+	return reimbursements
+}
+
+// ProcessLockFundsClaim processes a new claim on the outcome of the funds locked for an aggregate
+func (k Keeper) ProcessLockFundsClaim(ctx sdk.Context, claim types.MsgLockFundsClaim) (oracle.Status, error) {
+	oracleClaim, err := types.CreateOracleClaimFromMsgLockFundsClaim(k.cdc, claim)
+	if err != nil {
+		return oracle.Status{}, err
+	}
+
+	status, err := k.oracleWithConsensus(claim.Instance, k.GetParams(ctx).ConsensusNeeded.LockFundsClaim).ProcessClaim(ctx, oracleClaim)
+	if err != nil {
+		return status, err
+	}
+	return status, k.trackProphecy(ctx, oracleClaim.ID, types.ClaimTypeLockFunds, claim.Instance, claim.TxHash, status)
+}
+
+// ProcessSuccessfulLockFundsClaim owes the validator behind the initiator the lock funds cost when the aggregate expired.
+// Funds locked for an aggregate which was confirmed went back to the initiator, so they cost nothing.
+func (k Keeper) ProcessSuccessfulLockFundsClaim(ctx sdk.Context, claim string) error {
+	oracleClaim, err := types.CreateMsgLockFundsClaimFromOracleString(claim)
+	if err != nil {
+		return err
+	}
+
+	instance, found := k.GetInstance(ctx, oracleClaim.Instance)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, oracleClaim.Instance)
+	}
+	cosigner, found := instance.GetCosigner(oracleClaim.InitiatorPublicKey)
+	if !found {
+		return sdkerrors.Wrap(types.ErrCosignerNotFound, oracleClaim.InitiatorPublicKey.String())
+	}
+	validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+	if err != nil {
+		return err
+	}
+
+	cost := sdk.Coins{}
+	if oracleClaim.Outcome == types.LockFundsOutcomeExpired {
+		cost = k.GetParams(ctx).LockFundsCost
+		k.SetLockFundsReimbursement(ctx, validator, k.GetLockFundsReimbursement(ctx, validator).Add(cost...))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeLockFunds,
+			sdk.NewAttribute(types.AttributeKeyInstance, oracleClaim.Instance),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, oracleClaim.TxHash.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, validator.String()),
+			sdk.NewAttribute(types.AttributeKeyOutcome, oracleClaim.Outcome),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, cost.String()),
+		),
+	)
+	return nil
+}
+
+// reimburseLockFunds pays the outstanding reimbursements out of the fee pool, as far as the pool holds their denoms
+func (k Keeper) reimburseLockFunds(ctx sdk.Context) {
+	for _, reimbursement := range k.GetLockFundsReimbursements(ctx) {
+		pool := k.GetFeePool(ctx)
+		paid := sdk.Coins{}
+		for _, coin := range reimbursement.Outstanding {
+			amount := coin.Amount
+			if held := pool.AmountOf(coin.Denom); held.LT(amount) {
+				amount = held
+			}
+			if amount.IsPositive() {
+				paid = append(paid, sdk.NewCoin(coin.Denom, amount))
+			}
+		}
+		if paid.Empty() {
+			continue
+		}
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.FeePoolName, sdk.AccAddress(reimbursement.Validator), paid); err != nil {
+			panic(err)
+		}
+		k.SetLockFundsReimbursement(ctx, reimbursement.Validator, reimbursement.Outstanding.Sub(paid))
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeReimbursement,
+				sdk.NewAttribute(types.AttributeKeyValidator, reimbursement.Validator.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, paid.String()),
+			),
+		)
+	}
+}
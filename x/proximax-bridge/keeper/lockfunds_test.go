@@ -0,0 +1,64 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/peggy/x/oracle"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// claimLockFunds has every validator claim the outcome of the funds the first cosigner locked for an aggregate
+func claimLockFunds(t *testing.T, input testutil.TestInput, txHash types.MainchainTxHash, outcome string) {
+	var status oracle.Status
+	for _, validator := range input.Validators {
+		var err error
+		status, err = input.Keeper.ProcessLockFundsClaim(input.Ctx, types.NewMsgLockFundsClaim(validator, testutil.TestInstance, txHash, testutil.MainchainPublicKeyFromSeed(1), outcome))
+		require.NoError(t, err)
+	}
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+	require.NoError(t, input.Keeper.ProcessSuccessfulLockFundsClaim(input.Ctx, status.FinalClaim))
+}
+
+// fundFeePool adds bridge fees to the fee pool
+func fundFeePool(t *testing.T, input testutil.TestInput, amount sdk.Coins) {
+	require.NoError(t, input.SupplyKeeper.MintCoins(input.Ctx, types.ModuleName, amount))
+	require.NoError(t, input.SupplyKeeper.SendCoinsFromModuleToModule(input.Ctx, types.ModuleName, types.FeePoolName, amount))
+}
+
+func TestLockFundsReimbursedFromFees(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.LockFundsCost = xpx(10)
+	input.Keeper.SetParams(input.Ctx, params)
+	initiator := input.Validators[0]
+
+	// funds locked for a confirmed aggregate went back to the initiator
+	claimLockFunds(t, input, testutil.MainchainTxHashFromSeed(1), types.LockFundsOutcomeReturned)
+	require.True(t, input.Keeper.GetLockFundsReimbursement(input.Ctx, initiator).Empty())
+
+	claimLockFunds(t, input, testutil.MainchainTxHashFromSeed(2), types.LockFundsOutcomeExpired)
+	claimLockFunds(t, input, testutil.MainchainTxHashFromSeed(3), types.LockFundsOutcomeExpired)
+	require.Equal(t, xpx(20), input.Keeper.GetLockFundsReimbursement(input.Ctx, initiator))
+
+	// the fee pool pays out what it holds, the rest stays owed
+	fundFeePool(t, input, xpx(6))
+	ctx := input.Ctx.WithBlockHeight(params.FeeDistribution.Period)
+	input.Keeper.DistributeFees(ctx)
+	require.Equal(t, xpx(6), input.BankKeeper.GetCoins(ctx, sdk.AccAddress(initiator)))
+	require.Equal(t, xpx(14), input.Keeper.GetLockFundsReimbursement(ctx, initiator))
+	require.True(t, input.Keeper.GetFeePool(ctx).Empty())
+
+	fundFeePool(t, input, xpx(30))
+	ctx = input.Ctx.WithBlockHeight(2 * params.FeeDistribution.Period)
+	input.Keeper.DistributeFees(ctx)
+	require.Equal(t, xpx(20), input.BankKeeper.GetCoins(ctx, sdk.AccAddress(initiator)))
+	require.True(t, input.Keeper.GetLockFundsReimbursement(ctx, initiator).Empty())
+	require.Empty(t, input.Keeper.GetLockFundsReimbursements(ctx))
+	require.Equal(t, xpx(16), input.Keeper.GetFeePool(ctx))
+}
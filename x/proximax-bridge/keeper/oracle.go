@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"strconv"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/peggy/x/oracle"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// OracleKeeper is the keeper of the oracle module, extended with the deletion of prophecies
+// the bridge needs to expire those which never reached consensus.
+type OracleKeeper struct {
+	oracle.Keeper
+	storeKey sdk.StoreKey
+}
+
+var _ types.OracleKeeper = OracleKeeper{}
+
+// NewOracleKeeper extends the keeper of the oracle module over the given store
+func NewOracleKeeper(keeper oracle.Keeper, storeKey sdk.StoreKey) OracleKeeper {
+	return OracleKeeper{Keeper: keeper, storeKey: storeKey}
+}
+
+// DeleteProphecy forgets a prophecy together with its claims.
+// The oracle module keeps no API for it, so the prophecy is deleted under the key the oracle module stores it with.
+func (k OracleKeeper) DeleteProphecy(ctx sdk.Context, id string) {
+	ctx.KVStore(k.storeKey).Delete([]byte(id))
+}
+
+// oracleKeepers holds the oracle keepers of the bridge, one per instance and threshold
+type oracleKeepers struct {
+	keepers sync.Map
+}
+
+// oracleWithConsensus returns the oracle keeper over the shared oracle store which
+// finalizes prophecies on an instance once the given share of voting power agrees on a claim.
+// The threshold comes from params, so it can differ per claim type and be changed by governance,
+// and a keeper is built the first time a threshold is used.
+func (k Keeper) oracleWithConsensus(instance string, consensusNeeded sdk.Dec) types.OracleKeeper {
+	key := instance + "/" + consensusNeeded.String()
+	if keeper, found := k.oracles.keepers.Load(key); found {
+		return keeper.(types.OracleKeeper)
+	}
+	threshold, err := strconv.ParseFloat(consensusNeeded.String(), 64)
+	if err != nil {
+		panic(err)
+	}
+	keeper, _ := k.oracles.keepers.LoadOrStore(key, NewOracleKeeper(oracle.NewKeeper(k.cdc, k.oracleStoreKey, k.claimStakingKeeper(instance), threshold), k.oracleStoreKey))
+	return keeper.(types.OracleKeeper)
+}
+
+// claimStakingKeeper returns the validator set claims on an instance are weighed across
+func (k Keeper) claimStakingKeeper(instance string) types.StakingKeeper {
+	return claimStakingKeeper{StakingKeeper: k.stakingKeeper, bridgeKeeper: k, instance: instance}
+}
+
+// claimStakingKeeper is the validator set claims on an instance are weighed across, depending on the ClaimWeighting param.
+// Weighing across cosigners, it hides every validator which is not a registered cosigner of the instance from the oracle,
+// so only its cosigners can claim and consensus is reached on their stake alone.
+// The param and the cosigners are read at every call, as the keeper outlives the block it is built in.
+type claimStakingKeeper struct {
+	types.StakingKeeper
+	bridgeKeeper Keeper
+	instance     string
+}
+
+var _ types.StakingKeeper = claimStakingKeeper{}
+
+// weighs tells whether claims of the validator count
+func (sk claimStakingKeeper) weighs(ctx sdk.Context, validator sdk.ValAddress) bool {
+	params := sk.bridgeKeeper.GetParams(ctx)
+	if params.ClaimWeighting != types.ClaimWeightingCosigners {
+		return true
+	}
+	instance, _ := params.GetInstance(sk.instance)
+	return instance.HasCosigner(validator.String())
+}
+
+func (sk claimStakingKeeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (staking.Validator, bool) {
+	if !sk.weighs(ctx, addr) {
+		return staking.Validator{}, false
+	}
+	return sk.StakingKeeper.GetValidator(ctx, addr)
+}
+
+func (sk claimStakingKeeper) GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) int64 {
+	if !sk.weighs(ctx, operator) {
+		return 0
+	}
+	return sk.StakingKeeper.GetLastValidatorPower(ctx, operator)
+}
+
+// GetLastTotalPower returns the total last-block power of the bonded validators claims are weighed across,
+// measured the same way as the per-validator power the oracle weighs claims with.
+func (sk claimStakingKeeper) GetLastTotalPower(ctx sdk.Context) sdk.Int {
+	if sk.bridgeKeeper.GetParams(ctx).ClaimWeighting != types.ClaimWeightingCosigners {
+		return sk.StakingKeeper.GetLastTotalPower(ctx)
+	}
+	var total int64
+	for _, validator := range sk.GetBondedValidatorsByPower(ctx) {
+		total += sk.StakingKeeper.GetLastValidatorPower(ctx, validator.GetOperator())
+	}
+	return sdk.NewInt(total)
+}
+
+func (sk claimStakingKeeper) GetBondedValidatorsByPower(ctx sdk.Context) []staking.Validator {
+	validators := []staking.Validator{}
+	for _, validator := range sk.StakingKeeper.GetBondedValidatorsByPower(ctx) {
+		if sk.weighs(ctx, validator.OperatorAddress) {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
@@ -0,0 +1,98 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/peggy/x/oracle"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func testPegClaim(validator sdk.ValAddress) types.MsgPegClaim {
+	return types.NewMsgPegClaim(testutil.AccAddressFromSeed(1), testutil.TestInstance, "hot", testutil.MainchainTxHashFromSeed(1), testutil.MainchainAddressFromSeed(9), sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, 100)), 0, validator)
+}
+
+func setConsensusNeeded(input testutil.TestInput, pegClaim string, claimWeighting string) {
+	params := input.Keeper.GetParams(input.Ctx)
+	params.ConsensusNeeded.PegClaim = sdk.MustNewDecFromStr(pegClaim)
+	params.ClaimWeighting = claimWeighting
+	input.Keeper.SetParams(input.Ctx, params)
+}
+
+func TestPegClaimThreshold(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.7", types.ClaimWeightingValidators)
+
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[0]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+
+	// 60% of the power is still short of the threshold
+	status, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+
+	status, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[2]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+
+	_, err = input.Keeper.GetProphecyRecord(input.Ctx, types.GetPegClaimProphecyID(testPegClaim(input.Validators[0])))
+	require.Error(t, err, "a finalized prophecy is no longer tracked")
+}
+
+func TestPegClaimThresholdPerClaimType(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.5", types.ClaimWeightingValidators)
+
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[0]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+
+	status, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+}
+
+func TestPegClaimWeightedAcrossCosigners(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators[0], input.Validators[1])
+	setConsensusNeeded(input, "0.7", types.ClaimWeightingCosigners)
+
+	// a validator which is not a cosigner cannot claim
+	_, err := input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[2]))
+	require.Error(t, err)
+
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[0]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+
+	// the two cosigners hold all of the power claims are weighed across
+	status, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+}
+
+func TestCosignerTotalPowerIsLastPower(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators[0], input.Validators[1])
+	setConsensusNeeded(input, "0.7", types.ClaimWeightingCosigners)
+
+	// the tokens of a cosigner change within the block, its last power does not until the end of the block
+	validator, found := input.StakingKeeper.GetValidator(input.Ctx, input.Validators[0])
+	require.True(t, found)
+	input.StakingKeeper.DeleteValidatorByPowerIndex(input.Ctx, validator)
+	validator, _ = validator.AddTokensFromDel(sdk.TokensFromConsensusPower(70))
+	input.StakingKeeper.SetValidator(input.Ctx, validator)
+	input.StakingKeeper.SetValidatorByPowerIndex(input.Ctx, validator)
+
+	sk := input.Keeper.ClaimStakingKeeper(testutil.TestInstance)
+	require.Equal(t, sdk.NewInt(60), sk.GetLastTotalPower(input.Ctx))
+	require.Equal(t, int64(30), sk.GetLastValidatorPower(input.Ctx, input.Validators[0]))
+	require.Equal(t, int64(0), sk.GetLastValidatorPower(input.Ctx, input.Validators[2]))
+}
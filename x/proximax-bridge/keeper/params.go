@@ -3,29 +3,159 @@ package keeper
 // TODO: Define if your module needs Parameters, if not this can be deleted
 
 import (
+	"encoding/json"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
 )
 
-// GetParams returns the total set of proximax-bridge parameters.
+// GetParams returns the total set of proximax-bridge parameters,
+// with the cosigners of each instance from the module store.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 	k.paramspace.GetParamSet(ctx, &params)
+	for i := range params.Instances {
+		params.Instances[i].Cosigners = k.getCosigners(ctx, params.Instances[i].Name)
+	}
 	return params
 }
 
 // SetParams sets the proximax-bridge parameters to the param space.
+// The cosigners of the instances are left out, they change only through AddNewCosigner and RemoveCosigner.
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	instances := make([]types.BridgeInstance, len(params.Instances))
+	for i, instance := range params.Instances {
+		instance.Cosigners = []types.Cosigner{}
+		instances[i] = instance
+	}
+	params.Instances = instances
 	k.paramspace.SetParamSet(ctx, &params)
 }
 
-func (k Keeper) AddNewCosigner(ctx sdk.Context, address sdk.ValAddress, mainchainPublicKey string) {
+// SetCosigner stores a cosigner of an instance as it is, without logging the change, for the genesis
+func (k Keeper) SetCosigner(ctx sdk.Context, name string, cosigner types.Cosigner) {
+	bz, err := json.Marshal(cosigner)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetCosignerKey(name, cosigner.MainchainPublicKey), bz)
+}
+
+func (k Keeper) getCosigners(ctx sdk.Context, name string) []types.Cosigner {
+	cosigners := []types.Cosigner{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetCosignersPrefix(name))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var cosigner types.Cosigner
+		if err := json.Unmarshal(iterator.Value(), &cosigner); err != nil {
+			panic(err)
+		}
+		cosigners = append(cosigners, cosigner)
+	}
+	return cosigners
+}
+
+// GetInstance returns the bridge instance with the given name
+func (k Keeper) GetInstance(ctx sdk.Context, name string) (types.BridgeInstance, bool) {
+	return k.GetParams(ctx).GetInstance(name)
+}
+
+// GetInstances returns every bridge instance
+func (k Keeper) GetInstances(ctx sdk.Context) []types.BridgeInstance {
+	return k.GetParams(ctx).Instances
+}
+
+func (k Keeper) setInstance(ctx sdk.Context, instance types.BridgeInstance) {
 	params := k.GetParams(ctx)
+	params.SetInstance(instance)
+	k.SetParams(ctx, params)
+}
+
+// AddNewCosigner adds a cosigner to a vault of an instance and logs the change with its reason and the mainchain transaction, if any
+func (k Keeper) AddNewCosigner(ctx sdk.Context, name, vault string, address sdk.ValAddress, mainchainPublicKey types.MainchainPublicKey, reason string, mainchainTxHash types.MainchainTxHash) error {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, name)
+	}
+
+	if _, found := instance.GetCosigner(mainchainPublicKey); found {
+		return nil
+	}
+	if _, found := instance.GetVault(vault); !found {
+		return sdkerrors.Wrapf(types.ErrInvalidVault, "instance %s has no vault %s", name, vault)
+	}
+	if len(instance.VaultCosigners(vault)) >= types.MaxCosigners {
+		return sdkerrors.Wrapf(types.ErrInvalidVault, "vault %s of instance %s has %d cosigners already", vault, name, types.MaxCosigners)
+	}
+	if other, found := instance.GetCosignerOf(address.String()); found {
+		return sdkerrors.Wrapf(types.ErrCosignerAlreadyExists, "%s cosigns for vault %s already", address, other.Vault)
+	}
+	k.SetCosigner(ctx, name, types.Cosigner{ValidatorAddress: address.String(), MainchainPublicKey: mainchainPublicKey, Vault: vault})
+	k.AppendCosignerSetChange(ctx, name, reason, mainchainTxHash)
+	return nil
+}
+
+// SetMainchainMultisigAddress replaces the mainchain multisig address and the tier of a vault of an instance,
+// or adds the vault if the instance has none of that name
+func (k Keeper) SetMainchainMultisigAddress(ctx sdk.Context, name, vault string, address types.MainchainAddress, cold bool) error {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, name)
+	}
+	if other, found := instance.GetVaultByAddress(address); found && other.Name != vault {
+		return sdkerrors.Wrapf(types.ErrInvalidVault, "%s is the multisig of vault %s already", address, other.Name)
+	}
 
-	for _, cosigner := range params.Cosigners {
+	for i := range instance.Vaults {
+		if instance.Vaults[i].Name == vault {
+			instance.Vaults[i].MainchainMultisigAddress = address
+			instance.Vaults[i].Cold = cold
+			k.setInstance(ctx, instance)
+			return nil
+		}
+	}
+	instance.Vaults = append(instance.Vaults, types.NewVault(vault, address, cold))
+	k.setInstance(ctx, instance)
+	return nil
+}
+
+// GetCosigner returns the cosigner of an instance with the given mainchain public key
+func (k Keeper) GetCosigner(ctx sdk.Context, name string, mainchainPublicKey types.MainchainPublicKey) (types.Cosigner, bool) {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return types.Cosigner{}, false
+	}
+	return instance.GetCosigner(mainchainPublicKey)
+}
+
+// RemoveCosigner removes the cosigner of an instance with the given mainchain public key and logs the change
+// with its reason and the mainchain transaction, if any
+func (k Keeper) RemoveCosigner(ctx sdk.Context, name string, mainchainPublicKey types.MainchainPublicKey, reason string, mainchainTxHash types.MainchainTxHash) error {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, name)
+	}
+
+	for _, cosigner := range instance.Cosigners {
 		if cosigner.MainchainPublicKey == mainchainPublicKey {
-			return
+			ctx.KVStore(k.storeKey).Delete(types.GetCosignerKey(name, mainchainPublicKey))
+			k.AppendCosignerSetChange(ctx, name, reason, mainchainTxHash)
+			// the validator may still cosign for other instances
+			if validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress); err == nil && !k.isCosignerOfAnyInstance(ctx, cosigner.ValidatorAddress) {
+				ctx.KVStore(k.storeKey).Delete(types.GetInactiveCosignerKey(validator))
+			}
+			return nil
 		}
 	}
-	params.Cosigners = append(params.Cosigners, types.Cosigner{ValidatorAddress: address.String(), MainchainPublicKey: mainchainPublicKey})
-	k.SetParams(ctx, params)
+
+	return sdkerrors.Wrap(types.ErrCosignerNotFound, mainchainPublicKey.String())
+}
+
+func (k Keeper) isCosignerOfAnyInstance(ctx sdk.Context, validator string) bool {
+	for _, instance := range k.GetInstances(ctx) {
+		if instance.HasCosigner(validator) {
+			return true
+		}
+	}
+	return false
 }
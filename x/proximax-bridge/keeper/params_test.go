@@ -0,0 +1,71 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func TestColdApprovalNeverLessStrict(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	input.SetVaults(input.Validators...)
+
+	// a parameter change proposal validates the cold approval on its own
+	weaker := types.MultisigApproval{MinApproval: sdk.MustNewDecFromStr("0.1"), MinRemoval: sdk.MustNewDecFromStr("0.9")}
+	err := input.Keeper.Paramspace().(params.Subspace).Update(input.Ctx, types.KeyColdMultisigApproval, []byte(`{"min_approval":"0.1","min_removal":"0.9"}`))
+	require.NoError(t, err)
+	p := input.Keeper.GetParams(input.Ctx)
+	require.Equal(t, weaker, p.ColdMultisigApproval)
+
+	// the cold vaults still need at least the hot approval
+	instance, _ := p.GetInstance(testutil.TestInstance)
+	cold, _ := instance.GetVault("cold")
+	approval := p.ApprovalFor(cold)
+	require.Equal(t, p.MultisigApproval.MinApproval, approval.MinApproval)
+	require.Equal(t, weaker.MinRemoval, approval.MinRemoval)
+}
+
+func TestCosignersNotChangedThroughParams(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100, 100)
+	input.SetVaults(input.Validators[0])
+	subspace := input.Keeper.Paramspace().(params.Subspace)
+	cosigners := func() []types.Cosigner {
+		instance, _ := input.Keeper.GetInstance(input.Ctx, testutil.TestInstance)
+		return instance.Cosigners
+	}
+	require.Len(t, cosigners(), 1)
+
+	// a parameter change proposal cannot slip a cosigner in
+	p := input.Keeper.GetParams(input.Ctx)
+	instance, _ := p.GetInstance(testutil.TestInstance)
+	instance.Cosigners = append(instance.Cosigners, types.Cosigner{
+		ValidatorAddress:   input.Validators[1].String(),
+		MainchainPublicKey: testutil.MainchainPublicKeyFromSeed(2),
+		Vault:              "hot",
+	})
+	p.SetInstance(instance)
+	bz, err := json.Marshal(p.Instances)
+	require.NoError(t, err)
+	require.Error(t, subspace.Update(input.Ctx, types.KeyInstances, bz))
+	require.Len(t, cosigners(), 1)
+
+	// nor drop one by leaving the cosigners out
+	instance.Cosigners = []types.Cosigner{}
+	p.SetInstance(instance)
+	bz, err = json.Marshal(p.Instances)
+	require.NoError(t, err)
+	require.NoError(t, subspace.Update(input.Ctx, types.KeyInstances, bz))
+	require.Len(t, cosigners(), 1)
+
+	// the keeper keeps them out of the params too
+	input.Keeper.SetParams(input.Ctx, p)
+	require.Len(t, cosigners(), 1)
+	require.Len(t, input.Keeper.GetCosignerSetChanges(input.Ctx, testutil.TestInstance), 0)
+}
@@ -0,0 +1,332 @@
+package keeper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/peggy/x/oracle"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// trackProphecy keeps record of a prophecy while it is open, starting from the block it was created in.
+// Prophecies which reached consensus are no longer tracked, so they are never expired.
+func (k Keeper) trackProphecy(ctx sdk.Context, id, claimType, instance string, mainchainTxHash types.MainchainTxHash, status oracle.Status) error {
+	record, err := k.GetProphecyRecord(ctx, id)
+	if status.Text == oracle.SuccessStatusText {
+		if err == nil {
+			k.deleteProphecyRecord(ctx, record)
+		}
+		return nil
+	}
+	if err == nil {
+		return nil
+	}
+
+	record = types.ProphecyRecord{ID: id, ClaimType: claimType, Instance: instance, MainchainTxHash: mainchainTxHash, CreatedHeight: ctx.BlockHeight()}
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKeyForProphecy)
+	store.Set(types.GetProphecyRecordKey(id), recordBytes)
+	store.Set(types.GetProphecyQueueKey(record.CreatedHeight, id), []byte(id))
+	return nil
+}
+
+func (k Keeper) GetProphecyRecord(ctx sdk.Context, id string) (types.ProphecyRecord, error) {
+	record := types.ProphecyRecord{}
+	store := ctx.KVStore(k.storeKeyForProphecy)
+	if !store.Has(types.GetProphecyRecordKey(id)) {
+		return record, errors.New(fmt.Sprintf("Prophecy Record is Not Found: %s", id))
+	}
+	err := json.Unmarshal(store.Get(types.GetProphecyRecordKey(id)), &record)
+	return record, err
+}
+
+// GetOpenProphecies returns the prophecies which have not reached consensus yet,
+// with the state the oracle keeps of them and the claims made on them
+func (k Keeper) GetOpenProphecies(ctx sdk.Context) []types.OpenProphecy {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKeyForProphecy), types.ProphecyRecordPrefix)
+	defer iterator.Close()
+
+	prophecies := []types.OpenProphecy{}
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.ProphecyRecord
+		if err := json.Unmarshal(iterator.Value(), &record); err != nil {
+			panic(err)
+		}
+		// the oracle module keeps no API to export a prophecy, it is read under the key the oracle module stores it with
+		var prophecy oracle.DBProphecy
+		if bz := ctx.KVStore(k.oracleStoreKey).Get([]byte(record.ID)); bz != nil {
+			k.cdc.MustUnmarshalBinaryBare(bz, &prophecy)
+		}
+		prophecies = append(prophecies, types.OpenProphecy{Record: record, Prophecy: prophecy, Claims: k.GetValidatorClaims(ctx, record.ID)})
+	}
+	return prophecies
+}
+
+// SetOpenProphecy stores a prophecy which has not reached consensus yet, together with its oracle state and claims
+func (k Keeper) SetOpenProphecy(ctx sdk.Context, prophecy types.OpenProphecy) {
+	recordBytes, err := json.Marshal(prophecy.Record)
+	if err != nil {
+		panic(err)
+	}
+	store := ctx.KVStore(k.storeKeyForProphecy)
+	store.Set(types.GetProphecyRecordKey(prophecy.Record.ID), recordBytes)
+	store.Set(types.GetProphecyQueueKey(prophecy.Record.CreatedHeight, prophecy.Record.ID), []byte(prophecy.Record.ID))
+	for _, claim := range prophecy.Claims {
+		claimBytes, err := json.Marshal(claim)
+		if err != nil {
+			panic(err)
+		}
+		store.Set(types.GetValidatorClaimKey(claim.ProphecyID, claim.ValidatorAddress), claimBytes)
+	}
+	ctx.KVStore(k.oracleStoreKey).Set([]byte(prophecy.Record.ID), k.cdc.MustMarshalBinaryBare(prophecy.Prophecy))
+}
+
+func (k Keeper) deleteProphecyRecord(ctx sdk.Context, record types.ProphecyRecord) {
+	store := ctx.KVStore(k.storeKeyForProphecy)
+	store.Delete(types.GetProphecyRecordKey(record.ID))
+	store.Delete(types.GetProphecyQueueKey(record.CreatedHeight, record.ID))
+}
+
+// ExpireProphecies removes the prophecies which have not reached consensus within ProphecyExpiry blocks
+// from the oracle, so that the claim can be submitted again from scratch.
+func (k Keeper) ExpireProphecies(ctx sdk.Context) {
+	expiredHeight := ctx.BlockHeight() - k.GetParams(ctx).ProphecyExpiry
+	if expiredHeight < 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKeyForProphecy)
+	iterator := store.Iterator(types.ProphecyQueuePrefix, types.GetProphecyQueueHeightPrefix(expiredHeight+1))
+	ids := []string{}
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, id := range ids {
+		record, err := k.GetProphecyRecord(ctx, id)
+		if err != nil {
+			k.Logger(ctx).Error("failed to get prophecy record", "id", id, "err", err)
+			continue
+		}
+
+		status := oracle.PendingStatusText
+		if prophecy, found := k.oracleKeeper.GetProphecy(ctx, id); found {
+			status = prophecy.Status.Text
+		}
+		k.oracleKeeper.DeleteProphecy(ctx, id)
+		k.deleteProphecyRecord(ctx, record)
+		k.deleteValidatorClaims(ctx, record.ID)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeProphecyExpiry,
+				sdk.NewAttribute(types.AttributeKeyInstance, record.Instance),
+				sdk.NewAttribute(types.AttributeKeyProphecyID, record.ID),
+				sdk.NewAttribute(types.AttributeKeyClaimType, record.ClaimType),
+				sdk.NewAttribute(types.AttributeKeyStatus, status.String()),
+			),
+		)
+	}
+}
+
+// setValidatorClaim records the claim a validator made on a prophecy
+func (k Keeper) setValidatorClaim(ctx sdk.Context, claim oracle.Claim) error {
+	validatorClaim := types.ValidatorClaim{ProphecyID: claim.ID, ValidatorAddress: claim.ValidatorAddress, Claim: claim.Content}
+	claimBytes, err := json.Marshal(validatorClaim)
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKeyForProphecy).Set(types.GetValidatorClaimKey(claim.ID, claim.ValidatorAddress), claimBytes)
+	return nil
+}
+
+// GetValidatorClaims returns the claims validators made on a prophecy which has not been finalized yet
+func (k Keeper) GetValidatorClaims(ctx sdk.Context, prophecyID string) []types.ValidatorClaim {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKeyForProphecy), types.GetValidatorClaimsPrefix(prophecyID))
+	defer iterator.Close()
+
+	claims := []types.ValidatorClaim{}
+	for ; iterator.Valid(); iterator.Next() {
+		var claim types.ValidatorClaim
+		if err := json.Unmarshal(iterator.Value(), &claim); err != nil {
+			k.Logger(ctx).Error("failed to parse validator claim", "key", iterator.Key(), "err", err)
+			continue
+		}
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// deleteValidatorClaims forgets the claims made on a prophecy
+func (k Keeper) deleteValidatorClaims(ctx sdk.Context, prophecyID string) {
+	store := ctx.KVStore(k.storeKeyForProphecy)
+	for _, claim := range k.GetValidatorClaims(ctx, prophecyID) {
+		store.Delete(types.GetValidatorClaimKey(prophecyID, claim.ValidatorAddress))
+	}
+}
+
+// processFaultyClaims compares the claims made on a prophecy of a mainchain transaction with the claim consensus was reached on.
+// Validators who claimed something else are slashed and their claims are recorded as faulty,
+// the others earn their share of the fee pool.
+// Claims on other prophecies of the same transaction are left to be finalized or expired on their own.
+func (k Keeper) processFaultyClaims(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash, prophecyID, finalClaim string) error {
+	consensus, err := types.CreateMsgPegClaimFromOracleString(finalClaim)
+	if err != nil {
+		return err
+	}
+	params := k.GetParams(ctx)
+	slashFraction := params.FaultyClaimSlashFraction
+
+	for _, validatorClaim := range k.GetValidatorClaims(ctx, prophecyID) {
+		if validatorClaim.Claim == finalClaim {
+			k.addRewardPoints(ctx, validatorClaim.ValidatorAddress, params.FeeDistribution.Claimant)
+			continue
+		}
+
+		claim, err := types.CreateMsgPegClaimFromOracleString(validatorClaim.Claim)
+		if err != nil {
+			return err
+		}
+		faultyClaim := types.FaultyClaim{
+			Instance:         instance,
+			MainchainTxHash:  mainchainTxHash,
+			ValidatorAddress: validatorClaim.ValidatorAddress,
+			Claim:            claim,
+			Consensus:        consensus,
+			SlashFraction:    slashFraction,
+			Height:           ctx.BlockHeight(),
+		}
+		if err := k.SetFaultyClaim(ctx, faultyClaim); err != nil {
+			return err
+		}
+		k.slashValidator(ctx, validatorClaim.ValidatorAddress, slashFraction)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeFaultyClaim,
+				sdk.NewAttribute(types.AttributeKeyInstance, instance),
+				sdk.NewAttribute(types.AttributeKeyMainchainTxHash, mainchainTxHash.String()),
+				sdk.NewAttribute(types.AttributeKeyValidator, validatorClaim.ValidatorAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyProphecyID, validatorClaim.ProphecyID),
+			),
+		)
+	}
+
+	k.deleteValidatorClaims(ctx, prophecyID)
+	return nil
+}
+
+// slashValidator slashes the given fraction of a validator's stake, unless it has already unbonded
+func (k Keeper) slashValidator(ctx sdk.Context, address sdk.ValAddress, fraction sdk.Dec) {
+	if !fraction.IsPositive() {
+		return
+	}
+	validator, found := k.stakingKeeper.GetValidator(ctx, address)
+	if !found || validator.IsUnbonded() {
+		return
+	}
+	k.slashingKeeper.Slash(ctx, validator.GetConsAddr(), fraction, validator.GetConsensusPower(), ctx.BlockHeight())
+}
+
+// SetFaultyClaim records a claim found to differ from the one consensus was reached on
+func (k Keeper) SetFaultyClaim(ctx sdk.Context, faultyClaim types.FaultyClaim) error {
+	claimBytes, err := json.Marshal(faultyClaim)
+	if err != nil {
+		return err
+	}
+	key := types.GetFaultyClaimKey(faultyClaim.ValidatorAddress, faultyClaim.Height, faultyClaim.Instance, faultyClaim.MainchainTxHash)
+	ctx.KVStore(k.storeKeyForProphecy).Set(key, claimBytes)
+	return nil
+}
+
+// GetFaultyClaims returns the faulty claims of a validator, or of all validators if the address is empty
+func (k Keeper) GetFaultyClaims(ctx sdk.Context, validator sdk.ValAddress) []types.FaultyClaim {
+	prefix := types.FaultyClaimPrefix
+	if !validator.Empty() {
+		prefix = types.GetFaultyClaimsPrefix(validator)
+	}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKeyForProphecy), prefix)
+	defer iterator.Close()
+
+	faultyClaims := []types.FaultyClaim{}
+	for ; iterator.Valid(); iterator.Next() {
+		var faultyClaim types.FaultyClaim
+		if err := json.Unmarshal(iterator.Value(), &faultyClaim); err != nil {
+			k.Logger(ctx).Error("failed to parse faulty claim", "key", iterator.Key(), "err", err)
+			continue
+		}
+		faultyClaims = append(faultyClaims, faultyClaim)
+	}
+	return faultyClaims
+}
+
+// GetProphecyStatus describes a bridge prophecy, with the power behind each claim
+// counted the same way the oracle counts it for the current ClaimWeighting.
+func (k Keeper) GetProphecyStatus(ctx sdk.Context, id string) (types.QueryResProphecy, bool) {
+	prophecy, found := k.oracleKeeper.GetProphecy(ctx, id)
+	if !found {
+		return types.QueryResProphecy{}, false
+	}
+
+	stakingKeeper := k.claimStakingKeeper(types.GetProphecyInstance(id))
+	powers := make(map[string]int64)
+	for _, validator := range stakingKeeper.GetBondedValidatorsByPower(ctx) {
+		powers[validator.OperatorAddress.String()] = validator.GetConsensusPower()
+	}
+
+	validators := make([]string, 0, len(prophecy.ValidatorClaims))
+	for validator := range prophecy.ValidatorClaims {
+		validators = append(validators, validator)
+	}
+	sort.Strings(validators)
+
+	claims := []types.ProphecyClaim{}
+	claimPowers := make(map[string]int64)
+	var highestClaimPower int64
+	for _, validator := range validators {
+		claim := types.ProphecyClaim{ValidatorAddress: validator, Claim: prophecy.ValidatorClaims[validator], Power: powers[validator]}
+		claims = append(claims, claim)
+		claimPowers[claim.Claim] += claim.Power
+		if claimPowers[claim.Claim] > highestClaimPower {
+			highestClaimPower = claimPowers[claim.Claim]
+		}
+	}
+
+	res := types.QueryResProphecy{
+		ID:                id,
+		Status:            prophecy.Status,
+		Claims:            claims,
+		HighestClaimPower: highestClaimPower,
+		TotalPower:        stakingKeeper.GetLastTotalPower(ctx).Int64(),
+		ConsensusReached:  sdk.ZeroDec(),
+		ConsensusNeeded:   sdk.ZeroDec(),
+	}
+	if res.TotalPower > 0 {
+		res.ConsensusReached = sdk.NewDec(highestClaimPower).QuoInt64(res.TotalPower)
+	}
+
+	if record, err := k.GetProphecyRecord(ctx, id); err == nil {
+		res.ClaimType = record.ClaimType
+		res.CreatedHeight = record.CreatedHeight
+		consensusNeeded := k.GetParams(ctx).ConsensusNeeded
+		switch record.ClaimType {
+		case types.ClaimTypePeg:
+			res.ConsensusNeeded = consensusNeeded.PegClaim
+		case types.ClaimTypeNotCosigned:
+			res.ConsensusNeeded = consensusNeeded.NotCosignedClaim
+		case types.ClaimTypeLockFunds:
+			res.ConsensusNeeded = consensusNeeded.LockFundsClaim
+		case types.ClaimTypeConfirmedInvitation, types.ClaimTypeConfirmedRemoval, types.ClaimTypeConfirmedTransfer, types.ClaimTypeConfirmedUnpegBatch:
+			res.ConsensusNeeded = consensusNeeded.ConfirmedClaim
+		}
+	}
+
+	return res, true
+}
@@ -0,0 +1,146 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/peggy/x/oracle"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func TestExpireProphecies(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.7", types.ClaimWeightingValidators)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.ProphecyExpiry = 10
+	input.Keeper.SetParams(input.Ctx, params)
+
+	claim := testPegClaim(input.Validators[0])
+	id := types.GetPegClaimProphecyID(claim)
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, claim)
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+	require.Len(t, input.Keeper.GetValidatorClaims(input.Ctx, id), 1)
+
+	// the prophecy is kept until ProphecyExpiry blocks have passed
+	ctx := input.Ctx.WithBlockHeight(10)
+	input.Keeper.ExpireProphecies(ctx)
+	_, found := input.Keeper.OracleKeeper().GetProphecy(ctx, id)
+	require.True(t, found)
+
+	ctx = input.Ctx.WithBlockHeight(11)
+	input.Keeper.ExpireProphecies(ctx)
+	_, found = input.Keeper.OracleKeeper().GetProphecy(ctx, id)
+	require.False(t, found)
+	_, err = input.Keeper.GetProphecyRecord(ctx, id)
+	require.Error(t, err)
+	require.Empty(t, input.Keeper.GetValidatorClaims(ctx, id))
+
+	// the same validator can claim again from scratch
+	status, err = input.Keeper.ProcessPegClaim(ctx, claim)
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+	record, err := input.Keeper.GetProphecyRecord(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), record.CreatedHeight)
+}
+
+func TestExpirePropheciesKeepsFinalized(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.5", types.ClaimWeightingValidators)
+
+	claim := testPegClaim(input.Validators[0])
+	_, err := input.Keeper.ProcessPegClaim(input.Ctx, claim)
+	require.NoError(t, err)
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+
+	ctx := input.Ctx.WithBlockHeight(1 + input.Keeper.GetParams(input.Ctx).ProphecyExpiry)
+	input.Keeper.ExpireProphecies(ctx)
+	prophecy, found := input.Keeper.OracleKeeper().GetProphecy(ctx, types.GetPegClaimProphecyID(claim))
+	require.True(t, found, "a finalized prophecy is never expired, so the peg cannot be claimed again")
+	require.Equal(t, oracle.SuccessStatusText, prophecy.Status.Text)
+}
+
+func TestFaultyPegClaimSlashed(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.6", types.ClaimWeightingValidators)
+
+	faulty := testPegClaim(input.Validators[2])
+	faulty.Address = testutil.AccAddressFromSeed(2)
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, faulty)
+	require.NoError(t, err)
+	require.Equal(t, oracle.PendingStatusText, status.Text)
+
+	_, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[0]))
+	require.NoError(t, err)
+	require.Empty(t, *input.Slashes, "nobody is slashed before consensus")
+
+	status, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+
+	validator, _ := input.StakingKeeper.GetValidator(input.Ctx, input.Validators[2])
+	require.Len(t, *input.Slashes, 1)
+	require.Equal(t, validator.GetConsAddr(), (*input.Slashes)[0].ConsAddress)
+	require.Equal(t, types.DefaultFaultyClaimSlashFraction(), (*input.Slashes)[0].Fraction)
+	require.Equal(t, int64(40), (*input.Slashes)[0].Power)
+
+	faultyClaims := input.Keeper.GetFaultyClaims(input.Ctx, nil)
+	require.Len(t, faultyClaims, 1)
+	require.Equal(t, input.Validators[2], faultyClaims[0].ValidatorAddress)
+	require.Equal(t, faulty.Address, faultyClaims[0].Claim.Address)
+	require.Equal(t, testPegClaim(nil).Address, faultyClaims[0].Consensus.Address)
+	require.Empty(t, input.Keeper.GetValidatorClaims(input.Ctx, types.GetPegClaimProphecyID(faulty)))
+}
+
+func TestFaultyPegClaimsComparedWithinProphecy(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.6", types.ClaimWeightingValidators)
+
+	// a claim of another amount on the same transaction is a prophecy of its own
+	other := testPegClaim(input.Validators[2])
+	other.Amount = sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, 200))
+	_, err := input.Keeper.ProcessPegClaim(input.Ctx, other)
+	require.NoError(t, err)
+	_, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[0]))
+	require.NoError(t, err)
+	status, err := input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+	require.Equal(t, oracle.SuccessStatusText, status.Text)
+
+	require.Empty(t, *input.Slashes)
+	require.Empty(t, input.Keeper.GetFaultyClaims(input.Ctx, nil))
+	require.Empty(t, input.Keeper.GetValidatorClaims(input.Ctx, types.GetPegClaimProphecyID(testPegClaim(nil))))
+	require.Len(t, input.Keeper.GetValidatorClaims(input.Ctx, types.GetPegClaimProphecyID(other)), 1, "the other prophecy keeps its claims")
+}
+
+func TestFaultyPegClaimNotSlashedWithZeroFraction(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 40)
+	input.SetVaults(input.Validators...)
+	setConsensusNeeded(input, "0.6", types.ClaimWeightingValidators)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.FaultyClaimSlashFraction = sdk.ZeroDec()
+	input.Keeper.SetParams(input.Ctx, params)
+
+	faulty := testPegClaim(input.Validators[2])
+	faulty.Address = testutil.AccAddressFromSeed(2)
+	_, err := input.Keeper.ProcessPegClaim(input.Ctx, faulty)
+	require.NoError(t, err)
+	_, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[0]))
+	require.NoError(t, err)
+	_, err = input.Keeper.ProcessPegClaim(input.Ctx, testPegClaim(input.Validators[1]))
+	require.NoError(t, err)
+
+	require.Empty(t, *input.Slashes)
+	require.Len(t, input.Keeper.GetFaultyClaims(input.Ctx, input.Validators[2]), 1)
+}
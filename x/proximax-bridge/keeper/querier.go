@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/peggy/x/oracle"
 )
 
 // NewQuerier creates a new querier for proximax-bridge clients.
@@ -15,6 +16,36 @@ func NewQuerier(k Keeper) sdk.Querier {
 		switch path[0] {
 		case types.QueryParams:
 			return queryParams(ctx, k)
+		case types.QueryFaultyClaims:
+			return queryFaultyClaims(ctx, req, k)
+		case types.QueryProphecy:
+			return queryProphecy(ctx, req, k)
+		case types.QueryCosignerSetChanges:
+			return queryCosignerSetChanges(ctx, req, k)
+		case types.QueryCosignerSet:
+			return queryCosignerSet(ctx, req, k)
+		case types.QueryVaults:
+			return queryVaults(ctx, req, k)
+		case types.QueryDepositVault:
+			return queryDepositVault(ctx, req, k)
+		case types.QueryFeeEstimate:
+			return queryFeeEstimate(ctx, req, k)
+		case types.QueryFeePool:
+			return queryFeePool(ctx, k)
+		case types.QueryRewards:
+			return queryRewards(ctx, k)
+		case types.QueryLockFundsReimbursements:
+			return queryLockFundsReimbursements(ctx, k)
+		case types.QueryQueuedUnpeg:
+			return queryQueuedUnpeg(ctx, req, k)
+		case types.QueryQueuedUnpegs:
+			return queryQueuedUnpegs(ctx, req, k)
+		case types.QueryTimeLockedUnpegs:
+			return queryTimeLockedUnpegs(ctx, req, k)
+		case types.QueryUnpegBatch:
+			return queryUnpegBatch(ctx, req, k)
+		case types.QueryUnpegBatches:
+			return queryUnpegBatches(ctx, req, k)
 		// TODO: Put the modules query routes
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown proximax-bridge query endpoint")
@@ -35,3 +66,308 @@ func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
 
 	return res, nil
 }
+
+func queryFaultyClaims(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryFaultyClaimsParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	faultyClaims := k.GetFaultyClaims(ctx, params.ValidatorAddress)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, faultyClaims)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryProphecy(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryProphecyParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	prophecy, found := k.GetProphecyStatus(ctx, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrap(oracle.ErrProphecyNotFound, params.ID)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, prophecy)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryCosignerSetChanges(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCosignerSetChangesParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	changes := k.GetCosignerSetChanges(ctx, params.Instance)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, changes)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryCosignerSet(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCosignerSetParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	change, found := k.GetCosignerSetAtHeight(ctx, params.Instance, params.Height)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrCosignerSetNotFound, "of instance %s as of height %d", params.Instance, params.Height)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, change)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryVaults(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryVaultsParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	instance, found := k.GetInstance(ctx, params.Instance)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrInvalidInstance, params.Instance)
+	}
+
+	vaults := types.QueryResVaults{
+		Instance:         instance.Name,
+		Vaults:           []types.QueryResVault{},
+		PendingTransfers: k.GetVaultTransfers(ctx, instance.Name),
+		PendingSweeps:    k.GetSweepRequests(ctx, instance.Name),
+		Total:            sdk.Coins{},
+		Supply:           sdk.Coins{},
+	}
+	for _, vault := range instance.Vaults {
+		balance := k.GetVaultBalance(ctx, instance.Name, vault.Name)
+		vaults.Vaults = append(vaults.Vaults, types.QueryResVault{Vault: vault, Cosigners: instance.VaultCosigners(vault.Name), Balance: balance})
+		vaults.Total = vaults.Total.Add(balance...)
+	}
+	for _, transfer := range vaults.PendingTransfers {
+		vaults.Total = vaults.Total.Add(transfer.Amount...)
+	}
+	supply := k.supplyKeeper.GetSupply(ctx).GetTotal()
+	for _, mapping := range instance.Denoms {
+		if amount := supply.AmountOf(mapping.Denom); amount.IsPositive() {
+			vaults.Supply = vaults.Supply.Add(sdk.NewCoin(mapping.Denom, amount))
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, vaults)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryDepositVault(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDepositVaultParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	instance, found := k.GetInstance(ctx, params.Instance)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrInvalidInstance, params.Instance)
+	}
+	if _, found := instance.GetDenom(params.Denom); !found {
+		return nil, sdkerrors.Wrapf(types.ErrUnmappedDenom, "%s is not pegged by instance %s", params.Denom, params.Instance)
+	}
+	vault, found := k.RouteDeposit(ctx, params.Instance, params.Denom)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidVault, "instance %s has no vault", params.Instance)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, vault)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryFeeEstimate(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryFeeEstimateParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if err := types.ValidateFeeDirection(params.Direction); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	if !params.Amount.IsValid() || params.Amount.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, params.Amount.String())
+	}
+
+	estimate := types.QueryResFeeEstimate{Direction: params.Direction, Amount: params.Amount}
+	if params.Direction == types.FeeDirectionUnpeg {
+		// an unpeg not covering its fee is rejected
+		fee, net, err := k.UnpegFee(ctx, params.Amount)
+		if err != nil {
+			return nil, err
+		}
+		estimate.Fee, estimate.Net = fee, net
+	} else {
+		estimate.Fee, estimate.Net = k.EstimateFee(ctx, params.Direction, params.Amount)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, estimate)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryFeePool(ctx sdk.Context, k Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, k.GetFeePool(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryRewards(ctx sdk.Context, k Keeper) ([]byte, error) {
+	period := k.GetParams(ctx).FeeDistribution.Period
+	rewards := types.QueryResRewards{
+		FeePool:          k.GetFeePool(ctx),
+		NextPayoutHeight: (ctx.BlockHeight()/period + 1) * period,
+		Points:           k.GetAllRewardPoints(ctx),
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, rewards)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryLockFundsReimbursements(ctx sdk.Context, k Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, k.GetLockFundsReimbursements(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryQueuedUnpeg(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryQueuedUnpegParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	for _, position := range k.GetQueuePositions(ctx) {
+		if position.QueuedUnpeg.ID != params.ID {
+			continue
+		}
+		res, err := codec.MarshalJSONIndent(types.ModuleCdc, position)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		}
+		return res, nil
+	}
+	return nil, sdkerrors.Wrapf(types.ErrQueuedUnpegNotFound, "%d", params.ID)
+}
+
+func queryQueuedUnpegs(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryQueuedUnpegsParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	positions := []types.QueuePosition{}
+	for _, position := range k.GetQueuePositions(ctx) {
+		if params.Sender.Empty() || position.QueuedUnpeg.Unpeg.Address.Equals(params.Sender) {
+			positions = append(positions, position)
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, positions)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryTimeLockedUnpegs(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryTimeLockedUnpegsParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	unpegs := []types.TimeLockedUnpeg{}
+	for _, locked := range k.GetTimeLockedUnpegs(ctx) {
+		if params.Sender.Empty() || locked.Unpeg.Address.Equals(params.Sender) {
+			unpegs = append(unpegs, locked)
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, unpegs)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryUnpegBatch(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUnpegBatchParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	batch, found := k.GetUnpegBatch(ctx, params.ID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrUnpegBatchNotFound, "%d", params.ID)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, batch)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryUnpegBatches(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUnpegBatchesParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	batches := []types.UnpegBatch{}
+	for _, batch := range k.GetUnpegBatches(ctx) {
+		if params.Status == "" || batch.Status == params.Status {
+			batches = append(batches, batch)
+		}
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, batches)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,335 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// SetPendingUnpeg stores an unpeg paid out on its own until its first cosigner announces it
+func (k Keeper) SetPendingUnpeg(ctx sdk.Context, unpeg types.PendingUnpeg) {
+	bz, err := json.Marshal(unpeg)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetPendingUnpegKey(unpeg.ID), bz)
+}
+
+// GetPendingUnpegs returns the unpegs paid out on their own which were not announced yet, in id order
+func (k Keeper) GetPendingUnpegs(ctx sdk.Context) []types.PendingUnpeg {
+	unpegs := []types.PendingUnpeg{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.PendingUnpegPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var unpeg types.PendingUnpeg
+		if err := json.Unmarshal(iterator.Value(), &unpeg); err != nil {
+			panic(err)
+		}
+		unpegs = append(unpegs, unpeg)
+	}
+	return unpegs
+}
+
+// ResolvePendingUnpeg returns the oldest pending unpeg a recorded announcement pays out, and forgets it
+func (k Keeper) ResolvePendingUnpeg(ctx sdk.Context, msg types.MsgRecordUnpeg) (types.PendingUnpeg, bool) {
+	for _, unpeg := range k.GetPendingUnpegs(ctx) {
+		if unpeg.IsAnnouncedBy(msg) {
+			ctx.KVStore(k.storeKey).Delete(types.GetPendingUnpegKey(unpeg.ID))
+			return unpeg, true
+		}
+	}
+	return types.PendingUnpeg{}, false
+}
+
+// GetQueuedUnpeg returns an unpeg of the withdrawal queue
+func (k Keeper) GetQueuedUnpeg(ctx sdk.Context, id uint64) (types.QueuedUnpeg, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetQueuedUnpegKey(id))
+	if bz == nil {
+		return types.QueuedUnpeg{}, false
+	}
+	var queued types.QueuedUnpeg
+	if err := json.Unmarshal(bz, &queued); err != nil {
+		panic(err)
+	}
+	return queued, true
+}
+
+// SetQueuedUnpeg stores an unpeg in the withdrawal queue, ids issued later are kept clear of its id
+func (k Keeper) SetQueuedUnpeg(ctx sdk.Context, queued types.QueuedUnpeg) {
+	bz, err := json.Marshal(queued)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetQueuedUnpegKey(queued.ID), bz)
+	k.reserveQueuedUnpegID(ctx, queued.ID)
+}
+
+// DeleteQueuedUnpeg removes an unpeg from the withdrawal queue
+func (k Keeper) DeleteQueuedUnpeg(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.GetQueuedUnpegKey(id))
+}
+
+// GetQueuedUnpegs returns the withdrawal queue, first in first
+func (k Keeper) GetQueuedUnpegs(ctx sdk.Context) []types.QueuedUnpeg {
+	unpegs := []types.QueuedUnpeg{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.QueuedUnpegPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var queued types.QueuedUnpeg
+		if err := json.Unmarshal(iterator.Value(), &queued); err != nil {
+			panic(err)
+		}
+		unpegs = append(unpegs, queued)
+	}
+	return unpegs
+}
+
+func (k Keeper) getNextQueuedUnpegID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.NextQueuedUnpegIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// reserveQueuedUnpegID keeps the ids issued later clear of an id, which queued and time-locked unpegs share
+func (k Keeper) reserveQueuedUnpegID(ctx sdk.Context, id uint64) {
+	if id >= k.getNextQueuedUnpegID(ctx) {
+		ctx.KVStore(k.storeKey).Set(types.NextQueuedUnpegIDKey, sdk.Uint64ToBigEndian(id+1))
+	}
+}
+
+// EnqueueUnpeg appends an unpeg whose coins the module holds to the withdrawal queue
+func (k Keeper) EnqueueUnpeg(ctx sdk.Context, msg types.MsgUnpeg) uint64 {
+	queued := types.QueuedUnpeg{
+		ID:     k.getNextQueuedUnpegID(ctx),
+		Height: ctx.BlockHeight(),
+		Unpeg:  msg,
+	}
+	k.SetQueuedUnpeg(ctx, queued)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpegQueued,
+			sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", queued.ID)),
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyMainchainAddress, msg.MainchainAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipients, types.RecipientsAttribute(msg.GetRecipients())),
+		),
+	)
+	return queued.ID
+}
+
+// CancelQueuedUnpeg takes an unpeg out of the withdrawal queue or the time lock at the request of its sender and refunds it
+func (k Keeper) CancelQueuedUnpeg(ctx sdk.Context, sender sdk.AccAddress, id uint64) error {
+	var unpeg types.MsgUnpeg
+	if queued, found := k.GetQueuedUnpeg(ctx, id); found {
+		unpeg = queued.Unpeg
+	} else if locked, found := k.GetTimeLockedUnpeg(ctx, id); found {
+		unpeg = locked.Unpeg
+	} else {
+		return sdkerrors.Wrapf(types.ErrQueuedUnpegNotFound, "%d", id)
+	}
+	if !unpeg.Address.Equals(sender) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "unpeg %d was not sent by %s", id, sender)
+	}
+	k.DeleteQueuedUnpeg(ctx, id)
+	k.DeleteTimeLockedUnpeg(ctx, id)
+	k.refundUnpeg(ctx, id, unpeg, "cancelled")
+	return nil
+}
+
+// GetQueuePositions returns the place of each unpeg in the withdrawal queue and when it is expected to be released.
+// The release is simulated from the current block time on: an unpeg waits for those ahead of it,
+// then for enough of the volume to leave the window for it to fit in the caps.
+func (k Keeper) GetQueuePositions(ctx sdk.Context) []types.QueuePosition {
+	params := k.GetParams(ctx)
+	window := []types.VolumeEntry{}
+	volume := make(map[string]sdk.Int)
+	for _, entry := range k.GetVolumeEntries(ctx) {
+		if entry.Direction != types.FeeDirectionUnpeg {
+			continue
+		}
+		window = append(window, entry)
+		volume[entry.Denom] = volumeOf(volume, entry.Denom).Add(entry.Amount)
+	}
+	fits := func(amount sdk.Coins) bool {
+		for _, coin := range amount {
+			if limit, found := params.GetLimit(coin.Denom); found && !limit.Unpeg.WithinVolumeCap(volumeOf(volume, coin.Denom), coin.Amount) {
+				return false
+			}
+		}
+		return true
+	}
+
+	positions := []types.QueuePosition{}
+	eta := ctx.BlockTime()
+	for i, queued := range k.GetQueuedUnpegs(ctx) {
+		position := types.QueuePosition{QueuedUnpeg: queued, Position: int64(i + 1), ETA: eta}
+		// unpegs out of the limits or from addresses no longer permitted are refunded when their turn comes
+		if params.CheckUnpeg(queued.Unpeg) != nil {
+			positions = append(positions, position)
+			continue
+		}
+		for {
+			for len(window) > 0 && !window[0].Time.After(position.ETA.Add(-params.VolumeWindow)) {
+				volume[window[0].Denom] = volumeOf(volume, window[0].Denom).Sub(window[0].Amount)
+				window = window[1:]
+			}
+			if fits(queued.Unpeg.Amount) || len(window) == 0 {
+				break
+			}
+			position.ETA = window[0].Time.Add(params.VolumeWindow)
+		}
+		for _, coin := range queued.Unpeg.Amount {
+			if _, found := params.GetLimit(coin.Denom); found {
+				window = append(window, types.VolumeEntry{Direction: types.FeeDirectionUnpeg, Denom: coin.Denom, Time: position.ETA, Amount: coin.Amount})
+				volume[coin.Denom] = volumeOf(volume, coin.Denom).Add(coin.Amount)
+			}
+		}
+		eta = position.ETA
+		positions = append(positions, position)
+	}
+	return positions
+}
+
+func volumeOf(volume map[string]sdk.Int, denom string) sdk.Int {
+	if amount, found := volume[denom]; found {
+		return amount
+	}
+	return sdk.ZeroInt()
+}
+
+// ReleaseQueuedUnpegs dispatches the withdrawal queue in order for as long as the volume caps allow.
+// An unpeg which cannot be dispatched any more, because the limits, the address filter, its instance or the vaults changed while it waited,
+// is refunded to its sender rather than blocking the queue.
+func (k Keeper) ReleaseQueuedUnpegs(ctx sdk.Context) {
+	for _, queued := range k.GetQueuedUnpegs(ctx) {
+		if err := k.GetParams(ctx).CheckUnpeg(queued.Unpeg); err != nil {
+			k.DeleteQueuedUnpeg(ctx, queued.ID)
+			k.refundUnpeg(ctx, queued.ID, queued.Unpeg, err.Error())
+			continue
+		}
+		if !k.WithinVolumeCap(ctx, types.FeeDirectionUnpeg, queued.Unpeg.Amount) {
+			return
+		}
+		k.DeleteQueuedUnpeg(ctx, queued.ID)
+
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.DispatchUnpeg(cacheCtx, queued.ID, queued.Unpeg); err != nil {
+			k.refundUnpeg(ctx, queued.ID, queued.Unpeg, err.Error())
+			continue
+		}
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
+
+// SetQueuedPeg stores a peg waiting to be minted, ids issued later are kept clear of its id
+func (k Keeper) SetQueuedPeg(ctx sdk.Context, queued types.QueuedPeg) {
+	bz, err := json.Marshal(queued)
+	if err != nil {
+		panic(err)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetQueuedPegKey(queued.ID), bz)
+	if queued.ID >= k.getNextQueuedPegID(ctx) {
+		store.Set(types.NextQueuedPegIDKey, sdk.Uint64ToBigEndian(queued.ID+1))
+	}
+}
+
+// GetQueuedPegs returns the pegs waiting to be minted, first in first
+func (k Keeper) GetQueuedPegs(ctx sdk.Context) []types.QueuedPeg {
+	pegs := []types.QueuedPeg{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.QueuedPegPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var queued types.QueuedPeg
+		if err := json.Unmarshal(iterator.Value(), &queued); err != nil {
+			panic(err)
+		}
+		pegs = append(pegs, queued)
+	}
+	return pegs
+}
+
+func (k Keeper) getNextQueuedPegID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.NextQueuedPegIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// enqueuePeg holds back a peg which reached consensus over the volume cap
+func (k Keeper) enqueuePeg(ctx sdk.Context, peg types.MsgPegClaim) {
+	queued := types.QueuedPeg{
+		ID:     k.getNextQueuedPegID(ctx),
+		Height: ctx.BlockHeight(),
+		Peg:    peg,
+	}
+	k.SetQueuedPeg(ctx, queued)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypePegQueued,
+			sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", queued.ID)),
+			sdk.NewAttribute(types.AttributeKeyInstance, peg.Instance),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, peg.MainchainTxHash.String()),
+			sdk.NewAttribute(types.AttributeKeyCosmosReceiver, peg.Address.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, peg.Amount.String()),
+		),
+	)
+}
+
+// ReleaseQueuedPegs mints the queued pegs in order for as long as the volume caps allow.
+// Their deposits are on the mainchain already, a peg which cannot be minted stays at the head of the queue.
+func (k Keeper) ReleaseQueuedPegs(ctx sdk.Context) {
+	for _, queued := range k.GetQueuedPegs(ctx) {
+		if !k.WithinVolumeCap(ctx, types.FeeDirectionPeg, queued.Peg.Amount) {
+			return
+		}
+
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.mintPeg(cacheCtx, queued.Peg); err != nil {
+			k.Logger(ctx).Error("cannot mint a queued peg", "id", queued.ID, "err", err)
+			return
+		}
+		ctx.KVStore(k.storeKey).Delete(types.GetQueuedPegKey(queued.ID))
+		write()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypePegReleased,
+				sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", queued.ID)),
+				sdk.NewAttribute(types.AttributeKeyInstance, queued.Peg.Instance),
+				sdk.NewAttribute(types.AttributeKeyMainchainTxHash, queued.Peg.MainchainTxHash.String()),
+				sdk.NewAttribute(types.AttributeKeyCosmosReceiver, queued.Peg.Address.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, queued.Peg.Amount.String()),
+			),
+		)
+	}
+}
+
+// refundUnpeg gives the coins of a queued or time-locked unpeg back to its sender
+func (k Keeper) refundUnpeg(ctx sdk.Context, id uint64, unpeg types.MsgUnpeg, reason string) {
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, unpeg.Address, unpeg.Amount); err != nil {
+		panic(err)
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpegRefund,
+			sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", id)),
+			sdk.NewAttribute(types.AttributeKeyInstance, unpeg.Instance),
+			sdk.NewAttribute(types.AttributeKeyCosmosSender, unpeg.Address.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, unpeg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyReason, reason),
+		),
+	)
+}
@@ -0,0 +1,145 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func finalPegClaim(t *testing.T, txSeed byte, amount int64) string {
+	claim := testPegClaim(nil)
+	claim.MainchainTxHash = testutil.MainchainTxHashFromSeed(txSeed)
+	claim.Amount = sdk.NewCoins(sdk.NewInt64Coin(types.DefaultDenom, amount))
+	bz, err := json.Marshal(claim)
+	require.NoError(t, err)
+	return string(bz)
+}
+
+func TestPegOverVolumeCapQueued(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	input.SetVaults(input.Validators...)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.Limits = []types.DenomLimit{{
+		Denom: types.DefaultDenom,
+		Peg:   types.NewTransferLimit(sdk.ZeroInt(), sdk.ZeroInt(), sdk.NewInt(150)),
+		Unpeg: types.NewTransferLimit(sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt()),
+	}}
+	params.VolumeWindow = time.Hour
+	input.Keeper.SetParams(input.Ctx, params)
+	recipient := testutil.AccAddressFromSeed(1)
+	balance := func(ctx sdk.Context) sdk.Int {
+		return input.BankKeeper.GetCoins(ctx, recipient).AmountOf(types.DefaultDenom)
+	}
+
+	require.NoError(t, input.Keeper.ProcessSuccessfulPegClaim(input.Ctx, finalPegClaim(t, 1, 100)))
+	require.Equal(t, sdk.NewInt(100), balance(input.Ctx))
+
+	// the deposit over the cap is held by the vault, but minted later
+	require.NoError(t, input.Keeper.ProcessSuccessfulPegClaim(input.Ctx, finalPegClaim(t, 2, 100)))
+	require.Equal(t, sdk.NewInt(100), balance(input.Ctx))
+	require.Equal(t, sdk.NewInt(200), input.Keeper.GetVaultBalance(input.Ctx, testutil.TestInstance, "hot").AmountOf(types.DefaultDenom))
+	// and a peg within the cap waits behind it
+	require.NoError(t, input.Keeper.ProcessSuccessfulPegClaim(input.Ctx, finalPegClaim(t, 3, 10)))
+	require.Equal(t, sdk.NewInt(100), balance(input.Ctx))
+	require.Len(t, input.Keeper.GetQueuedPegs(input.Ctx), 2)
+
+	start := input.Ctx.BlockTime()
+	ctx := input.Ctx.WithBlockHeight(10).WithBlockTime(start.Add(time.Hour - time.Second))
+	input.Keeper.PruneVolume(ctx)
+	input.Keeper.ReleaseQueuedPegs(ctx)
+	require.Equal(t, sdk.NewInt(100), balance(ctx), "the first peg is still in the window")
+
+	ctx = input.Ctx.WithBlockHeight(11).WithBlockTime(start.Add(time.Hour))
+	input.Keeper.PruneVolume(ctx)
+	input.Keeper.ReleaseQueuedPegs(ctx)
+	require.Equal(t, sdk.NewInt(210), balance(ctx))
+	require.Empty(t, input.Keeper.GetQueuedPegs(ctx))
+	require.Equal(t, sdk.NewInt(110), input.Keeper.GetVolume(ctx, types.FeeDirectionPeg, types.DefaultDenom))
+}
+
+// escrowTestUnpeg has a sender escrow an unpeg of xpx to a single recipient and queues it
+func escrowTestUnpeg(t *testing.T, input testutil.TestInput, ctx sdk.Context, amount int64) uint64 {
+	sender := testutil.AccAddressFromSeed(1)
+	require.NoError(t, input.SupplyKeeper.MintCoins(ctx, types.ModuleName, xpx(amount)))
+	require.NoError(t, input.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, xpx(amount)))
+	msg := types.NewMsgUnpeg(sender, testutil.TestInstance, testutil.MainchainAddressFromSeed(3), xpx(amount), input.Validators[0])
+	require.NoError(t, input.Keeper.EscrowUnpeg(ctx, msg))
+	return input.Keeper.EnqueueUnpeg(ctx, msg)
+}
+
+func TestCancelQueuedUnpegRefundsGross(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	input.SetVaults(input.Validators...)
+	setUnpegFee(input)
+	id := escrowTestUnpeg(t, input, input.Ctx, 400)
+	require.True(t, input.BankKeeper.GetCoins(input.Ctx, testutil.AccAddressFromSeed(1)).Empty())
+
+	// only the sender can cancel its unpeg
+	err := input.Keeper.CancelQueuedUnpeg(input.Ctx, testutil.AccAddressFromSeed(2), id)
+	require.Error(t, err)
+
+	// nothing was charged yet, the whole amount goes back
+	require.NoError(t, input.Keeper.CancelQueuedUnpeg(input.Ctx, testutil.AccAddressFromSeed(1), id))
+	require.Equal(t, xpx(400), input.BankKeeper.GetCoins(input.Ctx, testutil.AccAddressFromSeed(1)))
+	require.True(t, input.Keeper.GetFeePool(input.Ctx).Empty())
+	require.Empty(t, input.Keeper.GetQueuedUnpegs(input.Ctx))
+
+	err = input.Keeper.CancelQueuedUnpeg(input.Ctx, testutil.AccAddressFromSeed(1), id)
+	require.True(t, types.ErrQueuedUnpegNotFound.Is(err))
+}
+
+func TestReleaseQueuedUnpegsWithinCap(t *testing.T) {
+	input := testutil.CreateTestInput(t, 100)
+	input.SetVaults(input.Validators...)
+	setUnpegFee(input)
+	params := input.Keeper.GetParams(input.Ctx)
+	params.Limits = []types.DenomLimit{{
+		Denom: types.DefaultDenom,
+		Peg:   types.NewTransferLimit(sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt()),
+		Unpeg: types.NewTransferLimit(sdk.ZeroInt(), sdk.ZeroInt(), sdk.NewInt(500)),
+	}}
+	params.VolumeWindow = time.Hour
+	input.Keeper.SetParams(input.Ctx, params)
+	input.Keeper.SetVaultBalance(input.Ctx, testutil.TestInstance, "hot", xpx(1000))
+
+	first := escrowTestUnpeg(t, input, input.Ctx, 400)
+	second := escrowTestUnpeg(t, input, input.Ctx, 400)
+	positions := input.Keeper.GetQueuePositions(input.Ctx)
+	require.Len(t, positions, 2)
+	require.Equal(t, first, positions[0].QueuedUnpeg.ID)
+	start := input.Ctx.BlockTime()
+	require.Equal(t, start, positions[0].ETA)
+	require.Equal(t, second, positions[1].QueuedUnpeg.ID)
+	require.Equal(t, start.Add(time.Hour), positions[1].ETA, "the second waits for the first to leave the window")
+
+	ctx := input.Ctx.WithBlockHeight(2)
+	input.Keeper.ReleaseQueuedUnpegs(ctx)
+	queued := input.Keeper.GetQueuedUnpegs(ctx)
+	require.Len(t, queued, 1)
+	require.Equal(t, second, queued[0].ID)
+
+	// the vault pays out the net, the fee goes to the pool and the gross counts in the volume
+	require.Equal(t, xpx(605), input.Keeper.GetVaultBalance(ctx, testutil.TestInstance, "hot"))
+	require.Equal(t, xpx(5), input.Keeper.GetFeePool(ctx))
+	require.False(t, input.Keeper.WithinVolumeCap(ctx, types.FeeDirectionUnpeg, xpx(101)))
+	require.True(t, input.Keeper.WithinVolumeCap(ctx, types.FeeDirectionUnpeg, xpx(100)))
+
+	ctx = input.Ctx.WithBlockHeight(3).WithBlockTime(start.Add(time.Hour - time.Second))
+	input.Keeper.PruneVolume(ctx)
+	input.Keeper.ReleaseQueuedUnpegs(ctx)
+	require.Len(t, input.Keeper.GetQueuedUnpegs(ctx), 1, "the volume is counted over block time, not blocks")
+
+	ctx = input.Ctx.WithBlockHeight(4).WithBlockTime(start.Add(time.Hour))
+	input.Keeper.PruneVolume(ctx)
+	input.Keeper.ReleaseQueuedUnpegs(ctx)
+	require.Empty(t, input.Keeper.GetQueuedUnpegs(ctx))
+	require.Equal(t, xpx(210), input.Keeper.GetVaultBalance(ctx, testutil.TestInstance, "hot"))
+	require.Equal(t, xpx(10), input.Keeper.GetFeePool(ctx))
+}
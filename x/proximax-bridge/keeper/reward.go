@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetRewardPoints returns the points a validator earned since the last payout of the fee pool
+func (k Keeper) GetRewardPoints(ctx sdk.Context, validator sdk.ValAddress) sdk.Dec {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetRewardPointsKey(validator))
+	if bz == nil {
+		return sdk.ZeroDec()
+	}
+	var entry types.RewardPoints
+	if err := json.Unmarshal(bz, &entry); err != nil {
+		panic(err)
+	}
+	return entry.Points
+}
+
+// SetRewardPoints stores the points of a validator, forgetting them if they are not positive
+func (k Keeper) SetRewardPoints(ctx sdk.Context, validator sdk.ValAddress, points sdk.Dec) {
+	store := ctx.KVStore(k.storeKey)
+	if !points.IsPositive() {
+		store.Delete(types.GetRewardPointsKey(validator))
+		return
+	}
+	bz, err := json.Marshal(types.RewardPoints{Validator: validator, Points: points})
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.GetRewardPointsKey(validator), bz)
+}
+
+// GetAllRewardPoints returns the points of every validator who earned some since the last payout of the fee pool
+func (k Keeper) GetAllRewardPoints(ctx sdk.Context) []types.RewardPoints {
+	points := []types.RewardPoints{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.RewardPointsPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.RewardPoints
+		if err := json.Unmarshal(iterator.Value(), &entry); err != nil {
+			panic(err)
+		}
+		points = append(points, entry)
+	}
+	return points
+}
+
+// addRewardPoints credits a validator with the weight of a piece of work
+func (k Keeper) addRewardPoints(ctx sdk.Context, validator sdk.ValAddress, weight sdk.Dec) {
+	if validator.Empty() || !weight.IsPositive() {
+		return
+	}
+	k.SetRewardPoints(ctx, validator, k.GetRewardPoints(ctx, validator).Add(weight))
+}
+
+// CreditInitiator credits the validator who announced the aggregate of an unpeg
+func (k Keeper) CreditInitiator(ctx sdk.Context, validator sdk.ValAddress) {
+	k.addRewardPoints(ctx, validator, k.GetParams(ctx).FeeDistribution.Initiator)
+}
+
+// creditCosigner credits the validator behind a cosignature on the mainchain,
+// cosignatures of keys which are not cosigners of the instance earn nothing
+func (k Keeper) creditCosigner(ctx sdk.Context, instance string, cosignerPublicKey types.MainchainPublicKey) {
+	params := k.GetParams(ctx)
+	bridgeInstance, found := params.GetInstance(instance)
+	if !found {
+		return
+	}
+	cosigner, found := bridgeInstance.GetCosigner(cosignerPublicKey)
+	if !found {
+		return
+	}
+	validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+	if err != nil {
+		return
+	}
+	k.addRewardPoints(ctx, validator, params.FeeDistribution.Cosigner)
+}
+
+// DistributeFees pays the fee pool out once every period, first the lock funds the bridge owes,
+// then the rest to the validators pro rata to the points they earned.
+// What the truncation leaves stays in the pool for the next payout, and so do the points while the pool is empty.
+func (k Keeper) DistributeFees(ctx sdk.Context) {
+	distribution := k.GetParams(ctx).FeeDistribution
+	if !distribution.IsPayoutHeight(ctx.BlockHeight()) {
+		return
+	}
+	k.reimburseLockFunds(ctx)
+	pool := k.GetFeePool(ctx)
+	if pool.Empty() {
+		return
+	}
+	entries := k.GetAllRewardPoints(ctx)
+	total := sdk.ZeroDec()
+	for _, entry := range entries {
+		total = total.Add(entry.Points)
+	}
+	if !total.IsPositive() {
+		return
+	}
+
+	decPool := sdk.NewDecCoinsFromCoins(pool...)
+	for _, entry := range entries {
+		k.SetRewardPoints(ctx, entry.Validator, sdk.ZeroDec())
+		share, _ := decPool.MulDecTruncate(entry.Points.Quo(total)).TruncateDecimal()
+		if share.Empty() {
+			continue
+		}
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.FeePoolName, sdk.AccAddress(entry.Validator), share); err != nil {
+			panic(err)
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeFeeDistribution,
+				sdk.NewAttribute(types.AttributeKeyValidator, entry.Validator.String()),
+				sdk.NewAttribute(types.AttributeKeyPoints, entry.Points.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, share.String()),
+			),
+		)
+	}
+}
@@ -0,0 +1,391 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetEligibleCosigners returns the bonded validators holding at least MinCosignerStakeShare
+// of the stake, ordered by power and capped at max
+func (k Keeper) GetEligibleCosigners(ctx sdk.Context, max int) []sdk.ValAddress {
+	eligible := []sdk.ValAddress{}
+
+	totalPower := k.stakingKeeper.GetLastTotalPower(ctx)
+	if !totalPower.IsPositive() {
+		return eligible
+	}
+
+	minShare := types.MinCosignerStakeShare()
+	for _, validator := range k.stakingKeeper.GetBondedValidatorsByPower(ctx) {
+		if len(eligible) >= max {
+			break
+		}
+		power := k.stakingKeeper.GetLastValidatorPower(ctx, validator.GetOperator())
+		if sdk.NewDec(power).QuoInt(totalPower).LT(minShare) {
+			// validators are ordered by power, nobody after this one is eligible either
+			break
+		}
+		eligible = append(eligible, validator.GetOperator())
+	}
+
+	return eligible
+}
+
+func (k Keeper) getLastEligibleCosigners(ctx sdk.Context, name string) []sdk.ValAddress {
+	eligible := []sdk.ValAddress{}
+	bz := ctx.KVStore(k.storeKey).Get(types.GetEligibleCosignersKey(name))
+	if bz == nil {
+		return eligible
+	}
+	if err := json.Unmarshal(bz, &eligible); err != nil {
+		panic(err)
+	}
+	return eligible
+}
+
+func (k Keeper) setLastEligibleCosigners(ctx sdk.Context, name string, eligible []sdk.ValAddress) {
+	bz, err := json.Marshal(eligible)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetEligibleCosignersKey(name), bz)
+}
+
+// GetAllEligibleCosigners returns the eligible cosigner set last computed for every instance
+func (k Keeper) GetAllEligibleCosigners(ctx sdk.Context) []types.EligibleCosigners {
+	sets := []types.EligibleCosigners{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.EligibleCosignersPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		set := types.EligibleCosigners{Instance: string(iterator.Key()[len(types.EligibleCosignersPrefix):])}
+		if err := json.Unmarshal(iterator.Value(), &set.Validators); err != nil {
+			panic(err)
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// SetEligibleCosigners stores the eligible cosigner set last computed for an instance
+func (k Keeper) SetEligibleCosigners(ctx sdk.Context, set types.EligibleCosigners) {
+	k.setLastEligibleCosigners(ctx, set.Instance, set.Validators)
+}
+
+// RotateCosigners compares the eligible cosigner set with the one of the previous block, for every instance.
+// An instance takes up to MaxCosigners per vault. When the set changed, it emits an invitation to the least
+// populated vault for each eligible validator which is not a cosigner of the instance yet and a removal
+// for each cosigner which is not eligible anymore, to be picked up by the relayers.
+func (k Keeper) RotateCosigners(ctx sdk.Context) {
+	for _, instance := range k.GetInstances(ctx) {
+		eligible := k.GetEligibleCosigners(ctx, types.MaxCosigners*len(instance.Vaults))
+		if sameValidators(eligible, k.getLastEligibleCosigners(ctx, instance.Name)) {
+			continue
+		}
+		k.setLastEligibleCosigners(ctx, instance.Name, eligible)
+		k.rotateInstanceCosigners(ctx, instance, eligible)
+	}
+}
+
+func (k Keeper) rotateInstanceCosigners(ctx sdk.Context, instance types.BridgeInstance, eligible []sdk.ValAddress) {
+	cosigners := make(map[string]types.Cosigner)
+	for _, cosigner := range instance.Cosigners {
+		cosigners[cosigner.ValidatorAddress] = cosigner
+	}
+	isEligible := make(map[string]bool)
+	for _, validator := range eligible {
+		isEligible[validator.String()] = true
+	}
+
+	// invitations of validators which are not eligible anymore lapse, the ones of governance stand,
+	// and so do the removals not announced yet of cosigners which are eligible again
+	for _, invitation := range k.GetCosignerInvitations(ctx, instance.Name) {
+		if invitation.Reason == types.CosignerSetChangeInvitation && !isEligible[invitation.ValidatorAddress.String()] {
+			k.deleteCosignerInvitation(ctx, instance.Name, invitation.ValidatorAddress)
+		}
+	}
+	for _, removal := range k.GetCosignerRemovals(ctx, instance.Name) {
+		if removal.Reason == types.CosignerSetChangeRemoval && removal.IsScheduled() && isEligible[removal.ValidatorAddress.String()] {
+			k.deleteCosignerRemoval(ctx, instance.Name, removal.MainchainPublicKey)
+		}
+	}
+
+	// invitations of this pass count towards the vaults they are sent to
+	planned := instance
+	planned.Cosigners = append([]types.Cosigner{}, instance.Cosigners...)
+	for _, validator := range eligible {
+		if _, found := cosigners[validator.String()]; found {
+			continue
+		}
+		// a pending invitation keeps its vault
+		invitation, invited := k.GetCosignerInvitation(ctx, instance.Name, validator)
+		vault, found := planned.VaultForNewCosigner()
+		if invited {
+			vault, found = instance.GetVault(invitation.Vault)
+		}
+		if !found {
+			k.Logger(ctx).Error("every vault is full, cannot invite an eligible validator", "instance", instance.Name, "validator", validator.String())
+			continue
+		}
+		planned.Cosigners = append(planned.Cosigners, types.Cosigner{ValidatorAddress: validator.String(), Vault: vault.Name})
+		if !invited {
+			k.SetCosignerInvitation(ctx, types.CosignerInvitation{Instance: instance.Name, Vault: vault.Name, ValidatorAddress: validator, Reason: types.CosignerSetChangeInvitation})
+		}
+
+		attributes := []sdk.Attribute{
+			sdk.NewAttribute(types.AttributeKeyInstance, instance.Name),
+			sdk.NewAttribute(types.AttributeKeyVault, vault.Name),
+			sdk.NewAttribute(types.AttributeKeyValidator, validator.String()),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+		}
+		if firstCosigner, found := firstCosignerFor(validator.String(), vault.Name, eligible, cosigners); found {
+			attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, firstCosigner))
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCosignerInvitation, attributes...))
+	}
+
+	for _, cosigner := range instance.Cosigners {
+		if isEligible[cosigner.ValidatorAddress] {
+			continue
+		}
+		validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+		if err != nil {
+			continue
+		}
+		// the removal of inactive cosigners has been scheduled by the staking hooks already
+		if !k.IsCosignerActive(ctx, validator) {
+			continue
+		}
+		if _, found := k.GetCosignerRemoval(ctx, instance.Name, cosigner.MainchainPublicKey); found {
+			continue
+		}
+		// the cosigner may not cooperate in its own removal, another active cosigner of the vault requests it
+		requester, found := k.GetActiveCosigner(ctx, instance.Name, cosigner.Vault, validator)
+		if !found {
+			k.Logger(ctx).Error("no active cosigner left to remove a cosigner", "instance", instance.Name, "vault", cosigner.Vault, "validator", cosigner.ValidatorAddress)
+			continue
+		}
+		vault, _ := instance.GetVault(cosigner.Vault)
+		k.ScheduleCosignerRemoval(ctx, types.CosignerRemoval{
+			Instance:                 instance.Name,
+			Vault:                    vault.Name,
+			ValidatorAddress:         validator,
+			MainchainPublicKey:       cosigner.MainchainPublicKey,
+			MainchainMultisigAddress: vault.MainchainMultisigAddress,
+			Reason:                   types.CosignerSetChangeRemoval,
+		}, requester)
+	}
+}
+
+// firstCosignerFor picks the eligible cosigner of the vault with the most stake, other than the subject itself,
+// to announce the multisig modification
+func firstCosignerFor(subject, vault string, eligible []sdk.ValAddress, cosigners map[string]types.Cosigner) (string, bool) {
+	for _, validator := range eligible {
+		address := validator.String()
+		if address == subject {
+			continue
+		}
+		if cosigner, found := cosigners[address]; found && cosigner.Vault == vault {
+			return address, true
+		}
+	}
+	return "", false
+}
+
+// sameValidators compares the validators regardless of their order, power shifts among
+// eligible validators do not change the cosigner set
+func sameValidators(a, b []sdk.ValAddress) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	members := make(map[string]bool)
+	for _, validator := range a {
+		members[validator.String()] = true
+	}
+	for _, validator := range b {
+		if !members[validator.String()] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsCosignerActive returns false when the validator of the cosigner left the bonded set
+func (k Keeper) IsCosignerActive(ctx sdk.Context, validator sdk.ValAddress) bool {
+	return !ctx.KVStore(k.storeKey).Has(types.GetInactiveCosignerKey(validator))
+}
+
+// GetInactiveCosigners returns the validators whose cosigner is inactive, with the height they left the bonded set at
+func (k Keeper) GetInactiveCosigners(ctx sdk.Context) []types.InactiveCosigner {
+	inactive := []types.InactiveCosigner{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.InactiveCosignerPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		inactive = append(inactive, types.InactiveCosigner{
+			ValidatorAddress: sdk.ValAddress(iterator.Key()[len(types.InactiveCosignerPrefix):]),
+			Height:           int64(binary.BigEndian.Uint64(iterator.Value())),
+		})
+	}
+	return inactive
+}
+
+// SetInactiveCosigner marks the cosigner of a validator inactive
+func (k Keeper) SetInactiveCosigner(ctx sdk.Context, inactive types.InactiveCosigner) {
+	ctx.KVStore(k.storeKey).Set(types.GetInactiveCosignerKey(inactive.ValidatorAddress), sdk.Uint64ToBigEndian(uint64(inactive.Height)))
+}
+
+// GetActiveCosigner returns the active cosigner of an instance with the most power, other than the excluded ones.
+// Unless the vault is empty, only the cosigners of that vault are considered.
+func (k Keeper) GetActiveCosigner(ctx sdk.Context, name, vault string, exclude ...sdk.ValAddress) (sdk.ValAddress, bool) {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return nil, false
+	}
+
+	for _, validator := range k.stakingKeeper.GetBondedValidatorsByPower(ctx) {
+		operator := validator.GetOperator()
+		if containsValidator(exclude, operator) {
+			continue
+		}
+		cosigner, found := instance.GetCosignerOf(operator.String())
+		if !found || (vault != "" && cosigner.Vault != vault) {
+			continue
+		}
+		if k.IsCosignerActive(ctx, operator) {
+			return operator, true
+		}
+	}
+	return nil, false
+}
+
+// deactivateCosigner marks the cosigner of the validator inactive and schedules its removal from the multisig
+// of every instance it cosigns for. The removal is requested by an active cosigner, since the validator may be offline.
+func (k Keeper) deactivateCosigner(ctx sdk.Context, validator sdk.ValAddress) {
+	if !k.isCosignerOfAnyInstance(ctx, validator.String()) || !k.IsCosignerActive(ctx, validator) {
+		return
+	}
+	k.SetInactiveCosigner(ctx, types.InactiveCosigner{ValidatorAddress: validator, Height: ctx.BlockHeight()})
+
+	for _, instance := range k.GetInstances(ctx) {
+		for _, cosigner := range instance.Cosigners {
+			if cosigner.ValidatorAddress == validator.String() {
+				k.scheduleInactiveCosignerRemoval(ctx, instance, cosigner)
+			}
+		}
+	}
+}
+
+func (k Keeper) scheduleInactiveCosignerRemoval(ctx sdk.Context, instance types.BridgeInstance, cosigner types.Cosigner) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeCosignerInactive,
+			sdk.NewAttribute(types.AttributeKeyInstance, instance.Name),
+			sdk.NewAttribute(types.AttributeKeyValidator, cosigner.ValidatorAddress),
+			sdk.NewAttribute(types.AttributeKeyCosignerPublicKey, cosigner.MainchainPublicKey.String()),
+		),
+	)
+
+	validator, _ := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+	requester, found := k.GetActiveCosigner(ctx, instance.Name, cosigner.Vault, validator)
+	if !found {
+		k.Logger(ctx).Error("no active cosigner left to remove an inactive cosigner", "instance", instance.Name, "vault", cosigner.Vault, "validator", cosigner.ValidatorAddress)
+		return
+	}
+	// a removal requested by governance keeps its reason
+	removal, found := k.GetCosignerRemoval(ctx, instance.Name, cosigner.MainchainPublicKey)
+	if !found {
+		removal.Reason = types.CosignerSetChangeRemoval
+	}
+	vault, _ := instance.GetVault(cosigner.Vault)
+	k.ScheduleCosignerRemoval(ctx, types.CosignerRemoval{
+		Instance:                 instance.Name,
+		Vault:                    vault.Name,
+		ValidatorAddress:         validator,
+		MainchainPublicKey:       cosigner.MainchainPublicKey,
+		MainchainMultisigAddress: vault.MainchainMultisigAddress,
+		Reason:                   removal.Reason,
+	}, requester)
+}
+
+// ScheduleCosignerRemoval records a removal the zone decided on and asks an active cosigner of the vault
+// to request it and announce the multisig modification within ProphecyExpiry blocks
+func (k Keeper) ScheduleCosignerRemoval(ctx sdk.Context, removal types.CosignerRemoval, requester sdk.ValAddress) {
+	removal.Requester = requester
+	removal.ExpiryHeight = ctx.BlockHeight() + k.GetParams(ctx).ProphecyExpiry
+	k.SetCosignerRemoval(ctx, removal)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeCosignerRemoval,
+			sdk.NewAttribute(types.AttributeKeyInstance, removal.Instance),
+			sdk.NewAttribute(types.AttributeKeyValidator, removal.ValidatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyVault, removal.Vault),
+			sdk.NewAttribute(types.AttributeKeyCosignerPublicKey, removal.MainchainPublicKey.String()),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, removal.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, requester.String()),
+			sdk.NewAttribute(types.AttributeKeyRequester, requester.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, fmt.Sprintf("%d", removal.ExpiryHeight)),
+		),
+	)
+}
+
+// ExpireCosignerRemovals hands the scheduled removals which were not announced by the expiry height
+// over to another active cosigner of the vault, or asks the same cosigner again when no other one is left.
+// The removals of cosigners which already left are dropped.
+func (k Keeper) ExpireCosignerRemovals(ctx sdk.Context) {
+	for _, instance := range k.GetInstances(ctx) {
+		for _, removal := range k.GetCosignerRemovals(ctx, instance.Name) {
+			if !removal.IsScheduled() || removal.ExpiryHeight > ctx.BlockHeight() {
+				continue
+			}
+			if !instance.HasCosigner(removal.ValidatorAddress.String()) {
+				k.deleteCosignerRemoval(ctx, instance.Name, removal.MainchainPublicKey)
+				continue
+			}
+			requester, found := k.GetActiveCosigner(ctx, instance.Name, removal.Vault, removal.ValidatorAddress, removal.Requester)
+			if !found {
+				if !k.IsActiveVaultCosigner(ctx, instance.Name, removal.Vault, removal.Requester) {
+					k.Logger(ctx).Error("no active cosigner left to remove a cosigner", "instance", instance.Name, "vault", removal.Vault, "validator", removal.ValidatorAddress.String())
+					continue
+				}
+				requester = removal.Requester
+			}
+			k.ScheduleCosignerRemoval(ctx, removal, requester)
+		}
+	}
+}
+
+// containsValidator returns true when the validator is one of the given ones
+func containsValidator(validators []sdk.ValAddress, validator sdk.ValAddress) bool {
+	for _, v := range validators {
+		if v.Equals(validator) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActiveCosigner returns true when the validator is a cosigner of the instance and its cosigner is active
+func (k Keeper) IsActiveCosigner(ctx sdk.Context, name string, validator sdk.ValAddress) bool {
+	instance, found := k.GetInstance(ctx, name)
+	if !found || !instance.HasCosigner(validator.String()) {
+		return false
+	}
+	return k.IsCosignerActive(ctx, validator)
+}
+
+// IsActiveVaultCosigner returns true when the validator is an active cosigner of the given vault of the instance
+func (k Keeper) IsActiveVaultCosigner(ctx sdk.Context, name, vault string, validator sdk.ValAddress) bool {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return false
+	}
+	cosigner, found := instance.GetCosignerOf(validator.String())
+	if !found || cosigner.Vault != vault {
+		return false
+	}
+	return k.IsCosignerActive(ctx, validator)
+}
@@ -0,0 +1,174 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// eventsOfType returns the attributes of the events of the given type, keyed by attribute
+func eventsOfType(ctx sdk.Context, eventType string) []map[string]string {
+	events := []map[string]string{}
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != eventType {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, attribute := range event.Attributes {
+			attributes[string(attribute.Key)] = string(attribute.Value)
+		}
+		events = append(events, attributes)
+	}
+	return events
+}
+
+func TestEligibleCosigners(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 50, 15, 5)
+	v := input.Validators
+
+	// ordered by power, the validator below a tenth of the stake is left out
+	require.Equal(t, []sdk.ValAddress{v[1], v[0], v[2]}, input.Keeper.GetEligibleCosigners(input.Ctx, types.MaxCosigners))
+	// and the set is capped by stake priority
+	require.Equal(t, []sdk.ValAddress{v[1], v[0]}, input.Keeper.GetEligibleCosigners(input.Ctx, 2))
+}
+
+func TestRotateCosigners(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1], v[3])
+
+	ctx := input.Ctx.WithEventManager(sdk.NewEventManager())
+	input.Keeper.RotateCosigners(ctx)
+
+	// the eligible validator joins the least populated vault, announced by the cosigner of that vault with the most stake
+	invitations := eventsOfType(ctx, types.EventTypeCosignerInvitation)
+	require.Len(t, invitations, 1)
+	require.Equal(t, v[2].String(), invitations[0][types.AttributeKeyValidator])
+	require.Equal(t, "cold", invitations[0][types.AttributeKeyVault])
+	require.NotContains(t, invitations[0], types.AttributeKeyFirstCosignerAddress, "the cold vault has no cosigner to announce it")
+	invitation, found := input.Keeper.GetCosignerInvitation(ctx, testutil.TestInstance, v[2])
+	require.True(t, found)
+	require.Equal(t, "cold", invitation.Vault)
+	require.Equal(t, types.CosignerSetChangeInvitation, invitation.Reason)
+
+	// the cosigner below a tenth of the stake leaves, announced by another cosigner of its vault
+	removals := eventsOfType(ctx, types.EventTypeCosignerRemoval)
+	require.Len(t, removals, 1)
+	require.Equal(t, v[3].String(), removals[0][types.AttributeKeyValidator])
+	require.Equal(t, "hot", removals[0][types.AttributeKeyVault])
+	require.Contains(t, []string{v[0].String(), v[1].String()}, removals[0][types.AttributeKeyFirstCosignerAddress])
+	require.Equal(t, removals[0][types.AttributeKeyFirstCosignerAddress], removals[0][types.AttributeKeyRequester])
+	removal, found := input.Keeper.GetCosignerRemoval(ctx, testutil.TestInstance, testutil.MainchainPublicKeyFromSeed(3))
+	require.True(t, found)
+	require.Equal(t, v[3], removal.ValidatorAddress)
+	require.Equal(t, types.CosignerSetChangeRemoval, removal.Reason)
+	require.True(t, removal.IsScheduled())
+
+	// nothing is emitted again while the eligible set stays the same
+	ctx = input.Ctx.WithEventManager(sdk.NewEventManager())
+	input.Keeper.RotateCosigners(ctx)
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestExpireCosignerRemovals(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1], v[3])
+	removed := testutil.MainchainPublicKeyFromSeed(3)
+	input.Keeper.RotateCosigners(input.Ctx)
+	removal, _ := input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, removed)
+	first := removal.Requester
+
+	// the removal is kept with its requester until the expiry height
+	ctx := input.Ctx.WithBlockHeight(removal.ExpiryHeight - 1).WithEventManager(sdk.NewEventManager())
+	input.Keeper.ExpireCosignerRemovals(ctx)
+	require.Empty(t, ctx.EventManager().Events())
+
+	// then it is handed over to the other active cosigner of the vault
+	ctx = input.Ctx.WithBlockHeight(removal.ExpiryHeight).WithEventManager(sdk.NewEventManager())
+	input.Keeper.ExpireCosignerRemovals(ctx)
+	removals := eventsOfType(ctx, types.EventTypeCosignerRemoval)
+	require.Len(t, removals, 1)
+	removal, _ = input.Keeper.GetCosignerRemoval(ctx, testutil.TestInstance, removed)
+	require.NotEqual(t, first, removal.Requester)
+	require.NotEqual(t, v[3], removal.Requester)
+	require.Equal(t, removal.Requester.String(), removals[0][types.AttributeKeyRequester])
+	require.Equal(t, ctx.BlockHeight()+input.Keeper.GetParams(ctx).ProphecyExpiry, removal.ExpiryHeight)
+
+	// an announced removal is not handed over anymore
+	removal.ExpiryHeight = 0
+	input.Keeper.SetCosignerRemoval(ctx, removal)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 2*input.Keeper.GetParams(ctx).ProphecyExpiry).WithEventManager(sdk.NewEventManager())
+	input.Keeper.ExpireCosignerRemovals(ctx)
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestRotateCosignersLapsesRemovals(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1], v[3])
+	input.Keeper.RotateCosigners(input.Ctx)
+	_, found := input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, testutil.MainchainPublicKeyFromSeed(3))
+	require.True(t, found)
+
+	// the cosigner is eligible again before its removal was announced
+	input.StakingKeeper.SetLastValidatorPower(input.Ctx, v[3], 30)
+	input.StakingKeeper.SetLastTotalPower(input.Ctx, sdk.NewInt(125))
+	input.Keeper.RotateCosigners(input.Ctx)
+	_, found = input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, testutil.MainchainPublicKeyFromSeed(3))
+	require.False(t, found)
+}
+
+func TestRotateCosignersSkipsInactiveCosigners(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1], v[2], v[3])
+	input.Keeper.DeactivateCosigner(input.Ctx, v[3])
+
+	ctx := input.Ctx.WithEventManager(sdk.NewEventManager())
+	input.Keeper.RotateCosigners(ctx)
+	require.Empty(t, eventsOfType(ctx, types.EventTypeCosignerRemoval), "the staking hooks scheduled its removal already")
+}
+
+func TestRotateCosignersLapsesInvitations(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1], v[2])
+	input.Keeper.SetCosignerInvitation(input.Ctx, types.CosignerInvitation{Instance: testutil.TestInstance, Vault: "hot", ValidatorAddress: v[3], Reason: types.CosignerSetChangeInvitation})
+
+	input.Keeper.RotateCosigners(input.Ctx)
+	_, found := input.Keeper.GetCosignerInvitation(input.Ctx, testutil.TestInstance, v[3])
+	require.False(t, found, "the validator is not eligible anymore")
+}
+
+func TestRotateCosignersKeepsGovernanceInvitations(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1], v[2])
+	input.Keeper.SetCosignerInvitation(input.Ctx, types.CosignerInvitation{Instance: testutil.TestInstance, Vault: "hot", ValidatorAddress: v[3], Reason: types.CosignerSetChangeGovernance})
+
+	input.Keeper.RotateCosigners(input.Ctx)
+	_, found := input.Keeper.GetCosignerInvitation(input.Ctx, testutil.TestInstance, v[3])
+	require.True(t, found)
+}
+
+func TestRotateCosignersKeepsInvitedVault(t *testing.T) {
+	input := testutil.CreateTestInput(t, 30, 30, 35, 5)
+	v := input.Validators
+	input.SetVaults(v[0], v[1])
+	input.Keeper.SetCosignerInvitation(input.Ctx, types.CosignerInvitation{Instance: testutil.TestInstance, Vault: "hot", ValidatorAddress: v[2], Reason: types.CosignerSetChangeInvitation})
+
+	ctx := input.Ctx.WithEventManager(sdk.NewEventManager())
+	input.Keeper.RotateCosigners(ctx)
+
+	// the invitation is sent again to the vault it was planned for, not to the empty cold vault
+	invitations := eventsOfType(ctx, types.EventTypeCosignerInvitation)
+	require.Len(t, invitations, 1)
+	require.Equal(t, "hot", invitations[0][types.AttributeKeyVault])
+	require.Contains(t, []string{v[0].String(), v[1].String()}, invitations[0][types.AttributeKeyFirstCosignerAddress])
+}
@@ -0,0 +1,204 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetSweepRequest returns the sweep from a vault of an instance which has not been recorded yet
+func (k Keeper) GetSweepRequest(ctx sdk.Context, instance, vault string) (types.SweepRequest, bool) {
+	var request types.SweepRequest
+	bz := ctx.KVStore(k.storeKey).Get(types.GetSweepRequestKey(instance, vault))
+	if bz == nil {
+		return request, false
+	}
+	if err := json.Unmarshal(bz, &request); err != nil {
+		panic(err)
+	}
+	return request, true
+}
+
+// SetSweepRequest stores a sweep request as is
+func (k Keeper) SetSweepRequest(ctx sdk.Context, request types.SweepRequest) {
+	bz, err := json.Marshal(request)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetSweepRequestKey(request.Instance, request.FromVault), bz)
+}
+
+// DeleteSweepRequest deletes the sweep request from a vault of an instance
+func (k Keeper) DeleteSweepRequest(ctx sdk.Context, instance, vault string) {
+	ctx.KVStore(k.storeKey).Delete(types.GetSweepRequestKey(instance, vault))
+}
+
+// GetSweepRequests returns the sweep requests of the vaults of an instance,
+// or of the vaults of all instances if the name is empty
+func (k Keeper) GetSweepRequests(ctx sdk.Context, name string) []types.SweepRequest {
+	prefix := types.SweepRequestPrefix
+	if name != "" {
+		prefix = types.GetSweepRequestsPrefix(name)
+	}
+	requests := []types.SweepRequest{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var request types.SweepRequest
+		if err := json.Unmarshal(iterator.Value(), &request); err != nil {
+			panic(err)
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// ProposeSweeps asks the cosigners for the sweeps which bring the hot vaults back within their limits.
+// A hot vault above the ceiling of a denom is swept down to the target into the cold vault holding the least of it,
+// one below the floor is refilled up to the target from the cold vault holding the most of it.
+// Vaults with a transfer or a sweep in flight are left alone until it completes, and requests which were not
+// recorded within the prophecy expiry are dropped, so that the sweep is proposed again.
+func (k Keeper) ProposeSweeps(ctx sdk.Context) {
+	params := k.GetParams(ctx)
+	for _, request := range k.GetSweepRequests(ctx, "") {
+		if ctx.BlockHeight()-request.Height < params.ProphecyExpiry {
+			continue
+		}
+		k.DeleteSweepRequest(ctx, request.Instance, request.FromVault)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeSweepExpiry,
+				sdk.NewAttribute(types.AttributeKeyInstance, request.Instance),
+				sdk.NewAttribute(types.AttributeKeyFromVault, request.FromVault),
+				sdk.NewAttribute(types.AttributeKeyToVault, request.ToVault),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, request.Amount.String()),
+				sdk.NewAttribute(types.AttributeKeyHeight, strconv.FormatInt(request.Height, 10)),
+			),
+		)
+	}
+
+	limits := params.HotVaultLimits
+	if limits.Target.Empty() {
+		return
+	}
+	for _, instance := range params.Instances {
+		cold := instance.VaultsOf(true)
+		if len(cold) == 0 {
+			continue
+		}
+		// a hot vault is busy with anything in flight, a cold vault only with funds leaving it
+		busy := make(map[string]bool)
+		markBusy := func(from, to string) {
+			busy[from] = true
+			if vault, found := instance.GetVault(to); found && !vault.Cold {
+				busy[to] = true
+			}
+		}
+		for _, transfer := range k.GetVaultTransfers(ctx, instance.Name) {
+			markBusy(transfer.FromVault, transfer.ToVault)
+		}
+		for _, request := range k.GetSweepRequests(ctx, instance.Name) {
+			markBusy(request.FromVault, request.ToVault)
+		}
+
+		for _, hot := range instance.VaultsOf(false) {
+			if busy[hot.Name] {
+				continue
+			}
+			balance := k.GetVaultBalance(ctx, instance.Name, hot.Name)
+			excess, shortfall := sdk.Coins{}, sdk.Coins{}
+			for _, target := range limits.Target {
+				held := balance.AmountOf(target.Denom)
+				if ceiling := limits.Ceiling.AmountOf(target.Denom); ceiling.IsPositive() && held.GT(ceiling) {
+					excess = append(excess, sdk.NewCoin(target.Denom, held.Sub(target.Amount)))
+				} else if held.LT(limits.Floor.AmountOf(target.Denom)) {
+					shortfall = append(shortfall, sdk.NewCoin(target.Denom, target.Amount.Sub(held)))
+				}
+			}
+
+			if !excess.Empty() {
+				to := k.leastFundedVault(ctx, instance.Name, cold, excess[0].Denom)
+				k.proposeSweep(ctx, instance.Name, hot, to, excess)
+				continue
+			}
+			if !shortfall.Empty() {
+				from, found := k.mostFundedIdleVault(ctx, instance.Name, cold, shortfall[0].Denom, busy)
+				if !found {
+					continue
+				}
+				available := k.GetVaultBalance(ctx, instance.Name, from.Name)
+				amount := sdk.Coins{}
+				for _, coin := range shortfall {
+					if held := available.AmountOf(coin.Denom); held.LT(coin.Amount) {
+						coin.Amount = held
+					}
+					if coin.IsPositive() {
+						amount = append(amount, coin)
+					}
+				}
+				if amount.Empty() {
+					continue
+				}
+				k.proposeSweep(ctx, instance.Name, from, hot, amount)
+				busy[from.Name] = true
+			}
+		}
+	}
+}
+
+func (k Keeper) proposeSweep(ctx sdk.Context, instance string, from, to types.Vault, amount sdk.Coins) {
+	// the aggregate is announced from the source multisig, so one of its cosigners announces it
+	firstCosigner, found := k.GetActiveCosigner(ctx, instance, from.Name, nil)
+	if !found {
+		k.Logger(ctx).Error("no active cosigner to announce a sweep", "instance", instance, "from_vault", from.Name, "to_vault", to.Name, "amount", amount.String())
+		return
+	}
+	k.SetSweepRequest(ctx, types.SweepRequest{
+		Instance:  instance,
+		FromVault: from.Name,
+		ToVault:   to.Name,
+		Amount:    amount,
+		Height:    ctx.BlockHeight(),
+	})
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSweep,
+			sdk.NewAttribute(types.AttributeKeyInstance, instance),
+			sdk.NewAttribute(types.AttributeKeyFromVault, from.Name),
+			sdk.NewAttribute(types.AttributeKeyToVault, to.Name),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, from.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipientAddress, to.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+			sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, firstCosigner.String()),
+		),
+	)
+}
+
+// leastFundedVault returns the vault holding the least of a denom, the vaults must not be empty
+func (k Keeper) leastFundedVault(ctx sdk.Context, instance string, vaults []types.Vault, denom string) types.Vault {
+	lowest := vaults[0]
+	lowestBalance := k.GetVaultBalance(ctx, instance, lowest.Name).AmountOf(denom)
+	for _, vault := range vaults[1:] {
+		if balance := k.GetVaultBalance(ctx, instance, vault.Name).AmountOf(denom); balance.LT(lowestBalance) {
+			lowest, lowestBalance = vault, balance
+		}
+	}
+	return lowest
+}
+
+// mostFundedIdleVault returns the vault holding the most of a denom among the vaults without anything in flight
+func (k Keeper) mostFundedIdleVault(ctx sdk.Context, instance string, vaults []types.Vault, denom string, busy map[string]bool) (types.Vault, bool) {
+	var highest types.Vault
+	highestBalance := sdk.ZeroInt()
+	for _, vault := range vaults {
+		if busy[vault.Name] {
+			continue
+		}
+		if balance := k.GetVaultBalance(ctx, instance, vault.Name).AmountOf(denom); balance.GT(highestBalance) {
+			highest, highestBalance = vault, balance
+		}
+	}
+	return highest, highestBalance.IsPositive()
+}
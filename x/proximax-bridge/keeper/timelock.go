@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetTimeLockedUnpeg returns an unpeg held back by the time lock
+func (k Keeper) GetTimeLockedUnpeg(ctx sdk.Context, id uint64) (types.TimeLockedUnpeg, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTimeLockedUnpegKey(id))
+	if bz == nil {
+		return types.TimeLockedUnpeg{}, false
+	}
+	var locked types.TimeLockedUnpeg
+	if err := json.Unmarshal(bz, &locked); err != nil {
+		panic(err)
+	}
+	return locked, true
+}
+
+// SetTimeLockedUnpeg stores an unpeg held back by the time lock, ids issued later are kept clear of its id
+func (k Keeper) SetTimeLockedUnpeg(ctx sdk.Context, locked types.TimeLockedUnpeg) {
+	bz, err := json.Marshal(locked)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetTimeLockedUnpegKey(locked.ID), bz)
+	k.reserveQueuedUnpegID(ctx, locked.ID)
+}
+
+// DeleteTimeLockedUnpeg removes an unpeg from the time lock
+func (k Keeper) DeleteTimeLockedUnpeg(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.GetTimeLockedUnpegKey(id))
+}
+
+// GetTimeLockedUnpegs returns the unpegs held back by the time lock, in the order they were sent
+func (k Keeper) GetTimeLockedUnpegs(ctx sdk.Context) []types.TimeLockedUnpeg {
+	unpegs := []types.TimeLockedUnpeg{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.TimeLockedUnpegPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var locked types.TimeLockedUnpeg
+		if err := json.Unmarshal(iterator.Value(), &locked); err != nil {
+			panic(err)
+		}
+		unpegs = append(unpegs, locked)
+	}
+	return unpegs
+}
+
+// TimeLockUnpeg holds an unpeg whose coins the module holds back for the delay of the time lock
+func (k Keeper) TimeLockUnpeg(ctx sdk.Context, msg types.MsgUnpeg) uint64 {
+	locked := types.TimeLockedUnpeg{
+		ID:           k.getNextQueuedUnpegID(ctx),
+		Height:       ctx.BlockHeight(),
+		UnlockHeight: ctx.BlockHeight() + k.GetParams(ctx).TimeLock.Delay,
+		Unpeg:        msg,
+	}
+	k.SetTimeLockedUnpeg(ctx, locked)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpegTimeLocked,
+			sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", locked.ID)),
+			sdk.NewAttribute(types.AttributeKeyInstance, msg.Instance),
+			sdk.NewAttribute(types.AttributeKeyCosmosSender, msg.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyMainchainAddress, msg.MainchainAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipients, types.RecipientsAttribute(msg.GetRecipients())),
+			sdk.NewAttribute(types.AttributeKeyUnlockHeight, fmt.Sprintf("%d", locked.UnlockHeight)),
+		),
+	)
+	return locked.ID
+}
+
+// UnlockUnpegs moves the unpegs whose delay is over into the withdrawal queue,
+// where they keep the place of their id ahead of the unpegs sent after them
+func (k Keeper) UnlockUnpegs(ctx sdk.Context) {
+	for _, locked := range k.GetTimeLockedUnpegs(ctx) {
+		if locked.UnlockHeight > ctx.BlockHeight() {
+			continue
+		}
+		k.DeleteTimeLockedUnpeg(ctx, locked.ID)
+		k.SetQueuedUnpeg(ctx, types.QueuedUnpeg{ID: locked.ID, Height: locked.Height, Unpeg: locked.Unpeg})
+	}
+}
+
+// VetoUnpeg cancels an unpeg held back by the time lock at the request of a guardian and refunds it
+func (k Keeper) VetoUnpeg(ctx sdk.Context, guardian sdk.AccAddress, id uint64) error {
+	if !k.GetParams(ctx).TimeLock.IsGuardian(guardian) {
+		return sdkerrors.Wrap(types.ErrNotGuardian, guardian.String())
+	}
+	locked, found := k.GetTimeLockedUnpeg(ctx, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrQueuedUnpegNotFound, "no time-locked unpeg %d", id)
+	}
+	k.DeleteTimeLockedUnpeg(ctx, id)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUnpegVeto,
+			sdk.NewAttribute(types.AttributeKeyQueueID, fmt.Sprintf("%d", id)),
+			sdk.NewAttribute(types.AttributeKeyGuardian, guardian.String()),
+		),
+	)
+	k.refundUnpeg(ctx, id, locked.Unpeg, fmt.Sprintf("vetoed by %s", guardian))
+	return nil
+}
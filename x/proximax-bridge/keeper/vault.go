@@ -0,0 +1,233 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// GetVaultBalance returns the amount of the pegged denoms a vault of an instance holds on the mainchain
+func (k Keeper) GetVaultBalance(ctx sdk.Context, instance, vault string) sdk.Coins {
+	balance := sdk.Coins{}
+	bz := ctx.KVStore(k.storeKey).Get(types.GetVaultBalanceKey(instance, vault))
+	if bz == nil {
+		return balance
+	}
+	if err := json.Unmarshal(bz, &balance); err != nil {
+		panic(err)
+	}
+	return balance
+}
+
+// SetVaultBalance replaces the balance of a vault of an instance
+func (k Keeper) SetVaultBalance(ctx sdk.Context, instance, vault string, balance sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	if balance.Empty() {
+		store.Delete(types.GetVaultBalanceKey(instance, vault))
+		return
+	}
+	bz, err := json.Marshal(balance)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.GetVaultBalanceKey(instance, vault), bz)
+}
+
+// GetVaultBalances returns the balances of the vaults of an instance,
+// or of the vaults of all instances if the name is empty
+func (k Keeper) GetVaultBalances(ctx sdk.Context, name string) []types.VaultBalance {
+	balances := []types.VaultBalance{}
+	for _, instance := range k.GetInstances(ctx) {
+		if name != "" && instance.Name != name {
+			continue
+		}
+		for _, vault := range instance.Vaults {
+			balance := k.GetVaultBalance(ctx, instance.Name, vault.Name)
+			if balance.Empty() {
+				continue
+			}
+			balances = append(balances, types.VaultBalance{Instance: instance.Name, Vault: vault.Name, Balance: balance})
+		}
+	}
+	return balances
+}
+
+// addToVault credits a vault with pegged funds received on the mainchain
+func (k Keeper) addToVault(ctx sdk.Context, instance, vault string, amount sdk.Coins) {
+	k.SetVaultBalance(ctx, instance, vault, k.GetVaultBalance(ctx, instance, vault).Add(amount...))
+}
+
+// subtractFromVault debits a vault for funds leaving it on the mainchain
+func (k Keeper) subtractFromVault(ctx sdk.Context, instance, vault string, amount sdk.Coins) error {
+	balance, negative := k.GetVaultBalance(ctx, instance, vault).SafeSub(amount)
+	if negative {
+		return sdkerrors.Wrapf(types.ErrInsufficientVault, "vault %s of instance %s holds less than %s", vault, instance, amount)
+	}
+	k.SetVaultBalance(ctx, instance, vault, balance)
+	return nil
+}
+
+// RouteDeposit returns the hot vault of an instance holding the least of a denom, which deposits of the denom are sent to.
+// An instance without hot vaults takes deposits into its cold vaults.
+func (k Keeper) RouteDeposit(ctx sdk.Context, name, denom string) (types.Vault, bool) {
+	instance, found := k.GetInstance(ctx, name)
+	if !found || len(instance.Vaults) == 0 {
+		return types.Vault{}, false
+	}
+
+	vaults := instance.VaultsOf(false)
+	if len(vaults) == 0 {
+		vaults = instance.Vaults
+	}
+	return k.leastFundedVault(ctx, name, vaults, denom), true
+}
+
+// RouteUnpeg returns the hot vault of an instance an unpeg is paid from. Among the hot vaults holding the whole amount
+// with an active cosigner to announce it, it picks the one holding the most of the first denom.
+// Cold vaults pay unpegs only when the instance has no hot vault.
+func (k Keeper) RouteUnpeg(ctx sdk.Context, name string, amount sdk.Coins) (types.Vault, error) {
+	instance, found := k.GetInstance(ctx, name)
+	if !found {
+		return types.Vault{}, sdkerrors.Wrap(types.ErrInvalidInstance, name)
+	}
+
+	vaults := instance.VaultsOf(false)
+	if len(vaults) == 0 {
+		vaults = instance.Vaults
+	}
+	var routed types.Vault
+	highest := sdk.NewInt(-1)
+	for _, vault := range vaults {
+		balance := k.GetVaultBalance(ctx, name, vault.Name)
+		if !balance.IsAllGTE(amount) {
+			continue
+		}
+		if _, found := k.GetActiveCosigner(ctx, name, vault.Name, nil); !found {
+			continue
+		}
+		if held := balance.AmountOf(amount[0].Denom); held.GT(highest) {
+			routed, highest = vault, held
+		}
+	}
+	if highest.IsNegative() {
+		return types.Vault{}, sdkerrors.Wrapf(types.ErrInsufficientVault, "no vault of instance %s can pay %s", name, amount)
+	}
+	return routed, nil
+}
+
+// RecordVaultTransfer records a transfer between vaults announced on the mainchain and deducts it from the source vault
+func (k Keeper) RecordVaultTransfer(ctx sdk.Context, transfer types.VaultTransfer) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetVaultTransferKey(transfer.Instance, transfer.MainchainTxHash)
+	if store.Has(key) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "vault transfer %s is recorded already", transfer.MainchainTxHash)
+	}
+	if err := k.subtractFromVault(ctx, transfer.Instance, transfer.FromVault, transfer.Amount); err != nil {
+		return err
+	}
+	k.SetVaultTransfer(ctx, transfer)
+	return nil
+}
+
+// SetVaultTransfer stores a pending transfer between vaults as is
+func (k Keeper) SetVaultTransfer(ctx sdk.Context, transfer types.VaultTransfer) {
+	bz, err := json.Marshal(transfer)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetVaultTransferKey(transfer.Instance, transfer.MainchainTxHash), bz)
+}
+
+// GetVaultTransfer returns a pending transfer between vaults
+func (k Keeper) GetVaultTransfer(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash) (types.VaultTransfer, bool) {
+	var transfer types.VaultTransfer
+	bz := ctx.KVStore(k.storeKey).Get(types.GetVaultTransferKey(instance, mainchainTxHash))
+	if bz == nil {
+		return transfer, false
+	}
+	if err := json.Unmarshal(bz, &transfer); err != nil {
+		panic(err)
+	}
+	return transfer, true
+}
+
+// GetVaultTransfers returns the pending transfers between the vaults of an instance,
+// or between the vaults of all instances if the name is empty
+func (k Keeper) GetVaultTransfers(ctx sdk.Context, name string) []types.VaultTransfer {
+	prefix := types.VaultTransferPrefix
+	if name != "" {
+		prefix = types.GetVaultTransfersPrefix(name)
+	}
+	transfers := []types.VaultTransfer{}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var transfer types.VaultTransfer
+		if err := json.Unmarshal(iterator.Value(), &transfer); err != nil {
+			panic(err)
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers
+}
+
+// CompleteVaultTransfer credits the destination vault of a pending transfer confirmed on the mainchain,
+// or refunds the source vault of a transfer which was not cosigned
+func (k Keeper) CompleteVaultTransfer(ctx sdk.Context, instance string, mainchainTxHash types.MainchainTxHash, confirmed bool) (types.VaultTransfer, bool) {
+	transfer, found := k.GetVaultTransfer(ctx, instance, mainchainTxHash)
+	if !found {
+		return transfer, false
+	}
+	if confirmed {
+		k.addToVault(ctx, instance, transfer.ToVault, transfer.Amount)
+	} else {
+		k.addToVault(ctx, instance, transfer.FromVault, transfer.Amount)
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetVaultTransferKey(instance, mainchainTxHash))
+	k.emitVaultTransferResult(ctx, transfer, confirmed)
+	return transfer, true
+}
+
+// getMainchainTxVault returns the vault whose multisig a mainchain transaction of an instance is announced from
+func (k Keeper) getMainchainTxVault(ctx sdk.Context, instance types.BridgeInstance, txHash types.MainchainTxHash) (string, bool) {
+	if unpeg, err := k.GetUnpegRecord(ctx, instance.Name, txHash); err == nil {
+		return unpeg.Vault, true
+	}
+	if batch, found := k.GetUnpegBatchByTx(ctx, instance.Name, txHash); found {
+		return batch.Vault, true
+	}
+	if transfer, found := k.GetVaultTransfer(ctx, instance.Name, txHash); found {
+		return transfer.FromVault, true
+	}
+	if invitation, err := k.GetPendingRequest(ctx, instance.Name, txHash); err == nil {
+		return invitation.Vault, true
+	}
+	if removal, err := k.GetPendingRemovalRequest(ctx, instance.Name, txHash); err == nil {
+		if cosigner, found := instance.GetCosigner(removal.MainchainPublicKey); found {
+			return cosigner.Vault, true
+		}
+	}
+	return "", false
+}
+
+func (k Keeper) emitVaultTransferResult(ctx sdk.Context, transfer types.VaultTransfer, confirmed bool) {
+	status := "confirmed"
+	if !confirmed {
+		status = "not_cosigned"
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeVaultTransferResult,
+			sdk.NewAttribute(types.AttributeKeyInstance, transfer.Instance),
+			sdk.NewAttribute(types.AttributeKeyMainchainTxHash, transfer.MainchainTxHash.String()),
+			sdk.NewAttribute(types.AttributeKeyFromVault, transfer.FromVault),
+			sdk.NewAttribute(types.AttributeKeyToVault, transfer.ToVault),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, transfer.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyStatus, status),
+			sdk.NewAttribute(types.AttributeKeySweep, strconv.FormatBool(transfer.Sweep)),
+		),
+	)
+}
@@ -0,0 +1,195 @@
+package proximax_bridge
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// NewProposalHandler creates a govtypes.Handler for the proximax-bridge proposals
+func NewProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case ChangeMultisigAddressProposal:
+			return handleChangeMultisigAddressProposal(ctx, k, c)
+		case AddCosignerProposal:
+			return handleAddCosignerProposal(ctx, k, c)
+		case RemoveCosignerProposal:
+			return handleRemoveCosignerProposal(ctx, k, c)
+		case ChangeMultisigApprovalProposal:
+			return handleChangeMultisigApprovalProposal(ctx, k, c)
+
+		default:
+			errMsg := fmt.Sprintf("unrecognized %s proposal content type: %T", types.ModuleName, c)
+			return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		}
+	}
+}
+
+func handleChangeMultisigAddressProposal(ctx sdk.Context, k Keeper, p ChangeMultisigAddressProposal) error {
+	instance, found := k.GetInstance(ctx, p.Instance)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, p.Instance)
+	}
+	if err := p.MainchainMultisigAddress.ValidateFor(instance.MainchainNetworkType); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidMainchainAddress, err.Error())
+	}
+
+	previous, existed := instance.GetVault(p.Vault)
+	// the cosigners of a vault are members of its multisig on the mainchain, the vault cannot move
+	// to another multisig before they are removed through confirmed multisig modifications
+	if existed && previous.MainchainMultisigAddress != p.MainchainMultisigAddress && len(instance.VaultCosigners(p.Vault)) > 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidVault, "vault %s still has cosigners on %s", p.Vault, previous.MainchainMultisigAddress)
+	}
+	if err := k.SetMainchainMultisigAddress(ctx, p.Instance, p.Vault, p.MainchainMultisigAddress, p.Cold); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeChangeMultisigAddress,
+			sdk.NewAttribute(types.AttributeKeyInstance, p.Instance),
+			sdk.NewAttribute(types.AttributeKeyVault, p.Vault),
+			sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, p.MainchainMultisigAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyCold, strconv.FormatBool(p.Cold)),
+		),
+	)
+
+	// a vault moved to another tier takes the approval policy of the tier
+	if existed && previous.Cold != p.Cold {
+		vault := types.NewVault(p.Vault, p.MainchainMultisigAddress, p.Cold)
+		emitRebalanceMultisig(ctx, k, p.Instance, vault, k.GetParams(ctx).ApprovalFor(vault))
+	}
+
+	return nil
+}
+
+func handleAddCosignerProposal(ctx sdk.Context, k Keeper, p AddCosignerProposal) error {
+	instance, found := k.GetInstance(ctx, p.Instance)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, p.Instance)
+	}
+	if _, found := instance.GetCosigner(p.MainchainPublicKey); found {
+		return sdkerrors.Wrap(types.ErrCosignerAlreadyExists, p.MainchainPublicKey.String())
+	}
+	if other, found := instance.GetCosignerOf(p.ValidatorAddress.String()); found {
+		return sdkerrors.Wrapf(types.ErrCosignerAlreadyExists, "%s cosigns for vault %s already", p.ValidatorAddress, other.Vault)
+	}
+	vault, found := instance.GetVault(p.Vault)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrInvalidVault, "instance %s has no vault %s", p.Instance, p.Vault)
+	}
+
+	// nobody can announce a modification of a multisig without cosigners, its first cosigners
+	// are the ones its account was converted with on the mainchain
+	if len(instance.VaultCosigners(vault.Name)) == 0 {
+		if err := k.AddNewCosigner(ctx, p.Instance, p.Vault, p.ValidatorAddress, p.MainchainPublicKey, types.CosignerSetChangeGovernance, ""); err != nil {
+			return err
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeAddCosigner,
+				sdk.NewAttribute(types.AttributeKeyInstance, p.Instance),
+				sdk.NewAttribute(types.AttributeKeyVault, p.Vault),
+				sdk.NewAttribute(types.AttributeKeyValidator, p.ValidatorAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyCosignerPublicKey, p.MainchainPublicKey.String()),
+			),
+		)
+		return nil
+	}
+
+	// the validator is invited like the rotation invites it, and joins once the multisig modification is confirmed
+	k.SetCosignerInvitation(ctx, types.CosignerInvitation{
+		Instance:           p.Instance,
+		Vault:              vault.Name,
+		ValidatorAddress:   p.ValidatorAddress,
+		MainchainPublicKey: p.MainchainPublicKey,
+		Reason:             types.CosignerSetChangeGovernance,
+	})
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyInstance, p.Instance),
+		sdk.NewAttribute(types.AttributeKeyVault, vault.Name),
+		sdk.NewAttribute(types.AttributeKeyValidator, p.ValidatorAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+	}
+	if firstCosigner, found := k.GetActiveCosigner(ctx, p.Instance, vault.Name, p.ValidatorAddress); found {
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, firstCosigner.String()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCosignerInvitation, attributes...))
+
+	return nil
+}
+
+func handleRemoveCosignerProposal(ctx sdk.Context, k Keeper, p RemoveCosignerProposal) error {
+	instance, found := k.GetInstance(ctx, p.Instance)
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalidInstance, p.Instance)
+	}
+	cosigner, found := instance.GetCosigner(p.MainchainPublicKey)
+	if !found {
+		return sdkerrors.Wrap(types.ErrCosignerNotFound, p.MainchainPublicKey.String())
+	}
+	validator, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress)
+	if err != nil {
+		return err
+	}
+	vault, _ := instance.GetVault(cosigner.Vault)
+
+	// the cosigner may not cooperate in its own removal, another active cosigner of the vault requests and announces it,
+	// and the cosigner leaves once the multisig modification is confirmed
+	requester, found := k.GetActiveCosigner(ctx, p.Instance, vault.Name, validator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrNoActiveCosigner, "no other active cosigner in vault %s to remove %s", vault.Name, p.MainchainPublicKey)
+	}
+	k.ScheduleCosignerRemoval(ctx, types.CosignerRemoval{
+		Instance:                 p.Instance,
+		Vault:                    vault.Name,
+		ValidatorAddress:         validator,
+		MainchainPublicKey:       cosigner.MainchainPublicKey,
+		MainchainMultisigAddress: vault.MainchainMultisigAddress,
+		Reason:                   types.CosignerSetChangeGovernance,
+	}, requester)
+
+	return nil
+}
+
+func handleChangeMultisigApprovalProposal(ctx sdk.Context, k Keeper, p ChangeMultisigApprovalProposal) error {
+	params := k.GetParams(ctx)
+	if p.Cold {
+		params.ColdMultisigApproval = p.MultisigApproval
+	} else {
+		params.MultisigApproval = p.MultisigApproval
+	}
+	// the cold vaults cannot end up less strict than the hot ones
+	if err := params.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	k.SetParams(ctx, params)
+
+	// on every vault of the tier, the active cosigner with the most power announces the new thresholds on the mainchain
+	for _, instance := range params.Instances {
+		for _, vault := range instance.VaultsOf(p.Cold) {
+			emitRebalanceMultisig(ctx, k, instance.Name, vault, p.MultisigApproval)
+		}
+	}
+
+	return nil
+}
+
+func emitRebalanceMultisig(ctx sdk.Context, k Keeper, instance string, vault types.Vault, approval types.MultisigApproval) {
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyInstance, instance),
+		sdk.NewAttribute(types.AttributeKeyVault, vault.Name),
+		sdk.NewAttribute(types.AttributeKeyMultisigAccountAddress, vault.MainchainMultisigAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyMinApproval, approval.MinApproval.String()),
+		sdk.NewAttribute(types.AttributeKeyMinRemoval, approval.MinRemoval.String()),
+	}
+	if firstCosigner, found := k.GetActiveCosigner(ctx, instance, vault.Name, nil); found {
+		attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyFirstCosignerAddress, firstCosigner.String()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeRebalanceMultisig, attributes...))
+}
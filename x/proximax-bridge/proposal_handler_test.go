@@ -0,0 +1,89 @@
+package proximax_bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/testutil"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+func TestAddCosignerProposalInvites(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	newKey := testutil.MainchainPublicKeyFromSeed(4)
+	ctx := input.Ctx.WithEventManager(sdk.NewEventManager())
+
+	err := NewProposalHandler(input.Keeper)(ctx, types.NewAddCosignerProposal("add", "add", testutil.TestInstance, "hot", v[3], newKey))
+	require.NoError(t, err)
+
+	// the validator is only invited, it joins once the multisig modification is confirmed
+	_, found := input.Keeper.GetCosigner(ctx, testutil.TestInstance, newKey)
+	require.False(t, found)
+	invitation, found := input.Keeper.GetCosignerInvitation(ctx, testutil.TestInstance, v[3])
+	require.True(t, found)
+	require.Equal(t, "hot", invitation.Vault)
+	require.Equal(t, newKey, invitation.MainchainPublicKey)
+	require.Equal(t, types.CosignerSetChangeGovernance, invitation.Reason)
+	require.Equal(t, types.EventTypeCosignerInvitation, ctx.EventManager().Events()[0].Type)
+
+	// the invited validator can only request to join with the key governance approved
+	require.Error(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", testutil.MainchainPublicKeyFromSeed(5), v[0])))
+	require.NoError(t, deliver(input, types.NewMsgRequestInvitation(v[3], testutil.TestInstance, "hot", newKey, v[0])))
+}
+
+func TestAddCosignerProposalBootstrapsEmptyVault(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	v := input.Validators
+	newKey := testutil.MainchainPublicKeyFromSeed(4)
+
+	err := NewProposalHandler(input.Keeper)(input.Ctx, types.NewAddCosignerProposal("add", "add", testutil.TestInstance, "cold", v[3], newKey))
+	require.NoError(t, err)
+	cosigner, found := input.Keeper.GetCosigner(input.Ctx, testutil.TestInstance, newKey)
+	require.True(t, found)
+	require.Equal(t, "cold", cosigner.Vault)
+
+	// a validator cosigns for a single vault
+	err = NewProposalHandler(input.Keeper)(input.Ctx, types.NewAddCosignerProposal("add", "add", testutil.TestInstance, "hot", v[3], testutil.MainchainPublicKeyFromSeed(5)))
+	require.Error(t, err)
+}
+
+func TestRemoveCosignerProposalRequestsRemoval(t *testing.T) {
+	input, cosigners := createCosignerInput(t)
+	v := input.Validators
+	removed := cosigners[0].MainchainPublicKey
+	ctx := input.Ctx.WithEventManager(sdk.NewEventManager())
+
+	err := NewProposalHandler(input.Keeper)(ctx, types.NewRemoveCosignerProposal("remove", "remove", testutil.TestInstance, removed))
+	require.NoError(t, err)
+
+	// the cosigner stays until the multisig modification is confirmed
+	_, found := input.Keeper.GetCosigner(ctx, testutil.TestInstance, removed)
+	require.True(t, found)
+	removal, found := input.Keeper.GetCosignerRemoval(ctx, testutil.TestInstance, removed)
+	require.True(t, found)
+	require.Equal(t, types.CosignerSetChangeGovernance, removal.Reason)
+	event := ctx.EventManager().Events()[0]
+	require.Equal(t, types.EventTypeCosignerRemoval, event.Type)
+
+	// another active cosigner requests the removal even though the cosigner is active
+	require.NoError(t, deliver(input, types.NewMsgRequestRemoval(v[1], testutil.TestInstance, removed, v[1])))
+	removal, _ = input.Keeper.GetCosignerRemoval(input.Ctx, testutil.TestInstance, removed)
+	require.Equal(t, types.CosignerSetChangeGovernance, removal.Reason)
+}
+
+func TestChangeMultisigAddressProposalKeepsCosigners(t *testing.T) {
+	input, _ := createCosignerInput(t)
+	handler := NewProposalHandler(input.Keeper)
+
+	// the cosigners of the hot vault are members of its multisig
+	err := handler(input.Ctx, types.NewChangeMultisigAddressProposal("move", "move", testutil.TestInstance, "hot", testutil.MainchainAddressFromSeed(3), false))
+	require.Error(t, err)
+
+	// the cold vault has no cosigners yet
+	err = handler(input.Ctx, types.NewChangeMultisigAddressProposal("move", "move", testutil.TestInstance, "cold", testutil.MainchainAddressFromSeed(3), true))
+	require.NoError(t, err)
+}
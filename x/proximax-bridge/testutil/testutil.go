@@ -0,0 +1,215 @@
+// Package testutil builds a bridge keeper over in-memory stores for the tests of the module
+package testutil
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/peggy/x/oracle"
+
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/keeper"
+	"github.com/lcnem/proximax-pegzone/x/proximax-bridge/types"
+)
+
+// TestInstance is the bridge instance of the test input
+const TestInstance = types.DefaultInstanceName
+
+// Slash is a call to the slashing keeper
+type Slash struct {
+	ConsAddress sdk.ConsAddress
+	Fraction    sdk.Dec
+	Power       int64
+}
+
+// MockSlashingKeeper records the slashes instead of applying them
+type MockSlashingKeeper struct {
+	slashes *[]Slash
+}
+
+func (sk MockSlashingKeeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, fraction sdk.Dec, power int64, distributionHeight int64) {
+	*sk.slashes = append(*sk.slashes, Slash{ConsAddress: consAddr, Fraction: fraction, Power: power})
+}
+
+// TestInput is a keeper over in-memory stores, with bonded validators of the given powers
+type TestInput struct {
+	Ctx           sdk.Context
+	Keeper        keeper.Keeper
+	BankKeeper    bank.Keeper
+	SupplyKeeper  supply.Keeper
+	StakingKeeper staking.Keeper
+	Validators    []sdk.ValAddress
+	Slashes       *[]Slash
+}
+
+// CreateTestInput creates a keeper over in-memory stores with the default params and bonded validators of the given powers
+func CreateTestInput(t *testing.T, powers ...int64) TestInput {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyOracle := sdk.NewKVStoreKey(oracle.StoreKey)
+	keys := sdk.NewKVStoreKeys(types.StoreKey, types.StoreKeyForPeg, types.StoreKeyForUnpeg, types.StoreKeyForCosign, types.StoreKeyForInvite, types.StoreKeyForRemoval, types.StoreKeyForProphecy)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range []sdk.StoreKey{keyAcc, keyParams, keySupply, keyStaking, keyOracle} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	for _, key := range keys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "testchain", Height: 1, Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, false, log.NewNopLogger())
+	ctx = ctx.WithConsensusParams(&abci.ConsensusParams{
+		Validator: &abci.ValidatorParams{PubKeyTypes: []string{tmtypes.ABCIPubKeyTypeEd25519}},
+	})
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	staking.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), map[string]bool{})
+	maccPerms := map[string][]string{
+		staking.BondedPoolName:    {supply.Burner, supply.Staking},
+		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
+		types.ModuleName:          {supply.Minter, supply.Burner},
+		types.FeePoolName:         nil,
+	}
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+
+	// the stake of the validators is taken out of the not bonded pool
+	bonded := sdk.ZeroInt()
+	for _, power := range powers {
+		bonded = bonded.Add(sdk.TokensFromConsensusPower(power))
+	}
+	stake := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, bonded))
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(stake))
+	notBondedPool := supply.NewEmptyModuleAccount(staking.NotBondedPoolName, supply.Burner, supply.Staking)
+	require.NoError(t, notBondedPool.SetCoins(stake))
+	supplyKeeper.SetModuleAccount(ctx, notBondedPool)
+
+	stakingKeeper := staking.NewKeeper(cdc, keyStaking, supplyKeeper, paramsKeeper.Subspace(staking.DefaultParamspace))
+	stakingKeeper.SetParams(ctx, staking.DefaultParams())
+
+	validators := make([]sdk.ValAddress, len(powers))
+	for i, power := range powers {
+		pubKey := testPubKey(i)
+		validators[i] = sdk.ValAddress(pubKey.Address())
+		validator := staking.NewValidator(validators[i], pubKey, staking.Description{})
+		validator, _ = validator.AddTokensFromDel(sdk.TokensFromConsensusPower(power))
+		stakingKeeper.SetValidator(ctx, validator)
+		stakingKeeper.SetValidatorByPowerIndex(ctx, validator)
+	}
+	stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	slashes := []Slash{}
+	oracleKeeper := oracle.NewKeeper(cdc, keyOracle, stakingKeeper, oracle.DefaultConsensusNeeded)
+	bridgeKeeper := keeper.NewKeeper(cdc, keys[types.StoreKey], keys[types.StoreKeyForPeg], keys[types.StoreKeyForUnpeg], keys[types.StoreKeyForCosign], keys[types.StoreKeyForInvite], keys[types.StoreKeyForRemoval], keys[types.StoreKeyForProphecy], keyOracle, paramsKeeper.Subspace(types.DefaultParamspace), supplyKeeper, MockSlashingKeeper{slashes: &slashes}, stakingKeeper, keeper.NewOracleKeeper(oracleKeeper, keyOracle))
+	bridgeKeeper.SetParams(ctx, types.DefaultParams())
+
+	return TestInput{
+		Ctx:           ctx,
+		Keeper:        bridgeKeeper,
+		BankKeeper:    bankKeeper,
+		SupplyKeeper:  supplyKeeper,
+		StakingKeeper: stakingKeeper,
+		Validators:    validators,
+		Slashes:       &slashes,
+	}
+}
+
+// SetVaults registers a hot and a cold vault on the test instance,
+// with the validators as cosigners of the hot vault
+func (input TestInput) SetVaults(cosigners ...sdk.ValAddress) {
+	params := input.Keeper.GetParams(input.Ctx)
+	instance, _ := params.GetInstance(TestInstance)
+	instance.Vaults = []types.Vault{
+		types.NewVault("hot", MainchainAddressFromSeed(1), false),
+		types.NewVault("cold", MainchainAddressFromSeed(2), true),
+	}
+	params.SetInstance(instance)
+	input.Keeper.SetParams(input.Ctx, params)
+	for i, validator := range cosigners {
+		input.Keeper.SetCosigner(input.Ctx, TestInstance, types.Cosigner{
+			ValidatorAddress:   validator.String(),
+			MainchainPublicKey: MainchainPublicKeyFromSeed(byte(i + 1)),
+			Vault:              "hot",
+		})
+	}
+}
+
+// AccAddressFromSeed returns an account address derived from the seed
+func AccAddressFromSeed(seed byte) sdk.AccAddress {
+	bz := make([]byte, sdk.AddrLen)
+	for i := range bz {
+		bz[i] = seed
+	}
+	return sdk.AccAddress(bz)
+}
+
+// MainchainAddressFromSeed returns a valid address of the network of the test instance, derived from the seed
+func MainchainAddressFromSeed(seed byte) types.MainchainAddress {
+	raw := make([]byte, 21, 25)
+	raw[0] = types.DefaultMainchainNetworkType.Version()
+	for i := 1; i < 21; i++ {
+		raw[i] = seed
+	}
+	hash := sha3.Sum256(raw)
+	return types.MainchainAddress(base32.StdEncoding.EncodeToString(append(raw, hash[:4]...)))
+}
+
+// MainchainPublicKeyFromSeed returns a valid public key derived from the seed
+func MainchainPublicKeyFromSeed(seed byte) types.MainchainPublicKey {
+	bz := make([]byte, types.MainchainPublicKeyLength/2)
+	for i := range bz {
+		bz[i] = seed
+	}
+	return types.MainchainPublicKey(fmt.Sprintf("%X", bz))
+}
+
+// MainchainTxHashFromSeed returns a valid transaction hash derived from the seed
+func MainchainTxHashFromSeed(seed byte) types.MainchainTxHash {
+	bz := make([]byte, types.MainchainTxHashLength/2)
+	for i := range bz {
+		bz[i] = seed
+	}
+	return types.MainchainTxHash(fmt.Sprintf("%X", bz))
+}
+
+func testPubKey(i int) ed25519.PubKeyEd25519 {
+	bz, _ := hex.DecodeString(fmt.Sprintf("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AF%03d", 100+i))
+	var pubKey ed25519.PubKeyEd25519
+	copy(pubKey[:], bz)
+	return pubKey
+}
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultUnpegBatchWindow dispatches every unpeg in an aggregate of its own
+	DefaultUnpegBatchWindow int64 = 0
+
+	// MaxUnpegBatchTransfers is the most inner transfers of a batch aggregate, a full batch is closed before its window ends
+	MaxUnpegBatchTransfers = 1000
+
+	// MaxUnpegBatchReassignments is how often a batch which was not announced in time is handed to another cosigner before it is refunded
+	MaxUnpegBatchReassignments = 3
+)
+
+const (
+	// UnpegBatchStatusOpen is a batch collecting the unpegs of its window
+	UnpegBatchStatusOpen = "open"
+	// UnpegBatchStatusClosed is a batch waiting for its first cosigner to announce the aggregate
+	UnpegBatchStatusClosed = "closed"
+	// UnpegBatchStatusAnnounced is a batch whose aggregate waits for the cosignatures on ProximaX until the expiry height
+	UnpegBatchStatusAnnounced = "announced"
+	// UnpegBatchStatusCompleted is a batch whose aggregate was confirmed on ProximaX
+	UnpegBatchStatusCompleted = "completed"
+	// UnpegBatchStatusFailed is a batch which was not paid out, its unpegs were refunded
+	UnpegBatchStatusFailed = "failed"
+)
+
+// BatchedUnpeg is an unpeg paid out by a batch, with the net amount each of its recipients receives
+type BatchedUnpeg struct {
+	ID         uint64           `json:"id" yaml:"id"`
+	Address    sdk.AccAddress   `json:"address" yaml:"address"`
+	Recipients []UnpegRecipient `json:"recipients" yaml:"recipients"`
+}
+
+// Amount returns the net amount the unpeg pays out
+func (u BatchedUnpeg) Amount() sdk.Coins {
+	return SumRecipients(u.Recipients)
+}
+
+// UnpegBatch is the unpegs a vault pays out within a window of blocks, announced in a single aggregate.
+// The batch closes at the close height, the last block of its window, and its first cosigner
+// has until the expiry height to announce the aggregate. Once announced, the expiry height moves
+// to the deadline for the confirmation of the aggregate.
+type UnpegBatch struct {
+	ID                   uint64          `json:"id" yaml:"id"`
+	Instance             string          `json:"instance" yaml:"instance"`
+	Vault                string          `json:"vault" yaml:"vault"`
+	Height               int64           `json:"height" yaml:"height"`
+	CloseHeight          int64           `json:"close_height" yaml:"close_height"`
+	ExpiryHeight         int64           `json:"expiry_height" yaml:"expiry_height"`
+	Reassignments        uint32          `json:"reassignments" yaml:"reassignments"`
+	FirstCosignerAddress sdk.ValAddress  `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Unpegs               []BatchedUnpeg  `json:"unpegs" yaml:"unpegs"`
+	Status               string          `json:"status" yaml:"status"`
+	MainchainTxHash      MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// Amount returns the net amount the batch pays out
+func (b UnpegBatch) Amount() sdk.Coins {
+	amount := sdk.NewCoins()
+	for _, unpeg := range b.Unpegs {
+		amount = amount.Add(unpeg.Amount()...)
+	}
+	return amount
+}
+
+// Transfers returns the number of inner transfers of the batch aggregate, one per recipient of each unpeg
+func (b UnpegBatch) Transfers() int {
+	transfers := 0
+	for _, unpeg := range b.Unpegs {
+		transfers += len(unpeg.Recipients)
+	}
+	return transfers
+}
+
+// IsSettled tells whether the batch was paid out or refunded
+func (b UnpegBatch) IsSettled() bool {
+	return b.Status == UnpegBatchStatusCompleted || b.Status == UnpegBatchStatusFailed
+}
+
+// UnpegBatchCloseHeight returns the last block of the window of a height
+func UnpegBatchCloseHeight(height, window int64) int64 {
+	return (height/window+1)*window - 1
+}
+
+func validateUnpegBatchWindow(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("unpeg batch window cannot be negative: %d", v)
+	}
+	return nil
+}
+
+// validateUnpegBatches reports batches of unknown vaults, duplicate ids, unknown statuses,
+// malformed unpegs, closed and announced batches expiring before they close and announced batches without their mainchain transaction
+func validateUnpegBatches(batches []UnpegBatch, instances []BridgeInstance, report func(string, error)) {
+	ids := make(map[uint64]int)
+	for i, batch := range batches {
+		field := fmt.Sprintf("unpeg_batches[%d]", i)
+		if j, ok := ids[batch.ID]; ok {
+			report(field+".id", fmt.Errorf("duplicate of unpeg_batches[%d]: %d", j, batch.ID))
+		} else {
+			ids[batch.ID] = i
+		}
+		if err := validateVaultOf(batch.Instance, batch.Vault, instances); err != nil {
+			report(field, err)
+		}
+		if batch.CloseHeight < batch.Height {
+			report(field+".close_height", fmt.Errorf("close height %d before height %d", batch.CloseHeight, batch.Height))
+		}
+		if (batch.Status == UnpegBatchStatusClosed || batch.Status == UnpegBatchStatusAnnounced) && batch.ExpiryHeight < batch.CloseHeight {
+			report(field+".expiry_height", fmt.Errorf("expiry height %d before close height %d", batch.ExpiryHeight, batch.CloseHeight))
+		}
+		switch batch.Status {
+		case UnpegBatchStatusOpen, UnpegBatchStatusClosed:
+			if !batch.MainchainTxHash.Empty() {
+				report(field+".mainchain_tx_hash", fmt.Errorf("a %s batch has no mainchain tx hash: %s", batch.Status, batch.MainchainTxHash))
+			}
+		case UnpegBatchStatusAnnounced, UnpegBatchStatusCompleted:
+			if err := batch.MainchainTxHash.Validate(); err != nil {
+				report(field+".mainchain_tx_hash", err)
+			}
+		case UnpegBatchStatusFailed:
+		default:
+			report(field+".status", fmt.Errorf("unknown status: %s", batch.Status))
+		}
+		if len(batch.Unpegs) == 0 {
+			report(field+".unpegs", fmt.Errorf("empty batch"))
+		}
+		for j, unpeg := range batch.Unpegs {
+			if unpeg.Address.Empty() {
+				report(fmt.Sprintf("%s.unpegs[%d].address", field, j), fmt.Errorf("address cannot be empty"))
+			}
+			if len(unpeg.Recipients) == 0 {
+				report(fmt.Sprintf("%s.unpegs[%d].recipients", field, j), fmt.Errorf("no recipient"))
+			} else if err := validateRecipients(unpeg.Recipients, unpeg.Amount()); err != nil {
+				report(fmt.Sprintf("%s.unpegs[%d].recipients", field, j), err)
+			}
+		}
+	}
+}
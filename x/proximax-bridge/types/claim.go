@@ -3,15 +3,113 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/peggy/x/oracle"
 )
 
+// Claim types processed through the oracle
+const (
+	ClaimTypePeg         = "peg_claim"
+	ClaimTypeNotCosigned = "not_cosigned_claim"
+	ClaimTypeLockFunds   = "lock_funds_claim"
+
+	ClaimTypeConfirmedInvitation = "confirmed_invitation_claim"
+	ClaimTypeConfirmedRemoval    = "confirmed_removal_claim"
+	ClaimTypeConfirmedTransfer   = "confirmed_vault_transfer_claim"
+	ClaimTypeConfirmedUnpegBatch = "confirmed_unpeg_batch_claim"
+)
+
+// ProphecyRecord tracks an oracle prophecy of the bridge until it reaches consensus or expires
+type ProphecyRecord struct {
+	ID              string          `json:"id" yaml:"id"`
+	ClaimType       string          `json:"claim_type" yaml:"claim_type"`
+	Instance        string          `json:"instance" yaml:"instance"`
+	MainchainTxHash MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	CreatedHeight   int64           `json:"created_height" yaml:"created_height"`
+}
+
+// OpenProphecy is a bridge prophecy which has not reached consensus yet, with the state the oracle keeps of it
+// and the claims validators made on it, as exported to the genesis
+type OpenProphecy struct {
+	Record   ProphecyRecord    `json:"record" yaml:"record"`
+	Prophecy oracle.DBProphecy `json:"prophecy" yaml:"prophecy"`
+	Claims   []ValidatorClaim  `json:"claims" yaml:"claims"`
+}
+
+// ValidatorClaim is the claim a validator made on a prophecy,
+// kept until the prophecy is finalized or expires
+type ValidatorClaim struct {
+	ProphecyID       string         `json:"prophecy_id" yaml:"prophecy_id"`
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Claim            string         `json:"claim" yaml:"claim"`
+}
+
+// FaultyClaim is a peg claim which contradicted the consensus reached on the same prophecy
+type FaultyClaim struct {
+	Instance         string          `json:"instance" yaml:"instance"`
+	MainchainTxHash  MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	ValidatorAddress sdk.ValAddress  `json:"validator_address" yaml:"validator_address"`
+	Claim            MsgPegClaim     `json:"claim" yaml:"claim"`
+	Consensus        MsgPegClaim     `json:"consensus" yaml:"consensus"`
+	SlashFraction    sdk.Dec         `json:"slash_fraction" yaml:"slash_fraction"`
+	Height           int64           `json:"height" yaml:"height"`
+}
+
+// GetPegClaimProphecyID returns the id of the oracle prophecy a peg claim is made on
+func GetPegClaimProphecyID(msg MsgPegClaim) string {
+	return fmt.Sprintf("%s,%s,%s,%d", msg.Instance, msg.MainchainTxHash, msg.Amount.String(), msg.Remainning)
+}
+
+// GetNotCosignedClaimProphecyID returns the id of the oracle prophecy a not cosigned claim is made on
+func GetNotCosignedClaimProphecyID(msg MsgNotCosignedClaim) string {
+	return fmt.Sprintf("%s,%s", msg.Instance, msg.TxHash)
+}
+
+// GetLockFundsClaimProphecyID returns the id of the oracle prophecy a lock funds claim is made on,
+// which differs from the one of the not cosigned claim on the same aggregate
+func GetLockFundsClaimProphecyID(msg MsgLockFundsClaim) string {
+	return fmt.Sprintf("%s,%s,%s", msg.Instance, msg.TxHash, ClaimTypeLockFunds)
+}
+
+// ConfirmedClaim is the claim that a multisig transaction the zone is waiting for was confirmed on the mainchain.
+// The zone acts on it once validators reach consensus, so that no single relayer can settle the transaction.
+type ConfirmedClaim struct {
+	ClaimType       string          `json:"claim_type" yaml:"claim_type"`
+	Instance        string          `json:"instance" yaml:"instance"`
+	MainchainTxHash MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// NewConfirmedClaim creates a new ConfirmedClaim object
+func NewConfirmedClaim(claimType, instance string, mainchainTxHash MainchainTxHash) ConfirmedClaim {
+	return ConfirmedClaim{
+		ClaimType:       claimType,
+		Instance:        instance,
+		MainchainTxHash: mainchainTxHash,
+	}
+}
+
+// GetConfirmedClaimProphecyID returns the id of the oracle prophecy a confirmed claim is made on,
+// which differs from the ones of the other claims on the same transaction
+func GetConfirmedClaimProphecyID(claim ConfirmedClaim) string {
+	return fmt.Sprintf("%s,%s,%s", claim.Instance, claim.MainchainTxHash, claim.ClaimType)
+}
+
+// GetProphecyInstance returns the bridge instance a prophecy id was made for
+func GetProphecyInstance(prophecyID string) string {
+	return strings.SplitN(prophecyID, ",", 2)[0]
+}
+
+// CreateOracleClaimFromMsgPegClaim leaves the validator out of the claim content,
+// so that validators relaying the same peg make the same claim.
 func CreateOracleClaimFromMsgPegClaim(cdc *codec.Codec, msg MsgPegClaim) (oracle.Claim, error) {
-	oracleID := fmt.Sprintf("%s,%s,%d", msg.MainchainTxHash, msg.Amount.String(), msg.Remainning)
-	claimBytes, err := json.Marshal(msg)
+	oracleID := GetPegClaimProphecyID(msg)
+	content := msg
+	content.ValidatorAddress = nil
+	claimBytes, err := json.Marshal(content)
 	if err != nil {
 		return oracle.Claim{}, err
 	}
@@ -20,9 +118,13 @@ func CreateOracleClaimFromMsgPegClaim(cdc *codec.Codec, msg MsgPegClaim) (oracle
 	return claim, nil
 }
 
+// CreateOracleClaimFromMsgNotCosignedClaim leaves the validator out of the claim content,
+// so that validators reporting the same transaction make the same claim.
 func CreateOracleClaimFromMsgNotCosignedClaim(cdc *codec.Codec, msg MsgNotCosignedClaim) (oracle.Claim, error) {
-	oracleID := msg.TxHash
-	claimBytes, err := json.Marshal(msg)
+	oracleID := GetNotCosignedClaimProphecyID(msg)
+	content := msg
+	content.Address = nil
+	claimBytes, err := json.Marshal(content)
 	if err != nil {
 		return oracle.Claim{}, err
 	}
@@ -31,6 +133,30 @@ func CreateOracleClaimFromMsgNotCosignedClaim(cdc *codec.Codec, msg MsgNotCosign
 	return claim, nil
 }
 
+// CreateOracleClaimFromMsgLockFundsClaim leaves the validator out of the claim content,
+// so that validators reporting the same outcome make the same claim.
+func CreateOracleClaimFromMsgLockFundsClaim(cdc *codec.Codec, msg MsgLockFundsClaim) (oracle.Claim, error) {
+	oracleID := GetLockFundsClaimProphecyID(msg)
+	content := msg
+	content.Address = nil
+	claimBytes, err := json.Marshal(content)
+	if err != nil {
+		return oracle.Claim{}, err
+	}
+	claim := oracle.NewClaim(oracleID, msg.Address, string(claimBytes))
+	return claim, nil
+}
+
+// CreateOracleClaimFromConfirmedClaim makes the confirmed claim of a validator,
+// validators reporting the same confirmation make the same claim.
+func CreateOracleClaimFromConfirmedClaim(claim ConfirmedClaim, validator sdk.ValAddress) (oracle.Claim, error) {
+	claimBytes, err := json.Marshal(claim)
+	if err != nil {
+		return oracle.Claim{}, err
+	}
+	return oracle.NewClaim(GetConfirmedClaimProphecyID(claim), validator, string(claimBytes)), nil
+}
+
 // CreateOracleClaimFromOracleString converts a JSON string into an OracleClaimContent struct used by this module.
 // In general, it is expected that the oracle module will store claims in this JSON format
 // and so this should be used to convert oracle claims.
@@ -58,3 +184,99 @@ func CreateMsgNotCosignedClaimFromOracleString(oracleClaimString string) (MsgNot
 
 	return oracleClaim, nil
 }
+
+// CreateMsgLockFundsClaimFromOracleString converts the JSON content of an oracle claim back into the lock funds claim
+func CreateMsgLockFundsClaimFromOracleString(oracleClaimString string) (MsgLockFundsClaim, error) {
+	var oracleClaim MsgLockFundsClaim
+
+	bz := []byte(oracleClaimString)
+	if err := json.Unmarshal(bz, &oracleClaim); err != nil {
+		return MsgLockFundsClaim{}, sdkerrors.Wrap(ErrJSONMarshalling, fmt.Sprintf("failed to parse claim: %s", err.Error()))
+	}
+
+	return oracleClaim, nil
+}
+
+// CreateConfirmedClaimFromOracleString converts the JSON content of an oracle claim back into the confirmed claim
+func CreateConfirmedClaimFromOracleString(oracleClaimString string) (ConfirmedClaim, error) {
+	var oracleClaim ConfirmedClaim
+
+	bz := []byte(oracleClaimString)
+	if err := json.Unmarshal(bz, &oracleClaim); err != nil {
+		return ConfirmedClaim{}, sdkerrors.Wrap(ErrJSONMarshalling, fmt.Sprintf("failed to parse claim: %s", err.Error()))
+	}
+
+	return oracleClaim, nil
+}
+
+// validateOpenProphecies reports prophecies of unknown instances or claim types, duplicate ids,
+// oracle state and claims of other prophecies and claims without a validator
+func validateOpenProphecies(prophecies []OpenProphecy, instances []BridgeInstance, report func(string, error)) {
+	ids := make(map[string]int)
+	for i, prophecy := range prophecies {
+		field := fmt.Sprintf("open_prophecies[%d]", i)
+		record := prophecy.Record
+		if record.ID == "" {
+			report(field+".record.id", fmt.Errorf("id cannot be empty"))
+		} else if j, ok := ids[record.ID]; ok {
+			report(field+".record.id", fmt.Errorf("duplicate of open_prophecies[%d]: %s", j, record.ID))
+		} else {
+			ids[record.ID] = i
+		}
+		switch record.ClaimType {
+		case ClaimTypePeg, ClaimTypeNotCosigned, ClaimTypeLockFunds, ClaimTypeConfirmedInvitation, ClaimTypeConfirmedRemoval, ClaimTypeConfirmedTransfer, ClaimTypeConfirmedUnpegBatch:
+		default:
+			report(field+".record.claim_type", fmt.Errorf("unknown claim type: %s", record.ClaimType))
+		}
+		if !hasInstance(instances, record.Instance) {
+			report(field+".record.instance", fmt.Errorf("unknown instance %q", record.Instance))
+		}
+		if err := record.MainchainTxHash.Validate(); err != nil {
+			report(field+".record.mainchain_tx_hash", err)
+		}
+		if record.CreatedHeight < 0 {
+			report(field+".record.created_height", fmt.Errorf("height cannot be negative: %d", record.CreatedHeight))
+		}
+		if prophecy.Prophecy.ID != record.ID {
+			report(field+".prophecy.id", fmt.Errorf("prophecy %s kept for record %s", prophecy.Prophecy.ID, record.ID))
+		} else if _, err := prophecy.Prophecy.DeserializeFromDB(); err != nil {
+			report(field+".prophecy", err)
+		}
+		validators := make(map[string]int)
+		for j, claim := range prophecy.Claims {
+			claimField := fmt.Sprintf("%s.claims[%d]", field, j)
+			if claim.ProphecyID != record.ID {
+				report(claimField+".prophecy_id", fmt.Errorf("claim on prophecy %s kept for record %s", claim.ProphecyID, record.ID))
+			}
+			if claim.ValidatorAddress.Empty() {
+				report(claimField+".validator_address", fmt.Errorf("validator must be set"))
+			} else if k, ok := validators[claim.ValidatorAddress.String()]; ok {
+				report(claimField+".validator_address", fmt.Errorf("duplicate of %s.claims[%d]: %s", field, k, claim.ValidatorAddress))
+			} else {
+				validators[claim.ValidatorAddress.String()] = j
+			}
+		}
+	}
+}
+
+// validateFaultyClaims reports faulty claims of unknown instances, without a validator or with an invalid slash fraction
+func validateFaultyClaims(claims []FaultyClaim, instances []BridgeInstance, report func(string, error)) {
+	for i, claim := range claims {
+		field := fmt.Sprintf("faulty_claims[%d]", i)
+		if !hasInstance(instances, claim.Instance) {
+			report(field+".instance", fmt.Errorf("unknown instance %q", claim.Instance))
+		}
+		if err := claim.MainchainTxHash.Validate(); err != nil {
+			report(field+".mainchain_tx_hash", err)
+		}
+		if claim.ValidatorAddress.Empty() {
+			report(field+".validator_address", fmt.Errorf("validator must be set"))
+		}
+		if err := validateFaultyClaimSlashFraction(claim.SlashFraction); err != nil {
+			report(field+".slash_fraction", err)
+		}
+		if claim.Height < 0 {
+			report(field+".height", fmt.Errorf("height cannot be negative: %d", claim.Height))
+		}
+	}
+}
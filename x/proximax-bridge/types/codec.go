@@ -16,6 +16,17 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgPendingRequestInvitation{}, "proximaxbridge/MsgPendingRequestInvitation", nil)
 	cdc.RegisterConcrete(MsgConfirmedInvitation{}, "proximaxbridge/MsgConfirmedInvitation", nil)
 	cdc.RegisterConcrete(MsgNotCosignedClaim{}, "proximaxbridge/MsgNotCosignedClaim", nil)
+	cdc.RegisterConcrete(MsgRequestRemoval{}, "proximaxbridge/MsgRequestRemoval", nil)
+	cdc.RegisterConcrete(MsgPendingRequestRemoval{}, "proximaxbridge/MsgPendingRequestRemoval", nil)
+	cdc.RegisterConcrete(MsgConfirmedRemoval{}, "proximaxbridge/MsgConfirmedRemoval", nil)
+	cdc.RegisterConcrete(MsgRequestVaultTransfer{}, "proximaxbridge/MsgRequestVaultTransfer", nil)
+	cdc.RegisterConcrete(MsgRecordVaultTransfer{}, "proximaxbridge/MsgRecordVaultTransfer", nil)
+	cdc.RegisterConcrete(MsgConfirmedVaultTransfer{}, "proximaxbridge/MsgConfirmedVaultTransfer", nil)
+	cdc.RegisterConcrete(MsgLockFundsClaim{}, "proximaxbridge/MsgLockFundsClaim", nil)
+	cdc.RegisterConcrete(MsgCancelUnpeg{}, "proximaxbridge/MsgCancelUnpeg", nil)
+	cdc.RegisterConcrete(MsgVetoUnpeg{}, "proximaxbridge/MsgVetoUnpeg", nil)
+	cdc.RegisterConcrete(MsgRecordUnpegBatch{}, "proximaxbridge/MsgRecordUnpegBatch", nil)
+	cdc.RegisterConcrete(MsgConfirmedUnpegBatch{}, "proximaxbridge/MsgConfirmedUnpegBatch", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Reasons for a change of the cosigner set
+const (
+	CosignerSetChangeGenesis    = "genesis"
+	CosignerSetChangeInvitation = "invitation"
+	CosignerSetChangeRemoval    = "removal"
+	CosignerSetChangeGovernance = "governance"
+)
+
+// CosignerSetChange is an entry of the append-only log of cosigner set changes of a bridge instance.
+// Cosigners is the set resulting from the change.
+type CosignerSetChange struct {
+	Instance        string          `json:"instance" yaml:"instance"`
+	ID              uint64          `json:"id" yaml:"id"`
+	Height          int64           `json:"height" yaml:"height"`
+	MainchainTxHash MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Reason          string          `json:"reason" yaml:"reason"`
+	Cosigners       []Cosigner      `json:"cosigners" yaml:"cosigners"`
+}
+
+// CosignerInvitation invites a validator to join the multisig of a vault, planned by the rotation or by governance.
+// The public key is fixed by governance or by the validator requesting the invitation, the multisig modification
+// announced on the mainchain has to match it.
+type CosignerInvitation struct {
+	Instance           string             `json:"instance" yaml:"instance"`
+	Vault              string             `json:"vault" yaml:"vault"`
+	ValidatorAddress   sdk.ValAddress     `json:"validator_address" yaml:"validator_address"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+	Reason             string             `json:"reason" yaml:"reason"`
+}
+
+// CosignerRemoval is the removal of a cosigner from the multisig of its vault, requested by an authorized
+// validator or scheduled by the zone. The multisig modification announced on the mainchain has to match it.
+// A scheduled removal names the active cosigner asked to request it, and is handed over to another one
+// when it is not announced by the expiry height.
+type CosignerRemoval struct {
+	Instance                 string             `json:"instance" yaml:"instance"`
+	Vault                    string             `json:"vault" yaml:"vault"`
+	ValidatorAddress         sdk.ValAddress     `json:"validator_address" yaml:"validator_address"`
+	MainchainPublicKey       MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+	MainchainMultisigAddress MainchainAddress   `json:"mainchain_multisig_address" yaml:"mainchain_multisig_address"`
+	Reason                   string             `json:"reason" yaml:"reason"`
+	Requester                sdk.ValAddress     `json:"requester" yaml:"requester"`
+	ExpiryHeight             int64              `json:"expiry_height" yaml:"expiry_height"`
+}
+
+// IsScheduled returns true when the zone scheduled the removal and is waiting for it to be announced
+func (r CosignerRemoval) IsScheduled() bool {
+	return !r.Requester.Empty() && r.ExpiryHeight > 0
+}
+
+// PendingInviteRequest is the multisig modification inviting a cosigner, announced on the mainchain and waiting to be confirmed
+type PendingInviteRequest struct {
+	Instance           string             `json:"instance" yaml:"instance"`
+	Vault              string             `json:"vault" yaml:"vault"`
+	Address            sdk.ValAddress     `json:"address" yaml:"address"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+	MainchainTxHash    MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// PendingRemovalRequest is the multisig modification removing a cosigner, announced on the mainchain and waiting to be confirmed
+type PendingRemovalRequest struct {
+	Instance           string             `json:"instance" yaml:"instance"`
+	Address            sdk.ValAddress     `json:"address" yaml:"address"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+	MainchainTxHash    MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// InactiveCosigner marks the cosigner of a validator which left the bonded set at the height, until it is removed or bonded again
+type InactiveCosigner struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Height           int64          `json:"height" yaml:"height"`
+}
+
+// EligibleCosigners is the cosigner set of an instance last computed from the stake distribution by the rotation
+type EligibleCosigners struct {
+	Instance   string           `json:"instance" yaml:"instance"`
+	Validators []sdk.ValAddress `json:"validators" yaml:"validators"`
+}
+
+// validateEligibleCosigners reports sets of unknown instances, several sets of an instance and empty validators
+func validateEligibleCosigners(sets []EligibleCosigners, instances []BridgeInstance, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, set := range sets {
+		field := fmt.Sprintf("eligible_cosigners[%d]", i)
+		if !hasInstance(instances, set.Instance) {
+			report(field+".instance", fmt.Errorf("unknown instance %q", set.Instance))
+		} else if j, ok := seen[set.Instance]; ok {
+			report(field+".instance", fmt.Errorf("duplicate of eligible_cosigners[%d]: %s", j, set.Instance))
+		} else {
+			seen[set.Instance] = i
+		}
+		for j, validator := range set.Validators {
+			if validator.Empty() {
+				report(fmt.Sprintf("%s.validators[%d]", field, j), fmt.Errorf("validator must be set"))
+			}
+		}
+	}
+}
+
+// validateInactiveCosigners reports empty and duplicate validators and negative heights
+func validateInactiveCosigners(inactive []InactiveCosigner, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, cosigner := range inactive {
+		field := fmt.Sprintf("inactive_cosigners[%d]", i)
+		if cosigner.ValidatorAddress.Empty() {
+			report(field+".validator_address", fmt.Errorf("validator must be set"))
+		} else if j, ok := seen[cosigner.ValidatorAddress.String()]; ok {
+			report(field+".validator_address", fmt.Errorf("duplicate of inactive_cosigners[%d]: %s", j, cosigner.ValidatorAddress))
+		} else {
+			seen[cosigner.ValidatorAddress.String()] = i
+		}
+		if cosigner.Height < 0 {
+			report(field+".height", fmt.Errorf("height cannot be negative: %d", cosigner.Height))
+		}
+	}
+}
+
+// validateCosignerInvitations reports invitations to unknown vaults, malformed ones and several invitations of a validator to an instance
+func validateCosignerInvitations(invitations []CosignerInvitation, instances []BridgeInstance, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, invitation := range invitations {
+		field := fmt.Sprintf("cosigner_invitations[%d]", i)
+		if err := validateVaultOf(invitation.Instance, invitation.Vault, instances); err != nil {
+			report(field+".vault", err)
+		}
+		if invitation.ValidatorAddress.Empty() {
+			report(field+".validator_address", fmt.Errorf("validator must be set"))
+			continue
+		}
+		if !invitation.MainchainPublicKey.Empty() {
+			if err := invitation.MainchainPublicKey.Validate(); err != nil {
+				report(field+".mainchain_public_key", err)
+			}
+		}
+		key := invitation.Instance + "/" + invitation.ValidatorAddress.String()
+		if j, ok := seen[key]; ok {
+			report(field, fmt.Errorf("duplicate of cosigner_invitations[%d]: %s", j, key))
+		} else {
+			seen[key] = i
+		}
+	}
+}
+
+// validateCosignerRemovals reports removals from unknown vaults, malformed ones and several removals of a cosigner
+func validateCosignerRemovals(removals []CosignerRemoval, instances []BridgeInstance, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, removal := range removals {
+		field := fmt.Sprintf("cosigner_removals[%d]", i)
+		if err := validateVaultOf(removal.Instance, removal.Vault, instances); err != nil {
+			report(field+".vault", err)
+		}
+		if removal.ValidatorAddress.Empty() {
+			report(field+".validator_address", fmt.Errorf("validator must be set"))
+		}
+		if removal.ExpiryHeight < 0 {
+			report(field+".expiry_height", fmt.Errorf("expiry height cannot be negative: %d", removal.ExpiryHeight))
+		}
+		if err := removal.MainchainPublicKey.Validate(); err != nil {
+			report(field+".mainchain_public_key", err)
+			continue
+		}
+		key := removal.Instance + "/" + removal.MainchainPublicKey.String()
+		if j, ok := seen[key]; ok {
+			report(field, fmt.Errorf("duplicate of cosigner_removals[%d]: %s", j, key))
+		} else {
+			seen[key] = i
+		}
+	}
+}
+
+// validatePendingRequests reports pending invitations and removals of unknown instances, malformed ones
+// and several of them announced in a mainchain transaction
+func validatePendingRequests(invites []PendingInviteRequest, removals []PendingRemovalRequest, instances []BridgeInstance, report func(string, error)) {
+	validate := func(kind string, i int, instance string, address sdk.ValAddress, publicKey MainchainPublicKey, txHash MainchainTxHash, seen map[string]int) {
+		field := fmt.Sprintf("%s[%d]", kind, i)
+		if !hasInstance(instances, instance) {
+			report(field+".instance", fmt.Errorf("unknown instance %q", instance))
+		}
+		if address.Empty() {
+			report(field+".address", fmt.Errorf("validator must be set"))
+		}
+		if err := publicKey.Validate(); err != nil {
+			report(field+".mainchain_public_key", err)
+		}
+		if err := txHash.Validate(); err != nil {
+			report(field+".mainchain_tx_hash", err)
+			return
+		}
+		key := instance + "/" + txHash.String()
+		if j, ok := seen[key]; ok {
+			report(field+".mainchain_tx_hash", fmt.Errorf("duplicate of %s[%d]: %s", kind, j, key))
+		} else {
+			seen[key] = i
+		}
+	}
+
+	seen := make(map[string]int)
+	for i, request := range invites {
+		if err := validateVaultOf(request.Instance, request.Vault, instances); err != nil {
+			report(fmt.Sprintf("pending_invite_requests[%d].vault", i), err)
+		}
+		validate("pending_invite_requests", i, request.Instance, request.Address, request.MainchainPublicKey, request.MainchainTxHash, seen)
+	}
+	seen = make(map[string]int)
+	for i, request := range removals {
+		validate("pending_removal_requests", i, request.Instance, request.Address, request.MainchainPublicKey, request.MainchainTxHash, seen)
+	}
+}
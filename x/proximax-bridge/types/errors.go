@@ -11,4 +11,26 @@ var (
 	ErrInvalidMainchainAddress = sdkerrors.Register(ModuleName, 2, "invalid mainchain address")
 	ErrJSONMarshalling         = sdkerrors.Register(ModuleName, 3, "error marshalling JSON for this claim")
 	ErrInvalidClaimType        = sdkerrors.Register(ModuleName, 4, "invalid claim type provided")
+	ErrInvalidMainchainPubKey  = sdkerrors.Register(ModuleName, 5, "invalid mainchain public key")
+	ErrCosignerAlreadyExists   = sdkerrors.Register(ModuleName, 6, "cosigner already exists")
+	ErrCosignerNotFound        = sdkerrors.Register(ModuleName, 7, "cosigner not found")
+	ErrNoActiveCosigner        = sdkerrors.Register(ModuleName, 8, "no active cosigner")
+	ErrCosignerSetNotFound     = sdkerrors.Register(ModuleName, 9, "cosigner set not found")
+	ErrInvalidInstance         = sdkerrors.Register(ModuleName, 10, "invalid bridge instance")
+	ErrUnmappedDenom           = sdkerrors.Register(ModuleName, 11, "denom not pegged by the bridge instance")
+	ErrInvalidVault            = sdkerrors.Register(ModuleName, 12, "invalid vault")
+	ErrInsufficientVault       = sdkerrors.Register(ModuleName, 13, "insufficient vault balance")
+	ErrAmountBelowFee          = sdkerrors.Register(ModuleName, 14, "amount does not cover the bridge fee")
+	ErrInvalidLockFundsOutcome = sdkerrors.Register(ModuleName, 15, "invalid lock funds outcome")
+	ErrTransferLimit           = sdkerrors.Register(ModuleName, 16, "amount out of the transfer limits")
+	ErrVolumeCapExceeded       = sdkerrors.Register(ModuleName, 17, "volume cap exceeded")
+	ErrQueuedUnpegNotFound     = sdkerrors.Register(ModuleName, 18, "queued unpeg not found")
+	ErrNotGuardian             = sdkerrors.Register(ModuleName, 19, "not a guardian")
+	ErrAddressNotPermitted     = sdkerrors.Register(ModuleName, 20, "address not permitted to bridge")
+	ErrUnpegBatchNotFound      = sdkerrors.Register(ModuleName, 21, "unpeg batch not found")
+	ErrInvitationNotFound      = sdkerrors.Register(ModuleName, 22, "cosigner invitation not found")
+	ErrRemovalNotFound         = sdkerrors.Register(ModuleName, 23, "cosigner removal not found")
+	ErrVaultTransferNotFound   = sdkerrors.Register(ModuleName, 24, "vault transfer not found")
+	ErrPendingUnpegNotFound    = sdkerrors.Register(ModuleName, 25, "pending unpeg not found")
+	ErrMultisigTxNotFound      = sdkerrors.Register(ModuleName, 26, "multisig transaction not found")
 )
@@ -7,19 +7,73 @@ const (
 	EventTypePeg            = "peg"
 	EventTypeUnpeg          = "unpeg"
 	EventTypeInvitation     = "request_invitation"
+	EventTypeRemoval        = "request_removal"
+	EventTypeProphecyExpiry = "prophecy_expiry"
+	EventTypeFaultyClaim    = "faulty_claim"
 
+	EventTypeChangeMultisigAddress = "change_multisig_address"
+	EventTypeAddCosigner           = "add_cosigner"
+	EventTypeRemoveCosigner        = "remove_cosigner"
+	EventTypeCosignerInvitation    = "cosigner_invitation"
+	EventTypeCosignerRemoval       = "cosigner_removal"
+	EventTypeCosignerInactive      = "cosigner_inactive"
+	EventTypeRebalanceMultisig     = "rebalance_multisig"
+	EventTypeVaultTransfer         = "vault_transfer"
+	EventTypeVaultTransferResult   = "vault_transfer_result"
+	EventTypeSweep                 = "sweep"
+	EventTypeSweepExpiry           = "sweep_expiry"
+	EventTypeBridgeFee             = "bridge_fee"
+	EventTypeFeeDistribution       = "fee_distribution"
+	EventTypeLockFunds             = "lock_funds"
+	EventTypeUnpegQueued           = "unpeg_queued"
+	EventTypePegQueued             = "peg_queued"
+	EventTypePegReleased           = "peg_released"
+	EventTypeUnpegRefund           = "unpeg_refund"
+	EventTypeUnpegTimeLocked       = "unpeg_time_locked"
+	EventTypeUnpegVeto             = "unpeg_veto"
+	EventTypeReimbursement         = "lock_funds_reimbursement"
+	EventTypeUnpegBatched          = "unpeg_batched"
+	EventTypeUnpegBatch            = "unpeg_batch"
+	EventTypeUnpegBatchStatus      = "unpeg_batch_status"
+
+	AttributeKeyInstance        = "instance"
+	AttributeKeyVault           = "vault"
+	AttributeKeyFromVault       = "from_vault"
+	AttributeKeyToVault         = "to_vault"
 	AttributeKeyMainchainTxHash = "mainchain_tx_hash"
 	AttributeKeyCosmosReceiver  = "cosmos_receiver"
 	AttributeKeyAmount          = "amount"
 	AttributeKeyStatus          = "status"
 	AttributeKeyClaimType       = "claim_type"
+	AttributeKeyProphecyID      = "prophecy_id"
+	AttributeKeyValidator       = "validator"
+	AttributeKeyRequester       = "requester"
+	AttributeKeyMinApproval     = "min_approval"
+	AttributeKeyMinRemoval      = "min_removal"
+	AttributeKeySweep           = "sweep"
+	AttributeKeyHeight          = "height"
+	AttributeKeyCold            = "cold"
+	AttributeKeyDirection       = "direction"
+	AttributeKeyFee             = "fee"
+	AttributeKeyPoints          = "points"
+	AttributeKeyOutcome         = "outcome"
+	AttributeKeyQueueID         = "queue_id"
+	AttributeKeyReason          = "reason"
+	AttributeKeyUnlockHeight    = "unlock_height"
+	AttributeKeyExpiryHeight    = "expiry_height"
+	AttributeKeyGuardian        = "guardian"
+	AttributeKeyRecipients      = "recipients"
+	AttributeKeyBatchID         = "batch_id"
+	AttributeKeyUnpegs          = "unpegs"
 
 	AttributeKeyMultisigCustodyAddress = "multisig_custody_address"
 	AttributeKeyMultisigAccountAddress = "multisig_address"
+	AttributeKeyRecipientAddress       = "recipient_address"
 	AttributeKeyCosmosSender           = "cosmos_sender"
 	AttributeKeyCosmosAccount          = "cosmos_account"
 	AttributeKeyMainchainAddress       = "mainchain_address"
 	AttributeKeyNewCosignerPublicKey   = "new_cosigner_public_key"
+	AttributeKeyCosignerPublicKey      = "cosigner_public_key"
 
 	AttributeKeyConsumed = "consumed"
 
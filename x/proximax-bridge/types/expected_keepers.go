@@ -3,6 +3,7 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/peggy/x/oracle"
 
 	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
@@ -26,17 +27,29 @@ type AccountKeeper interface {
 type SupplyKeeper interface {
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+	GetModuleAccount(ctx sdk.Context, moduleName string) supplyexported.ModuleAccountI
 	MintCoins(ctx sdk.Context, name string, amt sdk.Coins) error
 	BurnCoins(ctx sdk.Context, name string, amt sdk.Coins) error
 	SetModuleAccount(sdk.Context, supplyexported.ModuleAccountI)
+	GetSupply(ctx sdk.Context) supplyexported.SupplyI
 }
 
 type SlashingKeeper interface {
 	Slash(ctx sdk.Context, consAddr sdk.ConsAddress, fraction sdk.Dec, power int64, distributionHeight int64)
 }
 
+// StakingKeeper defines the expected staking keeper, as required by the oracle
+type StakingKeeper interface {
+	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator staking.Validator, found bool)
+	GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) (power int64)
+	GetLastTotalPower(ctx sdk.Context) (power sdk.Int)
+	GetBondedValidatorsByPower(ctx sdk.Context) []staking.Validator
+}
+
 // OracleKeeper defines the expected oracle keeper
 type OracleKeeper interface {
 	ProcessClaim(ctx sdk.Context, claim oracle.Claim) (oracle.Status, error)
 	GetProphecy(ctx sdk.Context, id string) (oracle.Prophecy, bool)
+	DeleteProphecy(ctx sdk.Context, id string)
 }
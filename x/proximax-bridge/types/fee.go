@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// FeePoolName is the name of the module account holding the bridge fees collected and not paid out yet
+	FeePoolName = ModuleName + "_fee_pool"
+
+	// FeeDirectionPeg is the direction of pegs, from the mainchain to the zone
+	FeeDirectionPeg = "peg"
+	// FeeDirectionUnpeg is the direction of unpegs, from the zone to the mainchain
+	FeeDirectionUnpeg = "unpeg"
+
+	// MaxFeeBasisPoints is the basis points of the whole amount
+	MaxFeeBasisPoints = 10000
+)
+
+// FeeRate is a flat amount plus basis points of the amount bridged
+type FeeRate struct {
+	Flat        sdk.Int `json:"flat" yaml:"flat"`
+	BasisPoints uint32  `json:"basis_points" yaml:"basis_points"`
+}
+
+// DenomFee is the bridge fee of a denom in both directions. Denoms without a fee are bridged for free.
+type DenomFee struct {
+	Denom string  `json:"denom" yaml:"denom"`
+	Peg   FeeRate `json:"peg" yaml:"peg"`
+	Unpeg FeeRate `json:"unpeg" yaml:"unpeg"`
+}
+
+// NewFeeRate creates a new FeeRate object
+func NewFeeRate(flat sdk.Int, basisPoints uint32) FeeRate {
+	return FeeRate{
+		Flat:        flat,
+		BasisPoints: basisPoints,
+	}
+}
+
+// FeeOn returns the fee on an amount, which is never more than the amount
+func (r FeeRate) FeeOn(amount sdk.Int) sdk.Int {
+	fee := r.Flat.Add(amount.MulRaw(int64(r.BasisPoints)).QuoRaw(MaxFeeBasisPoints))
+	if fee.GT(amount) {
+		return amount
+	}
+	return fee
+}
+
+// Validate checks that the flat amount is not negative and the basis points do not exceed the whole amount
+func (r FeeRate) Validate() error {
+	if r.Flat == (sdk.Int{}) {
+		return fmt.Errorf("flat fee must be set")
+	}
+	if r.Flat.IsNegative() {
+		return fmt.Errorf("flat fee cannot be negative: %s", r.Flat)
+	}
+	if r.BasisPoints > MaxFeeBasisPoints {
+		return fmt.Errorf("fee basis points too large: %d", r.BasisPoints)
+	}
+	return nil
+}
+
+// RateOf returns the fee rate of the direction
+func (f DenomFee) RateOf(direction string) FeeRate {
+	if direction == FeeDirectionPeg {
+		return f.Peg
+	}
+	return f.Unpeg
+}
+
+// ValidateFeeDirection checks that the direction is peg or unpeg
+func ValidateFeeDirection(direction string) error {
+	switch direction {
+	case FeeDirectionPeg, FeeDirectionUnpeg:
+		return nil
+	default:
+		return fmt.Errorf("invalid fee direction: %s", direction)
+	}
+}
+
+// GetFee returns the fee of the denom, if it has one
+func (p Params) GetFee(denom string) (DenomFee, bool) {
+	for _, fee := range p.Fees {
+		if fee.Denom == denom {
+			return fee, true
+		}
+	}
+	return DenomFee{}, false
+}
+
+// ComputeFee returns the bridge fee on an amount bridged in the direction
+func (p Params) ComputeFee(direction string, amount sdk.Coins) sdk.Coins {
+	fees := sdk.Coins{}
+	for _, coin := range amount {
+		fee, found := p.GetFee(coin.Denom)
+		if !found {
+			continue
+		}
+		if charged := fee.RateOf(direction).FeeOn(coin.Amount); charged.IsPositive() {
+			fees = append(fees, sdk.NewCoin(coin.Denom, charged))
+		}
+	}
+	return fees
+}
+
+func validateFees(i interface{}) error {
+	v, ok := i.([]DenomFee)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	denoms := make(map[string]bool)
+	for _, fee := range v {
+		if err := sdk.ValidateDenom(fee.Denom); err != nil {
+			return err
+		}
+		if denoms[fee.Denom] {
+			return fmt.Errorf("duplicate fee of %s", fee.Denom)
+		}
+		denoms[fee.Denom] = true
+		if err := fee.Peg.Validate(); err != nil {
+			return fmt.Errorf("peg fee of %s: %w", fee.Denom, err)
+		}
+		if err := fee.Unpeg.Validate(); err != nil {
+			return fmt.Errorf("unpeg fee of %s: %w", fee.Denom, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// AddressFilterModeBlocklist lets every address bridge but the listed ones
+	AddressFilterModeBlocklist = "blocklist"
+	// AddressFilterModeAllowlist lets only the listed addresses bridge
+	AddressFilterModeAllowlist = "allowlist"
+)
+
+// AddressFilter is the list of the Cosmos and ProximaX addresses which are blocked from pegging and unpegging,
+// or in the allowlist mode the only ones which are not
+type AddressFilter struct {
+	Mode               string             `json:"mode" yaml:"mode"`
+	CosmosAddresses    []sdk.AccAddress   `json:"cosmos_addresses" yaml:"cosmos_addresses"`
+	MainchainAddresses []MainchainAddress `json:"mainchain_addresses" yaml:"mainchain_addresses"`
+}
+
+// NewAddressFilter creates a new AddressFilter object
+func NewAddressFilter(mode string, cosmosAddresses []sdk.AccAddress, mainchainAddresses []MainchainAddress) AddressFilter {
+	return AddressFilter{
+		Mode:               mode,
+		CosmosAddresses:    cosmosAddresses,
+		MainchainAddresses: mainchainAddresses,
+	}
+}
+
+// DefaultAddressFilter blocks no address
+func DefaultAddressFilter() AddressFilter {
+	return NewAddressFilter(AddressFilterModeBlocklist, []sdk.AccAddress{}, []MainchainAddress{})
+}
+
+// PermitsCosmos tells whether a Cosmos address may peg and unpeg
+func (f AddressFilter) PermitsCosmos(address sdk.AccAddress) bool {
+	listed := false
+	for _, entry := range f.CosmosAddresses {
+		if entry.Equals(address) {
+			listed = true
+			break
+		}
+	}
+	return listed == (f.Mode == AddressFilterModeAllowlist)
+}
+
+// PermitsMainchain tells whether a ProximaX address may peg and unpeg
+func (f AddressFilter) PermitsMainchain(address MainchainAddress) bool {
+	listed := false
+	for _, entry := range f.MainchainAddresses {
+		if entry == address {
+			listed = true
+			break
+		}
+	}
+	return listed == (f.Mode == AddressFilterModeAllowlist)
+}
+
+// Check checks that both the Cosmos and the ProximaX address of a transfer may bridge
+func (f AddressFilter) Check(address sdk.AccAddress, mainchainAddress MainchainAddress) error {
+	if !f.PermitsCosmos(address) {
+		return fmt.Errorf("%s is not permitted to bridge", address)
+	}
+	if !f.PermitsMainchain(mainchainAddress) {
+		return fmt.Errorf("%s is not permitted to bridge", mainchainAddress)
+	}
+	return nil
+}
+
+// CheckPegClaim checks a peg claim against the transfer limits and the address filter
+func (p Params) CheckPegClaim(msg MsgPegClaim) error {
+	if err := p.CheckTransferAmount(FeeDirectionPeg, msg.Amount); err != nil {
+		return sdkerrors.Wrap(ErrTransferLimit, err.Error())
+	}
+	if err := p.AddressFilter.Check(msg.Address, msg.MainchainSender); err != nil {
+		return sdkerrors.Wrap(ErrAddressNotPermitted, err.Error())
+	}
+	return nil
+}
+
+// CheckUnpeg checks an unpeg against the transfer limits and the address filter
+func (p Params) CheckUnpeg(msg MsgUnpeg) error {
+	if err := p.CheckTransferAmount(FeeDirectionUnpeg, msg.Amount); err != nil {
+		return sdkerrors.Wrap(ErrTransferLimit, err.Error())
+	}
+	for _, recipient := range msg.GetRecipients() {
+		if err := p.AddressFilter.Check(msg.Address, recipient.MainchainAddress); err != nil {
+			return sdkerrors.Wrap(ErrAddressNotPermitted, err.Error())
+		}
+	}
+	return nil
+}
+
+func validateAddressFilter(i interface{}) error {
+	v, ok := i.(AddressFilter)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.Mode != AddressFilterModeBlocklist && v.Mode != AddressFilterModeAllowlist {
+		return fmt.Errorf("address filter mode must be %s or %s: %s", AddressFilterModeBlocklist, AddressFilterModeAllowlist, v.Mode)
+	}
+	cosmosAddresses := make(map[string]bool)
+	for _, address := range v.CosmosAddresses {
+		if address.Empty() {
+			return fmt.Errorf("cosmos address cannot be empty")
+		}
+		if cosmosAddresses[address.String()] {
+			return fmt.Errorf("duplicate cosmos address %s", address)
+		}
+		cosmosAddresses[address.String()] = true
+	}
+	mainchainAddresses := make(map[MainchainAddress]bool)
+	for _, address := range v.MainchainAddresses {
+		if err := address.Validate(); err != nil {
+			return err
+		}
+		if mainchainAddresses[address] {
+			return fmt.Errorf("duplicate mainchain address %s", address)
+		}
+		mainchainAddresses[address] = true
+	}
+	return nil
+}
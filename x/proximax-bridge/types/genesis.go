@@ -1,23 +1,137 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 // GenesisState - all proximax-bridge state that must be provided at genesis
 type GenesisState struct {
 	// TODO: Fill out what is needed by the module for genesis
-	MainchainMultisigAddress string     `json:"mainchain_multisig_address"`
-	Cosigners                []Cosigner `json:"cosigners"`
+	Instances                []BridgeInstance `json:"instances"`
+	ConsensusNeeded          ConsensusNeeded  `json:"consensus_needed"`
+	ClaimWeighting           string           `json:"claim_weighting"`
+	ProphecyExpiry           int64            `json:"prophecy_expiry"`
+	FaultyClaimSlashFraction sdk.Dec          `json:"faulty_claim_slash_fraction"`
+	MultisigApproval         MultisigApproval `json:"multisig_approval"`
+	ColdMultisigApproval     MultisigApproval `json:"cold_multisig_approval"`
+	HotVaultLimits           HotVaultLimits   `json:"hot_vault_limits"`
+	Fees                     []DenomFee       `json:"fees"`
+	FeeDistribution          FeeDistribution  `json:"fee_distribution"`
+	LockFundsCost            sdk.Coins        `json:"lock_funds_cost"`
+	Limits                   []DenomLimit     `json:"limits"`
+	VolumeWindow             time.Duration    `json:"volume_window"`
+	TimeLock                 TimeLock         `json:"time_lock"`
+	AddressFilter            AddressFilter    `json:"address_filter"`
+	UnpegBatchWindow         int64            `json:"unpeg_batch_window"`
+
+	CosignerSetChanges []CosignerSetChange `json:"cosigner_set_changes"`
+	VaultBalances      []VaultBalance      `json:"vault_balances"`
+	VaultTransfers     []VaultTransfer     `json:"vault_transfers"`
+	SweepRequests      []SweepRequest      `json:"sweep_requests"`
+	RewardPoints       []RewardPoints      `json:"reward_points"`
+
+	LockFundsReimbursements []LockFundsReimbursement `json:"lock_funds_reimbursements"`
+	VolumeEntries           []VolumeEntry            `json:"volume_entries"`
+	QueuedUnpegs            []QueuedUnpeg            `json:"queued_unpegs"`
+	TimeLockedUnpegs        []TimeLockedUnpeg        `json:"time_locked_unpegs"`
+	UnpegBatches            []UnpegBatch             `json:"unpeg_batches"`
+	QueuedPegs              []QueuedPeg              `json:"queued_pegs"`
+
+	EligibleCosigners      []EligibleCosigners     `json:"eligible_cosigners"`
+	InactiveCosigners      []InactiveCosigner      `json:"inactive_cosigners"`
+	CosignerInvitations    []CosignerInvitation    `json:"cosigner_invitations"`
+	CosignerRemovals       []CosignerRemoval       `json:"cosigner_removals"`
+	PendingInviteRequests  []PendingInviteRequest  `json:"pending_invite_requests"`
+	PendingRemovalRequests []PendingRemovalRequest `json:"pending_removal_requests"`
+	PendingUnpegs          []PendingUnpeg          `json:"pending_unpegs"`
+	OpenProphecies         []OpenProphecy          `json:"open_prophecies"`
+	FaultyClaims           []FaultyClaim           `json:"faulty_claims"`
 }
 
 // NewGenesisState creates a new GenesisState object
 func NewGenesisState(
 	/* TODO: Fill out with what is needed for genesis state*/
-	mainchainMultisigAddress string,
-	cosigners []Cosigner,
+	instances []BridgeInstance,
+	consensusNeeded ConsensusNeeded,
+	claimWeighting string,
+	prophecyExpiry int64,
+	faultyClaimSlashFraction sdk.Dec,
+	multisigApproval MultisigApproval,
+	coldMultisigApproval MultisigApproval,
+	hotVaultLimits HotVaultLimits,
+	fees []DenomFee,
+	feeDistribution FeeDistribution,
+	lockFundsCost sdk.Coins,
+	limits []DenomLimit,
+	volumeWindow time.Duration,
+	timeLock TimeLock,
+	addressFilter AddressFilter,
+	unpegBatchWindow int64,
+	cosignerSetChanges []CosignerSetChange,
+	vaultBalances []VaultBalance,
+	vaultTransfers []VaultTransfer,
+	sweepRequests []SweepRequest,
+	rewardPoints []RewardPoints,
+	lockFundsReimbursements []LockFundsReimbursement,
+	volumeEntries []VolumeEntry,
+	queuedUnpegs []QueuedUnpeg,
+	timeLockedUnpegs []TimeLockedUnpeg,
+	unpegBatches []UnpegBatch,
+	queuedPegs []QueuedPeg,
+	eligibleCosigners []EligibleCosigners,
+	inactiveCosigners []InactiveCosigner,
+	cosignerInvitations []CosignerInvitation,
+	cosignerRemovals []CosignerRemoval,
+	pendingInviteRequests []PendingInviteRequest,
+	pendingRemovalRequests []PendingRemovalRequest,
+	pendingUnpegs []PendingUnpeg,
+	openProphecies []OpenProphecy,
+	faultyClaims []FaultyClaim,
 ) GenesisState {
 
 	return GenesisState{
 		// TODO: Fill out according to your genesis state
-		MainchainMultisigAddress: mainchainMultisigAddress,
-		Cosigners:                cosigners,
+		Instances:                instances,
+		ConsensusNeeded:          consensusNeeded,
+		ClaimWeighting:           claimWeighting,
+		ProphecyExpiry:           prophecyExpiry,
+		FaultyClaimSlashFraction: faultyClaimSlashFraction,
+		MultisigApproval:         multisigApproval,
+		ColdMultisigApproval:     coldMultisigApproval,
+		HotVaultLimits:           hotVaultLimits,
+		Fees:                     fees,
+		FeeDistribution:          feeDistribution,
+		LockFundsCost:            lockFundsCost,
+		Limits:                   limits,
+		VolumeWindow:             volumeWindow,
+		TimeLock:                 timeLock,
+		AddressFilter:            addressFilter,
+		UnpegBatchWindow:         unpegBatchWindow,
+		CosignerSetChanges:       cosignerSetChanges,
+		VaultBalances:            vaultBalances,
+		VaultTransfers:           vaultTransfers,
+		SweepRequests:            sweepRequests,
+		RewardPoints:             rewardPoints,
+		LockFundsReimbursements:  lockFundsReimbursements,
+		VolumeEntries:            volumeEntries,
+		QueuedUnpegs:             queuedUnpegs,
+		TimeLockedUnpegs:         timeLockedUnpegs,
+		UnpegBatches:             unpegBatches,
+		QueuedPegs:               queuedPegs,
+		EligibleCosigners:        eligibleCosigners,
+		InactiveCosigners:        inactiveCosigners,
+		CosignerInvitations:      cosignerInvitations,
+		CosignerRemovals:         cosignerRemovals,
+		PendingInviteRequests:    pendingInviteRequests,
+		PendingRemovalRequests:   pendingRemovalRequests,
+		PendingUnpegs:            pendingUnpegs,
+		OpenProphecies:           openProphecies,
+		FaultyClaims:             faultyClaims,
 	}
 }
 
@@ -25,13 +139,407 @@ func NewGenesisState(
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		// TODO: Fill out according to your genesis state, these values will be initialized but empty
-		MainchainMultisigAddress: "",
-		Cosigners:                []Cosigner{},
+		Instances:                []BridgeInstance{DefaultBridgeInstance()},
+		ConsensusNeeded:          DefaultConsensusNeeded(),
+		ClaimWeighting:           ClaimWeightingValidators,
+		ProphecyExpiry:           DefaultProphecyExpiry,
+		FaultyClaimSlashFraction: DefaultFaultyClaimSlashFraction(),
+		MultisigApproval:         DefaultMultisigApproval(),
+		ColdMultisigApproval:     DefaultColdMultisigApproval(),
+		HotVaultLimits:           DefaultHotVaultLimits(),
+		Fees:                     []DenomFee{},
+		FeeDistribution:          DefaultFeeDistribution(),
+		LockFundsCost:            DefaultLockFundsCost(),
+		Limits:                   []DenomLimit{},
+		VolumeWindow:             DefaultVolumeWindow,
+		TimeLock:                 DefaultTimeLock(),
+		AddressFilter:            DefaultAddressFilter(),
+		UnpegBatchWindow:         DefaultUnpegBatchWindow,
+		CosignerSetChanges:       []CosignerSetChange{},
+		VaultBalances:            []VaultBalance{},
+		VaultTransfers:           []VaultTransfer{},
+		SweepRequests:            []SweepRequest{},
+		RewardPoints:             []RewardPoints{},
+		LockFundsReimbursements:  []LockFundsReimbursement{},
+		VolumeEntries:            []VolumeEntry{},
+		QueuedUnpegs:             []QueuedUnpeg{},
+		TimeLockedUnpegs:         []TimeLockedUnpeg{},
+		UnpegBatches:             []UnpegBatch{},
+		QueuedPegs:               []QueuedPeg{},
+		EligibleCosigners:        []EligibleCosigners{},
+		InactiveCosigners:        []InactiveCosigner{},
+		CosignerInvitations:      []CosignerInvitation{},
+		CosignerRemovals:         []CosignerRemoval{},
+		PendingInviteRequests:    []PendingInviteRequest{},
+		PendingRemovalRequests:   []PendingRemovalRequest{},
+		PendingUnpegs:            []PendingUnpeg{},
+		OpenProphecies:           []OpenProphecy{},
+		FaultyClaims:             []FaultyClaim{},
 	}
 }
 
-// ValidateGenesis validates the proximax-bridge genesis parameters
+// ValidateGenesis validates the proximax-bridge genesis state,
+// reporting every problem found with the path of the offending field
 func ValidateGenesis(data GenesisState) error {
-	// TODO: Create a sanity check to make sure the state conforms to the modules needs
+	var problems []string
+	report := func(field string, err error) {
+		problems = append(problems, fmt.Sprintf("%s: %s", field, err))
+	}
+
+	validateInstanceSet(data.Instances, report)
+
+	if err := validateConsensusNeeded(data.ConsensusNeeded); err != nil {
+		report("consensus_needed", err)
+	}
+	if err := validateClaimWeighting(data.ClaimWeighting); err != nil {
+		report("claim_weighting", err)
+	}
+	if err := validateProphecyExpiry(data.ProphecyExpiry); err != nil {
+		report("prophecy_expiry", err)
+	}
+	if err := validateFaultyClaimSlashFraction(data.FaultyClaimSlashFraction); err != nil {
+		report("faulty_claim_slash_fraction", err)
+	}
+	if err := validateMultisigApproval(data.MultisigApproval); err != nil {
+		report("multisig_approval", err)
+	}
+	if err := validateMultisigApproval(data.ColdMultisigApproval); err != nil {
+		report("cold_multisig_approval", err)
+	} else if err := validateColdMultisigApproval(data.MultisigApproval, data.ColdMultisigApproval); err != nil {
+		report("cold_multisig_approval", err)
+	}
+	if err := validateHotVaultLimits(data.HotVaultLimits); err != nil {
+		report("hot_vault_limits", err)
+	}
+	validateFeeSet(data.Fees, report)
+	if err := validateFeeDistribution(data.FeeDistribution); err != nil {
+		report("fee_distribution", err)
+	}
+	if err := validateLockFundsCost(data.LockFundsCost); err != nil {
+		report("lock_funds_cost", err)
+	}
+	validateLimitSet(data.Limits, report)
+	if err := validateVolumeWindow(data.VolumeWindow); err != nil {
+		report("volume_window", err)
+	}
+	if err := validateTimeLock(data.TimeLock); err != nil {
+		report("time_lock", err)
+	}
+	if err := validateAddressFilter(data.AddressFilter); err != nil {
+		report("address_filter", err)
+	}
+	if err := validateUnpegBatchWindow(data.UnpegBatchWindow); err != nil {
+		report("unpeg_batch_window", err)
+	}
+
+	validateCosignerSetChanges(data.CosignerSetChanges, data.Instances, report)
+	validateVaultBalances(data.VaultBalances, data.Instances, report)
+	validateVaultTransfers(data.VaultTransfers, data.Instances, report)
+	validateSweepRequests(data.SweepRequests, data.Instances, report)
+	validateRewardPoints(data.RewardPoints, report)
+	validateLockFundsReimbursements(data.LockFundsReimbursements, report)
+	validateVolumeEntries(data.VolumeEntries, report)
+	validateQueuedUnpegs(data.QueuedUnpegs, data.Instances, report)
+	validateTimeLockedUnpegs(data.TimeLockedUnpegs, data.QueuedUnpegs, data.Instances, report)
+	validateUnpegBatches(data.UnpegBatches, data.Instances, report)
+	validateQueuedPegs(data.QueuedPegs, data.Instances, report)
+	validateEligibleCosigners(data.EligibleCosigners, data.Instances, report)
+	validateInactiveCosigners(data.InactiveCosigners, report)
+	validateCosignerInvitations(data.CosignerInvitations, data.Instances, report)
+	validateCosignerRemovals(data.CosignerRemovals, data.Instances, report)
+	validatePendingRequests(data.PendingInviteRequests, data.PendingRemovalRequests, data.Instances, report)
+	validatePendingUnpegs(data.PendingUnpegs, data.Instances, report)
+	validateOpenProphecies(data.OpenProphecies, data.Instances, report)
+	validateFaultyClaims(data.FaultyClaims, data.Instances, report)
+
+	if len(problems) != 0 {
+		return fmt.Errorf("invalid %s genesis state:\n%s", ModuleName, strings.Join(problems, "\n"))
+	}
 	return nil
 }
+
+// validateInstanceSet reports malformed instances, duplicate names and denoms pegged by several instances
+func validateInstanceSet(instances []BridgeInstance, report func(string, error)) {
+	names := make(map[string]int)
+	denoms := make(map[string]string)
+	for i, instance := range instances {
+		field := fmt.Sprintf("instances[%d]", i)
+		if err := ValidateInstanceName(instance.Name); err != nil {
+			report(field+".name", err)
+		} else if j, ok := names[instance.Name]; ok {
+			report(field+".name", fmt.Errorf("duplicate of instances[%d]: %s", j, instance.Name))
+		} else {
+			names[instance.Name] = i
+		}
+
+		if err := instance.MainchainNetworkType.Validate(); err != nil {
+			report(field+".mainchain_network_type", err)
+		}
+		vaults := make(map[string]int)
+		addresses := make(map[MainchainAddress]int)
+		for j, vault := range instance.Vaults {
+			vaultField := fmt.Sprintf("%s.vaults[%d]", field, j)
+			if err := vault.ValidateFor(instance.MainchainNetworkType); err != nil {
+				report(vaultField, err)
+			}
+			if k, ok := vaults[vault.Name]; ok {
+				report(vaultField+".name", fmt.Errorf("duplicate of %s.vaults[%d]: %s", field, k, vault.Name))
+			} else {
+				vaults[vault.Name] = j
+			}
+			if k, ok := addresses[vault.MainchainMultisigAddress]; ok {
+				report(vaultField+".mainchain_multisig_address", fmt.Errorf("duplicate of %s.vaults[%d]: %s", field, k, vault.MainchainMultisigAddress))
+			} else {
+				addresses[vault.MainchainMultisigAddress] = j
+			}
+		}
+
+		validateCosignerSet(field+".cosigners", instance.Cosigners, report)
+		validators := make(map[string]int)
+		for j, cosigner := range instance.Cosigners {
+			if _, ok := vaults[cosigner.Vault]; !ok {
+				report(fmt.Sprintf("%s.cosigners[%d].vault", field, j), fmt.Errorf("unknown vault %q", cosigner.Vault))
+			}
+			if k, ok := validators[cosigner.ValidatorAddress]; ok {
+				report(fmt.Sprintf("%s.cosigners[%d].validator_address", field, j), fmt.Errorf("cosigns for the vault of %s.cosigners[%d] already", field, k))
+			} else {
+				validators[cosigner.ValidatorAddress] = j
+			}
+		}
+
+		if len(instance.Denoms) == 0 {
+			report(field+".denoms", errors.New("no denom is pegged"))
+		}
+		for j, mapping := range instance.Denoms {
+			if err := mapping.Validate(); err != nil {
+				report(fmt.Sprintf("%s.denoms[%d]", field, j), err)
+				continue
+			}
+			if other, ok := denoms[mapping.Denom]; ok {
+				report(fmt.Sprintf("%s.denoms[%d].denom", field, j), fmt.Errorf("%s is pegged by %s already", mapping.Denom, other))
+				continue
+			}
+			denoms[mapping.Denom] = fmt.Sprintf("%s.denoms[%d]", field, j)
+		}
+	}
+}
+
+// validateCosignerSet reports vaults with too many cosigners, invalid validator addresses and public keys,
+// and duplicate public keys
+func validateCosignerSet(field string, cosigners []Cosigner, report func(string, error)) {
+	perVault := make(map[string]int)
+	for _, cosigner := range cosigners {
+		perVault[cosigner.Vault]++
+		if perVault[cosigner.Vault] == MaxCosigners+1 {
+			report(field, fmt.Errorf("more than %d cosigners in vault %q", MaxCosigners, cosigner.Vault))
+		}
+	}
+
+	first := make(map[MainchainPublicKey]int)
+	for i, cosigner := range cosigners {
+		if _, err := sdk.ValAddressFromBech32(cosigner.ValidatorAddress); err != nil {
+			report(fmt.Sprintf("%s[%d].validator_address", field, i), fmt.Errorf("invalid validator address %s: %w", cosigner.ValidatorAddress, err))
+		}
+		if err := cosigner.MainchainPublicKey.Validate(); err != nil {
+			report(fmt.Sprintf("%s[%d].mainchain_public_key", field, i), err)
+			continue
+		}
+		publicKey := cosigner.MainchainPublicKey
+		if j, ok := first[publicKey]; ok {
+			report(fmt.Sprintf("%s[%d].mainchain_public_key", field, i), fmt.Errorf("duplicate of %s[%d]: %s", field, j, cosigner.MainchainPublicKey))
+			continue
+		}
+		first[publicKey] = i
+	}
+}
+
+// validateFeeSet reports malformed fees and several fees of a denom
+func validateFeeSet(fees []DenomFee, report func(string, error)) {
+	denoms := make(map[string]int)
+	for i, fee := range fees {
+		field := fmt.Sprintf("fees[%d]", i)
+		if err := sdk.ValidateDenom(fee.Denom); err != nil {
+			report(field+".denom", err)
+		} else if j, ok := denoms[fee.Denom]; ok {
+			report(field+".denom", fmt.Errorf("duplicate of fees[%d]: %s", j, fee.Denom))
+		} else {
+			denoms[fee.Denom] = i
+		}
+		if err := fee.Peg.Validate(); err != nil {
+			report(field+".peg", err)
+		}
+		if err := fee.Unpeg.Validate(); err != nil {
+			report(field+".unpeg", err)
+		}
+	}
+}
+
+// validateCosignerSetChanges reports entries of the log out of order or malformed,
+// and a last entry of an instance whose set is not the current one of the instance
+func validateCosignerSetChanges(changes []CosignerSetChange, instances []BridgeInstance, report func(string, error)) {
+	cosigners := make(map[string][]Cosigner)
+	for _, instance := range instances {
+		cosigners[instance.Name] = instance.Cosigners
+	}
+	// every instance has its own log, an entry is compared with the previous entry of its instance
+	last := make(map[string]int)
+
+	for i, change := range changes {
+		field := fmt.Sprintf("cosigner_set_changes[%d]", i)
+		if _, ok := cosigners[change.Instance]; !ok {
+			report(field+".instance", fmt.Errorf("unknown instance: %s", change.Instance))
+			continue
+		}
+		if j, ok := last[change.Instance]; ok {
+			previous := changes[j]
+			if change.ID <= previous.ID {
+				report(field+".id", fmt.Errorf("id %d is not greater than the previous id %d", change.ID, previous.ID))
+			}
+			if change.Height < previous.Height {
+				report(field+".height", fmt.Errorf("height %d is lower than the previous height %d", change.Height, previous.Height))
+			}
+		}
+		if change.Height < 0 {
+			report(field+".height", fmt.Errorf("negative height %d", change.Height))
+		}
+
+		switch change.Reason {
+		case CosignerSetChangeInvitation, CosignerSetChangeRemoval:
+			if err := change.MainchainTxHash.Validate(); err != nil {
+				report(field+".mainchain_tx_hash", err)
+			}
+		case CosignerSetChangeGenesis, CosignerSetChangeGovernance:
+			if !change.MainchainTxHash.Empty() {
+				report(field+".mainchain_tx_hash", fmt.Errorf("a %s change has no mainchain tx hash: %s", change.Reason, change.MainchainTxHash))
+			}
+		default:
+			report(field+".reason", fmt.Errorf("unknown reason: %s", change.Reason))
+		}
+
+		validateCosignerSet(field+".cosigners", change.Cosigners, report)
+		last[change.Instance] = i
+	}
+
+	for _, instance := range instances {
+		if i, ok := last[instance.Name]; ok && !sameCosignerSet(changes[i].Cosigners, instance.Cosigners) {
+			report(fmt.Sprintf("cosigner_set_changes[%d].cosigners", i), fmt.Errorf("the last logged set is not the current cosigners of %s", instance.Name))
+		}
+	}
+}
+
+func sameCosignerSet(a, b []Cosigner) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	members := make(map[MainchainPublicKey]Cosigner)
+	for _, cosigner := range a {
+		members[cosigner.MainchainPublicKey] = cosigner
+	}
+	for _, cosigner := range b {
+		member, ok := members[cosigner.MainchainPublicKey]
+		if !ok || member != cosigner {
+			return false
+		}
+	}
+	return true
+}
+
+// validateVaultBalances reports balances of unknown vaults, duplicates and invalid amounts
+func validateVaultBalances(balances []VaultBalance, instances []BridgeInstance, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, balance := range balances {
+		field := fmt.Sprintf("vault_balances[%d]", i)
+		if err := validateVaultOf(balance.Instance, balance.Vault, instances); err != nil {
+			report(field, err)
+			continue
+		}
+		key := balance.Instance + "/" + balance.Vault
+		if j, ok := seen[key]; ok {
+			report(field, fmt.Errorf("duplicate of vault_balances[%d]: %s", j, key))
+			continue
+		}
+		seen[key] = i
+		if !balance.Balance.IsValid() {
+			report(field+".balance", fmt.Errorf("invalid balance: %s", balance.Balance))
+		}
+	}
+}
+
+// validateVaultTransfers reports pending transfers between unknown vaults and invalid amounts
+func validateVaultTransfers(transfers []VaultTransfer, instances []BridgeInstance, report func(string, error)) {
+	for i, transfer := range transfers {
+		field := fmt.Sprintf("vault_transfers[%d]", i)
+		if err := transfer.MainchainTxHash.Validate(); err != nil {
+			report(field+".mainchain_tx_hash", err)
+		}
+		if err := validateVaultOf(transfer.Instance, transfer.FromVault, instances); err != nil {
+			report(field+".from_vault", err)
+		}
+		if err := validateVaultOf(transfer.Instance, transfer.ToVault, instances); err != nil {
+			report(field+".to_vault", err)
+		}
+		if !transfer.Amount.IsValid() || transfer.Amount.Empty() {
+			report(field+".amount", fmt.Errorf("invalid amount: %s", transfer.Amount))
+		}
+	}
+}
+
+// validateSweepRequests reports sweeps not between a hot and a cold vault, several sweeps of a vault and invalid amounts
+func validateSweepRequests(requests []SweepRequest, instances []BridgeInstance, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, request := range requests {
+		field := fmt.Sprintf("sweep_requests[%d]", i)
+		if err := validateVaultOf(request.Instance, request.FromVault, instances); err != nil {
+			report(field+".from_vault", err)
+			continue
+		}
+		if err := validateVaultOf(request.Instance, request.ToVault, instances); err != nil {
+			report(field+".to_vault", err)
+			continue
+		}
+		for _, instance := range instances {
+			if instance.Name != request.Instance {
+				continue
+			}
+			from, _ := instance.GetVault(request.FromVault)
+			to, _ := instance.GetVault(request.ToVault)
+			if from.Cold == to.Cold {
+				report(field, fmt.Errorf("sweep between two vaults of the same tier: %s and %s", from.Name, to.Name))
+			}
+		}
+		key := request.Instance + "/" + request.FromVault
+		if j, ok := seen[key]; ok {
+			report(field+".from_vault", fmt.Errorf("duplicate of sweep_requests[%d]: %s", j, key))
+		} else {
+			seen[key] = i
+		}
+		if !request.Amount.IsValid() || request.Amount.Empty() {
+			report(field+".amount", fmt.Errorf("invalid amount: %s", request.Amount))
+		}
+		if request.Height < 0 {
+			report(field+".height", fmt.Errorf("negative height %d", request.Height))
+		}
+	}
+}
+
+func hasInstance(instances []BridgeInstance, name string) bool {
+	for _, instance := range instances {
+		if instance.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateVaultOf(name, vault string, instances []BridgeInstance) error {
+	for _, instance := range instances {
+		if instance.Name != name {
+			continue
+		}
+		if _, found := instance.GetVault(vault); !found {
+			return fmt.Errorf("unknown vault %s of instance %s", vault, name)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown instance: %s", name)
+}
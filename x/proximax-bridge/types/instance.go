@@ -0,0 +1,296 @@
+package types
+
+import (
+	"fmt"
+	"math/bits"
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultInstanceName is the name of the bridge instance of the default genesis, to the Sirius public chain
+	DefaultInstanceName = "sirius"
+	// DefaultDenom is the denom XPX of the default bridge instance is pegged to
+	DefaultDenom = "xpx"
+	// DefaultMainchainMosaic is the namespace alias of XPX
+	DefaultMainchainMosaic = "prime.xpx"
+	// DefaultMainchainDivisibility is the divisibility of XPX
+	DefaultMainchainDivisibility = 6
+
+	// MaxMainchainDivisibility is the highest divisibility of a ProximaX mosaic
+	MaxMainchainDivisibility = 6
+)
+
+var (
+	instanceNamePattern   = regexp.MustCompile(`^[a-z][a-z0-9-]{1,31}$`)
+	mosaicIDPattern       = regexp.MustCompile(`^[0-9A-F]{16}$`)
+	namespaceAliasPattern = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+){0,2}$`)
+)
+
+// BridgeInstance is a bridge to one ProximaX network, with its own multisig vaults, cosigners and pegged denoms.
+// Records and events of the bridge are keyed by the name of the instance.
+type BridgeInstance struct {
+	Name                 string               `json:"name" yaml:"name"`
+	MainchainNetworkType MainchainNetworkType `json:"mainchain_network_type" yaml:"mainchain_network_type"`
+	Vaults               []Vault              `json:"vaults" yaml:"vaults"`
+	Cosigners            []Cosigner           `json:"cosigners" yaml:"cosigners"`
+	Denoms               []DenomMapping       `json:"denoms" yaml:"denoms"`
+}
+
+// DenomMapping pegs a denom of the zone to a mosaic of the ProximaX network of an instance.
+// One unit of the denom is one relative unit of the mosaic, that is 10^divisibility absolute units.
+type DenomMapping struct {
+	Denom string `json:"denom" yaml:"denom"`
+	// MainchainMosaic is the namespace alias of the mosaic, such as prime.xpx, or its id in upper case hex
+	MainchainMosaic string `json:"mainchain_mosaic" yaml:"mainchain_mosaic"`
+	Divisibility    uint32 `json:"divisibility" yaml:"divisibility"`
+}
+
+// NewBridgeInstance creates a new BridgeInstance object
+func NewBridgeInstance(name string, mainchainNetworkType MainchainNetworkType, vaults []Vault, cosigners []Cosigner, denoms []DenomMapping) BridgeInstance {
+	return BridgeInstance{
+		Name:                 name,
+		MainchainNetworkType: mainchainNetworkType,
+		Vaults:               vaults,
+		Cosigners:            cosigners,
+		Denoms:               denoms,
+	}
+}
+
+// DefaultBridgeInstance bridges XPX of the Sirius public chain, the vaults are registered later
+func DefaultBridgeInstance() BridgeInstance {
+	return NewBridgeInstance(DefaultInstanceName, DefaultMainchainNetworkType, []Vault{}, []Cosigner{}, []DenomMapping{
+		{Denom: DefaultDenom, MainchainMosaic: DefaultMainchainMosaic, Divisibility: DefaultMainchainDivisibility},
+	})
+}
+
+// ValidateInstanceName checks that the name is a lower case identifier usable in store keys and prophecy ids
+func ValidateInstanceName(name string) error {
+	if !instanceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid bridge instance name %q, it must be 2 to 32 lower case letters, digits and dashes, starting with a letter", name)
+	}
+	return nil
+}
+
+// Validate checks the network type, the multisig address, the cosigners and the denoms of the instance
+func (i BridgeInstance) Validate() error {
+	if err := ValidateInstanceName(i.Name); err != nil {
+		return err
+	}
+	if err := i.MainchainNetworkType.Validate(); err != nil {
+		return fmt.Errorf("instance %s: %w", i.Name, err)
+	}
+	vaults := make(map[string]bool)
+	addresses := make(map[MainchainAddress]bool)
+	for _, vault := range i.Vaults {
+		if err := vault.ValidateFor(i.MainchainNetworkType); err != nil {
+			return fmt.Errorf("instance %s: %w", i.Name, err)
+		}
+		if vaults[vault.Name] {
+			return fmt.Errorf("instance %s: duplicate vault %s", i.Name, vault.Name)
+		}
+		if addresses[vault.MainchainMultisigAddress] {
+			return fmt.Errorf("instance %s: duplicate vault multisig address %s", i.Name, vault.MainchainMultisigAddress)
+		}
+		vaults[vault.Name] = true
+		addresses[vault.MainchainMultisigAddress] = true
+	}
+	if err := validateCosigners(i.Cosigners); err != nil {
+		return fmt.Errorf("instance %s: %w", i.Name, err)
+	}
+	validators := make(map[string]bool)
+	for _, cosigner := range i.Cosigners {
+		if !vaults[cosigner.Vault] {
+			return fmt.Errorf("instance %s: cosigner %s of unknown vault %q", i.Name, cosigner.MainchainPublicKey, cosigner.Vault)
+		}
+		if validators[cosigner.ValidatorAddress] {
+			return fmt.Errorf("instance %s: validator %s cosigns for several vaults", i.Name, cosigner.ValidatorAddress)
+		}
+		validators[cosigner.ValidatorAddress] = true
+	}
+	for _, vault := range i.Vaults {
+		if len(i.VaultCosigners(vault.Name)) > MaxCosigners {
+			return fmt.Errorf("instance %s: more than %d cosigners in vault %s", i.Name, MaxCosigners, vault.Name)
+		}
+	}
+	if len(i.Denoms) == 0 {
+		return fmt.Errorf("instance %s: no denom is pegged", i.Name)
+	}
+	denoms := make(map[string]bool)
+	for _, mapping := range i.Denoms {
+		if err := mapping.Validate(); err != nil {
+			return fmt.Errorf("instance %s: %w", i.Name, err)
+		}
+		if denoms[mapping.Denom] {
+			return fmt.Errorf("instance %s: duplicate denom %s", i.Name, mapping.Denom)
+		}
+		denoms[mapping.Denom] = true
+	}
+	return nil
+}
+
+// GetDenom returns the mapping of the denom, if the instance pegs it
+func (i BridgeInstance) GetDenom(denom string) (DenomMapping, bool) {
+	for _, mapping := range i.Denoms {
+		if mapping.Denom == denom {
+			return mapping, true
+		}
+	}
+	return DenomMapping{}, false
+}
+
+// ValidateCoins checks that every coin is of a denom the instance pegs
+func (i BridgeInstance) ValidateCoins(coins sdk.Coins) error {
+	for _, coin := range coins {
+		if _, found := i.GetDenom(coin.Denom); !found {
+			return fmt.Errorf("%s is not pegged by instance %s", coin.Denom, i.Name)
+		}
+	}
+	return nil
+}
+
+// CheckMainchainAmounts checks that every coin is of a denom the instance pegs and fits in the amount of its mosaic
+func (i BridgeInstance) CheckMainchainAmounts(coins sdk.Coins) error {
+	for _, coin := range coins {
+		mapping, found := i.GetDenom(coin.Denom)
+		if !found {
+			return fmt.Errorf("%s is not pegged by instance %s", coin.Denom, i.Name)
+		}
+		if _, err := mapping.MainchainAmount(coin.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetVault returns the vault of the instance with the given name
+func (i BridgeInstance) GetVault(name string) (Vault, bool) {
+	for _, vault := range i.Vaults {
+		if vault.Name == name {
+			return vault, true
+		}
+	}
+	return Vault{}, false
+}
+
+// GetVaultByAddress returns the vault of the instance with the given multisig address
+func (i BridgeInstance) GetVaultByAddress(address MainchainAddress) (Vault, bool) {
+	for _, vault := range i.Vaults {
+		if vault.MainchainMultisigAddress == address {
+			return vault, true
+		}
+	}
+	return Vault{}, false
+}
+
+// VaultsOf returns the cold or the hot vaults of the instance
+func (i BridgeInstance) VaultsOf(cold bool) []Vault {
+	vaults := []Vault{}
+	for _, vault := range i.Vaults {
+		if vault.Cold == cold {
+			vaults = append(vaults, vault)
+		}
+	}
+	return vaults
+}
+
+// VaultCosigners returns the cosigners of a vault of the instance
+func (i BridgeInstance) VaultCosigners(vault string) []Cosigner {
+	cosigners := []Cosigner{}
+	for _, cosigner := range i.Cosigners {
+		if cosigner.Vault == vault {
+			cosigners = append(cosigners, cosigner)
+		}
+	}
+	return cosigners
+}
+
+// VaultForNewCosigner returns the vault with the fewest cosigners, which a new cosigner joins,
+// unless every vault is full
+func (i BridgeInstance) VaultForNewCosigner() (Vault, bool) {
+	var found bool
+	var fewest Vault
+	least := MaxCosigners
+	for _, vault := range i.Vaults {
+		if cosigners := len(i.VaultCosigners(vault.Name)); cosigners < least {
+			fewest, least, found = vault, cosigners, true
+		}
+	}
+	return fewest, found
+}
+
+// GetCosignerOf returns the cosigner of the validator, which tells the vault it cosigns for
+func (i BridgeInstance) GetCosignerOf(validator string) (Cosigner, bool) {
+	for _, cosigner := range i.Cosigners {
+		if cosigner.ValidatorAddress == validator {
+			return cosigner, true
+		}
+	}
+	return Cosigner{}, false
+}
+
+// GetCosigner returns the cosigner of the instance with the given mainchain public key
+func (i BridgeInstance) GetCosigner(mainchainPublicKey MainchainPublicKey) (Cosigner, bool) {
+	for _, cosigner := range i.Cosigners {
+		if cosigner.MainchainPublicKey == mainchainPublicKey {
+			return cosigner, true
+		}
+	}
+	return Cosigner{}, false
+}
+
+// HasCosigner returns true when the validator is a cosigner of the instance
+func (i BridgeInstance) HasCosigner(validator string) bool {
+	_, found := i.GetCosignerOf(validator)
+	return found
+}
+
+// Validate checks the denom, the mosaic and the divisibility of the mapping
+func (m DenomMapping) Validate() error {
+	if err := sdk.ValidateDenom(m.Denom); err != nil {
+		return err
+	}
+	if !mosaicIDPattern.MatchString(m.MainchainMosaic) && !namespaceAliasPattern.MatchString(m.MainchainMosaic) {
+		return fmt.Errorf("invalid mainchain mosaic of %s, neither a namespace alias nor a hex mosaic id: %s", m.Denom, m.MainchainMosaic)
+	}
+	if m.Divisibility > MaxMainchainDivisibility {
+		return fmt.Errorf("divisibility of %s too large: %d", m.Denom, m.Divisibility)
+	}
+	return nil
+}
+
+// MainchainUnit returns the absolute amount of the mosaic in one unit of the denom
+func (m DenomMapping) MainchainUnit() (uint64, error) {
+	unit := uint64(1)
+	for i := uint32(0); i < m.Divisibility; i++ {
+		hi, lo := bits.Mul64(unit, 10)
+		if hi != 0 {
+			return 0, fmt.Errorf("divisibility of %s too large: %d", m.Denom, m.Divisibility)
+		}
+		unit = lo
+	}
+	return unit, nil
+}
+
+// MainchainAmount returns the absolute amount of the mosaic for an amount of the denom,
+// failing when it does not fit in the amount of a mosaic
+func (m DenomMapping) MainchainAmount(amount sdk.Int) (uint64, error) {
+	unit, err := m.MainchainUnit()
+	if err != nil {
+		return 0, err
+	}
+	if !amount.IsUint64() {
+		return 0, fmt.Errorf("%s%s out of the range of a mosaic amount", amount, m.Denom)
+	}
+	hi, lo := bits.Mul64(amount.Uint64(), unit)
+	if hi != 0 {
+		return 0, fmt.Errorf("%s%s out of the range of a mosaic amount", amount, m.Denom)
+	}
+	return lo, nil
+}
+
+// IsMosaicID returns true when the mosaic is given by its id rather than a namespace alias
+func (m DenomMapping) IsMosaicID() bool {
+	return mosaicIDPattern.MatchString(m.MainchainMosaic)
+}
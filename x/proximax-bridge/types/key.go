@@ -1,5 +1,11 @@
 package types
 
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "proximaxbridge"
@@ -15,8 +21,278 @@ const (
 
 	StoreKeyForInvite = ModuleName + "_invite"
 
+	StoreKeyForRemoval = ModuleName + "_removal"
+
+	StoreKeyForProphecy = ModuleName + "_prophecy"
+
 	// RouterKey to be used for routing msgs
 	RouterKey = ModuleName
 
 	QuerierRoute = ModuleName
 )
+
+// Keys in the module store
+var (
+	// EligibleCosignersPrefix is the prefix for the cosigner set last computed from the stake distribution, keyed by instance
+	EligibleCosignersPrefix = []byte{0x00}
+	// InactiveCosignerPrefix is the prefix for cosigners whose validator left the bonded set, keyed by validator
+	InactiveCosignerPrefix = []byte{0x01}
+	// CosignerSetChangePrefix is the prefix for the log of cosigner set changes, keyed by instance and id
+	CosignerSetChangePrefix = []byte{0x02}
+	// VaultBalancePrefix is the prefix for the balances of vaults, keyed by instance and vault
+	VaultBalancePrefix = []byte{0x03}
+	// VaultTransferPrefix is the prefix for pending transfers between vaults, keyed by instance and mainchain tx hash
+	VaultTransferPrefix = []byte{0x04}
+	// SweepRequestPrefix is the prefix for sweeps asked for and not recorded yet, keyed by instance and source vault
+	SweepRequestPrefix = []byte{0x05}
+	// RewardPointsPrefix is the prefix for the points validators earned since the last payout of the fee pool, keyed by validator
+	RewardPointsPrefix = []byte{0x06}
+	// LockFundsReimbursementPrefix is the prefix for what the bridge owes validators for lost lock funds, keyed by validator
+	LockFundsReimbursementPrefix = []byte{0x07}
+	// VolumeEntryPrefix is the prefix for the amounts bridged within the volume window, keyed by block time, direction and denom
+	VolumeEntryPrefix = []byte{0x08}
+	// VolumeTotalPrefix is the prefix for the volume bridged within the volume window, keyed by direction and denom
+	VolumeTotalPrefix = []byte{0x09}
+	// QueuedUnpegPrefix is the prefix for the withdrawal queue, keyed by id
+	QueuedUnpegPrefix = []byte{0x0A}
+	// NextQueuedUnpegIDKey is the key of the id of the next unpeg queued
+	NextQueuedUnpegIDKey = []byte{0x0B}
+	// TimeLockedUnpegPrefix is the prefix for the unpegs held back by the time lock, keyed by id
+	TimeLockedUnpegPrefix = []byte{0x0C}
+	// UnpegBatchPrefix is the prefix for the unpeg batches, keyed by id
+	UnpegBatchPrefix = []byte{0x0D}
+	// NextUnpegBatchIDKey is the key of the id of the next unpeg batch
+	NextUnpegBatchIDKey = []byte{0x0E}
+	// OpenUnpegBatchPrefix is the prefix for the id of the open batch of a vault, keyed by instance and vault
+	OpenUnpegBatchPrefix = []byte{0x0F}
+	// UnpegBatchTxPrefix is the prefix for the id of the batch announced in a mainchain transaction, keyed by instance and mainchain tx hash
+	UnpegBatchTxPrefix = []byte{0x10}
+	// CosignerInvitationPrefix is the prefix for the invitations to join a vault, keyed by instance and validator
+	CosignerInvitationPrefix = []byte{0x11}
+	// CosignerRemovalPrefix is the prefix for the requested removals of cosigners, keyed by instance and mainchain public key
+	CosignerRemovalPrefix = []byte{0x12}
+	// QueuedPegPrefix is the prefix for the pegs waiting to be minted within the volume caps, keyed by id
+	QueuedPegPrefix = []byte{0x13}
+	// NextQueuedPegIDKey is the key of the id of the next peg queued
+	NextQueuedPegIDKey = []byte{0x14}
+	// ClosedUnpegBatchPrefix is the prefix for the ids of the batches waiting to be announced, keyed by id
+	ClosedUnpegBatchPrefix = []byte{0x15}
+	// PendingUnpegPrefix is the prefix for the unpegs paid out on their own which were not announced yet, keyed by id
+	PendingUnpegPrefix = []byte{0x16}
+	// AnnouncedUnpegBatchPrefix is the prefix for the ids of the batches waiting for the confirmation of their aggregate, keyed by id
+	AnnouncedUnpegBatchPrefix = []byte{0x17}
+	// CosignerPrefix is the prefix for the cosigners of the vaults, keyed by instance and mainchain public key
+	CosignerPrefix = []byte{0x18}
+)
+
+// Key prefixes in the prophecy store
+var (
+	// ProphecyRecordPrefix is the prefix for open prophecy records, keyed by prophecy id
+	ProphecyRecordPrefix = []byte{0x00}
+	// ProphecyQueuePrefix is the prefix for open prophecy ids, keyed by creation height
+	ProphecyQueuePrefix = []byte{0x01}
+	// ValidatorClaimPrefix is the prefix for validator claims, keyed by prophecy id and validator
+	ValidatorClaimPrefix = []byte{0x02}
+	// FaultyClaimPrefix is the prefix for faulty claims, keyed by validator, height, instance and mainchain tx hash
+	FaultyClaimPrefix = []byte{0x03}
+)
+
+// GetMainchainTxKey returns the key of the records on a mainchain transaction of an instance,
+// in the stores of pegs, unpegs, cosignatures and pending requests
+func GetMainchainTxKey(instance string, mainchainTxHash MainchainTxHash) []byte {
+	return append(lengthPrefixed([]byte(instance)), []byte(mainchainTxHash)...)
+}
+
+// GetEligibleCosignersKey returns the key of the cosigner set of an instance last computed from the stake distribution
+func GetEligibleCosignersKey(instance string) []byte {
+	return append(EligibleCosignersPrefix, []byte(instance)...)
+}
+
+// GetCosignersPrefix returns the prefix of the cosigners of the vaults of an instance
+func GetCosignersPrefix(instance string) []byte {
+	return append(CosignerPrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetCosignerKey returns the key of the cosigner of an instance with the given mainchain public key
+func GetCosignerKey(instance string, mainchainPublicKey MainchainPublicKey) []byte {
+	return append(GetCosignersPrefix(instance), []byte(mainchainPublicKey)...)
+}
+
+// GetInactiveCosignerKey returns the key marking the cosigner of a validator inactive
+func GetInactiveCosignerKey(validator sdk.ValAddress) []byte {
+	return append(InactiveCosignerPrefix, validator.Bytes()...)
+}
+
+// GetCosignerSetChangesPrefix returns the prefix of the cosigner set change log of an instance
+func GetCosignerSetChangesPrefix(instance string) []byte {
+	return append(CosignerSetChangePrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetCosignerSetChangeKey returns the key of an entry of the cosigner set change log of an instance
+func GetCosignerSetChangeKey(instance string, id uint64) []byte {
+	return append(GetCosignerSetChangesPrefix(instance), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetVaultBalancesPrefix returns the prefix of the balances of the vaults of an instance
+func GetVaultBalancesPrefix(instance string) []byte {
+	return append(VaultBalancePrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetVaultBalanceKey returns the key of the balance of a vault of an instance
+func GetVaultBalanceKey(instance, vault string) []byte {
+	return append(GetVaultBalancesPrefix(instance), []byte(vault)...)
+}
+
+// GetVaultTransfersPrefix returns the prefix of the pending transfers between vaults of an instance
+func GetVaultTransfersPrefix(instance string) []byte {
+	return append(VaultTransferPrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetVaultTransferKey returns the key of a pending transfer between vaults of an instance
+func GetVaultTransferKey(instance string, mainchainTxHash MainchainTxHash) []byte {
+	return append(GetVaultTransfersPrefix(instance), []byte(mainchainTxHash)...)
+}
+
+// GetSweepRequestsPrefix returns the prefix of the sweeps of the vaults of an instance
+func GetSweepRequestsPrefix(instance string) []byte {
+	return append(SweepRequestPrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetSweepRequestKey returns the key of the sweep from a vault of an instance
+func GetSweepRequestKey(instance, vault string) []byte {
+	return append(GetSweepRequestsPrefix(instance), []byte(vault)...)
+}
+
+// GetRewardPointsKey returns the key of the points a validator earned since the last payout of the fee pool
+func GetRewardPointsKey(validator sdk.ValAddress) []byte {
+	return append(RewardPointsPrefix, validator.Bytes()...)
+}
+
+// GetLockFundsReimbursementKey returns the key of what the bridge owes a validator for lost lock funds
+func GetLockFundsReimbursementKey(validator sdk.ValAddress) []byte {
+	return append(LockFundsReimbursementPrefix, validator.Bytes()...)
+}
+
+// GetVolumeEntriesTimePrefix returns the prefix of the amounts bridged at a block time
+func GetVolumeEntriesTimePrefix(t time.Time) []byte {
+	return append(VolumeEntryPrefix, sdk.FormatTimeBytes(t)...)
+}
+
+// GetVolumeEntryKey returns the key of the amount of a denom bridged in a direction at a block time
+func GetVolumeEntryKey(t time.Time, direction, denom string) []byte {
+	return append(GetVolumeEntriesTimePrefix(t), getVolumeKey(direction, denom)...)
+}
+
+// GetVolumeTotalKey returns the key of the volume of a denom bridged in a direction within the volume window
+func GetVolumeTotalKey(direction, denom string) []byte {
+	return append(VolumeTotalPrefix, getVolumeKey(direction, denom)...)
+}
+
+func getVolumeKey(direction, denom string) []byte {
+	return append(lengthPrefixed([]byte(direction)), []byte(denom)...)
+}
+
+// GetPendingUnpegKey returns the key of an unpeg waiting to be announced
+func GetPendingUnpegKey(id uint64) []byte {
+	return append(PendingUnpegPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetQueuedUnpegKey returns the key of an unpeg in the withdrawal queue
+func GetQueuedUnpegKey(id uint64) []byte {
+	return append(QueuedUnpegPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetQueuedPegKey returns the key of a peg waiting to be minted
+func GetQueuedPegKey(id uint64) []byte {
+	return append(QueuedPegPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTimeLockedUnpegKey returns the key of an unpeg held back by the time lock
+func GetTimeLockedUnpegKey(id uint64) []byte {
+	return append(TimeLockedUnpegPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetUnpegBatchKey returns the key of an unpeg batch
+func GetUnpegBatchKey(id uint64) []byte {
+	return append(UnpegBatchPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetOpenUnpegBatchKey returns the key of the id of the open batch of a vault of an instance
+func GetOpenUnpegBatchKey(instance, vault string) []byte {
+	return append(OpenUnpegBatchPrefix, append(lengthPrefixed([]byte(instance)), []byte(vault)...)...)
+}
+
+// GetClosedUnpegBatchKey returns the key of the id of a batch waiting to be announced
+func GetClosedUnpegBatchKey(id uint64) []byte {
+	return append(ClosedUnpegBatchPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetAnnouncedUnpegBatchKey returns the key of the id of a batch waiting for the confirmation of its aggregate
+func GetAnnouncedUnpegBatchKey(id uint64) []byte {
+	return append(AnnouncedUnpegBatchPrefix, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetUnpegBatchTxKey returns the key of the id of the batch announced in a mainchain transaction of an instance
+func GetUnpegBatchTxKey(instance string, mainchainTxHash MainchainTxHash) []byte {
+	return append(UnpegBatchTxPrefix, GetMainchainTxKey(instance, mainchainTxHash)...)
+}
+
+// GetCosignerInvitationsPrefix returns the prefix of the invitations to join the vaults of an instance
+func GetCosignerInvitationsPrefix(instance string) []byte {
+	return append(CosignerInvitationPrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetCosignerInvitationKey returns the key of the invitation of a validator to join a vault of an instance
+func GetCosignerInvitationKey(instance string, validator sdk.ValAddress) []byte {
+	return append(GetCosignerInvitationsPrefix(instance), validator.Bytes()...)
+}
+
+// GetCosignerRemovalKey returns the key of the requested removal of a cosigner of an instance
+func GetCosignerRemovalKey(instance string, mainchainPublicKey MainchainPublicKey) []byte {
+	return append(GetCosignerRemovalsPrefix(instance), []byte(mainchainPublicKey)...)
+}
+
+// GetCosignerRemovalsPrefix returns the prefix of the requested removals of cosigners of an instance
+func GetCosignerRemovalsPrefix(instance string) []byte {
+	return append(CosignerRemovalPrefix, lengthPrefixed([]byte(instance))...)
+}
+
+// GetProphecyRecordKey returns the key of an open prophecy record
+func GetProphecyRecordKey(id string) []byte {
+	return append(ProphecyRecordPrefix, []byte(id)...)
+}
+
+// GetProphecyQueueHeightPrefix returns the prefix of prophecies created at the given height
+func GetProphecyQueueHeightPrefix(height int64) []byte {
+	return append(ProphecyQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetProphecyQueueKey returns the key of an open prophecy in the creation height queue
+func GetProphecyQueueKey(height int64, id string) []byte {
+	return append(GetProphecyQueueHeightPrefix(height), []byte(id)...)
+}
+
+// GetValidatorClaimsPrefix returns the prefix of the validator claims on a prophecy
+func GetValidatorClaimsPrefix(prophecyID string) []byte {
+	return append(ValidatorClaimPrefix, lengthPrefixed([]byte(prophecyID))...)
+}
+
+// GetValidatorClaimKey returns the key of a validator's claim on a prophecy
+func GetValidatorClaimKey(prophecyID string, validator sdk.ValAddress) []byte {
+	return append(GetValidatorClaimsPrefix(prophecyID), validator.Bytes()...)
+}
+
+// GetFaultyClaimsPrefix returns the prefix of the faulty claims of a validator
+func GetFaultyClaimsPrefix(validator sdk.ValAddress) []byte {
+	return append(FaultyClaimPrefix, lengthPrefixed(validator.Bytes())...)
+}
+
+// GetFaultyClaimKey returns the key of a validator's faulty claim
+func GetFaultyClaimKey(validator sdk.ValAddress, height int64, instance string, mainchainTxHash MainchainTxHash) []byte {
+	key := append(GetFaultyClaimsPrefix(validator), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(key, GetMainchainTxKey(instance, mainchainTxHash)...)
+}
+
+func lengthPrefixed(bz []byte) []byte {
+	return append(sdk.Uint64ToBigEndian(uint64(len(bz))), bz...)
+}
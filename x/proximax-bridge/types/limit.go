@@ -0,0 +1,197 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultVolumeWindow is the rolling 24 hours the volume caps apply over
+const DefaultVolumeWindow = 24 * time.Hour
+
+// TransferLimit bounds each transfer of a denom in a direction, and their volume over the volume window.
+// A zero max or volume cap leaves that bound out.
+type TransferLimit struct {
+	Min       sdk.Int `json:"min" yaml:"min"`
+	Max       sdk.Int `json:"max" yaml:"max"`
+	VolumeCap sdk.Int `json:"volume_cap" yaml:"volume_cap"`
+}
+
+// DenomLimit is the transfer limit of a denom in both directions. Denoms without a limit are not bounded.
+type DenomLimit struct {
+	Denom string        `json:"denom" yaml:"denom"`
+	Peg   TransferLimit `json:"peg" yaml:"peg"`
+	Unpeg TransferLimit `json:"unpeg" yaml:"unpeg"`
+}
+
+// VolumeEntry is the amount of a denom bridged in a direction at a block time, counted in the volume until it leaves the window
+type VolumeEntry struct {
+	Direction string    `json:"direction" yaml:"direction"`
+	Denom     string    `json:"denom" yaml:"denom"`
+	Time      time.Time `json:"time" yaml:"time"`
+	Amount    sdk.Int   `json:"amount" yaml:"amount"`
+}
+
+// NewTransferLimit creates a new TransferLimit object
+func NewTransferLimit(min, max, volumeCap sdk.Int) TransferLimit {
+	return TransferLimit{
+		Min:       min,
+		Max:       max,
+		VolumeCap: volumeCap,
+	}
+}
+
+// CheckAmount checks that an amount is within the min and the max, and would ever fit in the volume cap
+func (l TransferLimit) CheckAmount(amount sdk.Int) error {
+	if amount.LT(l.Min) {
+		return fmt.Errorf("%s is below the minimum of %s", amount, l.Min)
+	}
+	if l.Max.IsPositive() && amount.GT(l.Max) {
+		return fmt.Errorf("%s is above the maximum of %s", amount, l.Max)
+	}
+	if l.VolumeCap.IsPositive() && amount.GT(l.VolumeCap) {
+		return fmt.Errorf("%s is above the volume cap of %s", amount, l.VolumeCap)
+	}
+	return nil
+}
+
+// WithinVolumeCap tells whether an amount can be added to the volume of the window without exceeding the cap
+func (l TransferLimit) WithinVolumeCap(volume, amount sdk.Int) bool {
+	return !l.VolumeCap.IsPositive() || volume.Add(amount).LTE(l.VolumeCap)
+}
+
+// Validate checks that the bounds are set, not negative, and the min does not exceed the max
+func (l TransferLimit) Validate() error {
+	for _, bound := range []struct {
+		name  string
+		value sdk.Int
+	}{{"min", l.Min}, {"max", l.Max}, {"volume cap", l.VolumeCap}} {
+		if bound.value == (sdk.Int{}) {
+			return fmt.Errorf("%s must be set", bound.name)
+		}
+		if bound.value.IsNegative() {
+			return fmt.Errorf("%s cannot be negative: %s", bound.name, bound.value)
+		}
+	}
+	if l.Max.IsPositive() && l.Min.GT(l.Max) {
+		return fmt.Errorf("min %s exceeds max %s", l.Min, l.Max)
+	}
+	return nil
+}
+
+// LimitOf returns the transfer limit of the direction
+func (l DenomLimit) LimitOf(direction string) TransferLimit {
+	if direction == FeeDirectionPeg {
+		return l.Peg
+	}
+	return l.Unpeg
+}
+
+// GetLimit returns the transfer limit of the denom, if it has one
+func (p Params) GetLimit(denom string) (DenomLimit, bool) {
+	for _, limit := range p.Limits {
+		if limit.Denom == denom {
+			return limit, true
+		}
+	}
+	return DenomLimit{}, false
+}
+
+// CheckTransferAmount checks every coin of an amount bridged in the direction against the min and the max of its denom
+func (p Params) CheckTransferAmount(direction string, amount sdk.Coins) error {
+	for _, coin := range amount {
+		limit, found := p.GetLimit(coin.Denom)
+		if !found {
+			continue
+		}
+		if err := limit.LimitOf(direction).CheckAmount(coin.Amount); err != nil {
+			return fmt.Errorf("%s of %s: %w", direction, coin.Denom, err)
+		}
+	}
+	return nil
+}
+
+func validateLimits(i interface{}) error {
+	v, ok := i.([]DenomLimit)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	denoms := make(map[string]bool)
+	for _, limit := range v {
+		if err := sdk.ValidateDenom(limit.Denom); err != nil {
+			return err
+		}
+		if denoms[limit.Denom] {
+			return fmt.Errorf("duplicate limit of %s", limit.Denom)
+		}
+		denoms[limit.Denom] = true
+		if err := limit.Peg.Validate(); err != nil {
+			return fmt.Errorf("peg limit of %s: %w", limit.Denom, err)
+		}
+		if err := limit.Unpeg.Validate(); err != nil {
+			return fmt.Errorf("unpeg limit of %s: %w", limit.Denom, err)
+		}
+	}
+	return nil
+}
+
+func validateVolumeWindow(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("volume window must be positive: %s", v)
+	}
+	return nil
+}
+
+// validateLimitSet reports malformed and duplicate limits
+func validateLimitSet(limits []DenomLimit, report func(string, error)) {
+	denoms := make(map[string]int)
+	for i, limit := range limits {
+		field := fmt.Sprintf("limits[%d]", i)
+		if err := sdk.ValidateDenom(limit.Denom); err != nil {
+			report(field+".denom", err)
+		} else if j, ok := denoms[limit.Denom]; ok {
+			report(field+".denom", fmt.Errorf("duplicate of limits[%d]: %s", j, limit.Denom))
+		} else {
+			denoms[limit.Denom] = i
+		}
+		if err := limit.Peg.Validate(); err != nil {
+			report(field+".peg", err)
+		}
+		if err := limit.Unpeg.Validate(); err != nil {
+			report(field+".unpeg", err)
+		}
+	}
+}
+
+// validateVolumeEntries reports malformed and duplicate volume entries
+func validateVolumeEntries(entries []VolumeEntry, report func(string, error)) {
+	seen := make(map[string]int)
+	for i, entry := range entries {
+		field := fmt.Sprintf("volume_entries[%d]", i)
+		if err := ValidateFeeDirection(entry.Direction); err != nil {
+			report(field+".direction", err)
+		}
+		if err := sdk.ValidateDenom(entry.Denom); err != nil {
+			report(field+".denom", err)
+		}
+		if entry.Time.IsZero() {
+			report(field+".time", fmt.Errorf("time cannot be empty"))
+		}
+		if entry.Amount == (sdk.Int{}) || !entry.Amount.IsPositive() {
+			report(field+".amount", fmt.Errorf("amount must be positive: %s", entry.Amount))
+		}
+		key := fmt.Sprintf("%s/%s/%s", entry.Direction, entry.Denom, entry.Time.UTC().Format(time.RFC3339Nano))
+		if j, ok := seen[key]; ok {
+			report(field, fmt.Errorf("duplicate of volume_entries[%d]: %s", j, key))
+		} else {
+			seen[key] = i
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// LockFundsOutcomeReturned is the outcome of an aggregate confirmed on the mainchain, whose locked funds went back to the initiator
+	LockFundsOutcomeReturned = "returned"
+	// LockFundsOutcomeExpired is the outcome of an aggregate which expired, whose locked funds the initiator lost
+	LockFundsOutcomeExpired = "expired"
+
+	// DefaultLockFundsAmount is the amount of XPX the relayer locks for each aggregate it announces
+	DefaultLockFundsAmount int64 = 10
+)
+
+// LockFundsReimbursement is what the bridge owes a validator for the funds its cosigner lost
+// locking aggregates which expired
+type LockFundsReimbursement struct {
+	Validator   sdk.ValAddress `json:"validator" yaml:"validator"`
+	Outstanding sdk.Coins      `json:"outstanding" yaml:"outstanding"`
+}
+
+// DefaultLockFundsCost is the 10 XPX locked for each aggregate, pegged one to one to the default denom
+func DefaultLockFundsCost() sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(DefaultDenom, DefaultLockFundsAmount))
+}
+
+// ValidateLockFundsOutcome checks that the outcome is returned or expired
+func ValidateLockFundsOutcome(outcome string) error {
+	switch outcome {
+	case LockFundsOutcomeReturned, LockFundsOutcomeExpired:
+		return nil
+	default:
+		return fmt.Errorf("invalid lock funds outcome: %s", outcome)
+	}
+}
+
+func validateLockFundsCost(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() {
+		return fmt.Errorf("invalid lock funds cost: %s", v)
+	}
+	return nil
+}
+
+// validateLockFundsReimbursements reports malformed and duplicate reimbursements
+func validateLockFundsReimbursements(reimbursements []LockFundsReimbursement, report func(string, error)) {
+	validators := make(map[string]int)
+	for i, reimbursement := range reimbursements {
+		field := fmt.Sprintf("lock_funds_reimbursements[%d]", i)
+		if reimbursement.Validator.Empty() {
+			report(field+".validator", fmt.Errorf("validator must be set"))
+		} else if j, ok := validators[reimbursement.Validator.String()]; ok {
+			report(field+".validator", fmt.Errorf("duplicate of lock_funds_reimbursements[%d]: %s", j, reimbursement.Validator))
+		} else {
+			validators[reimbursement.Validator.String()] = i
+		}
+		if !reimbursement.Outstanding.IsValid() || reimbursement.Outstanding.Empty() {
+			report(field+".outstanding", fmt.Errorf("invalid outstanding amount: %s", reimbursement.Outstanding))
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ProximaX identifiers are kept in a canonical form, upper case and without dashes,
+// so that they can be compared and used as store keys as they are.
+// Constructors and JSON decoding canonicalize their input, Validate rejects anything else.
+
+// MainchainNetworkType is the name of a ProximaX network, as in the ProximaX SDK configuration
+type MainchainNetworkType string
+
+// MainchainNetworkTypes maps the ProximaX network types to the version byte their addresses start with
+var MainchainNetworkTypes = map[MainchainNetworkType]byte{
+	"mijin":       96,
+	"mijinTest":   144,
+	"public":      184,
+	"publicTest":  168,
+	"private":     200,
+	"privateTest": 176,
+}
+
+// NewMainchainNetworkType parses the name of a ProximaX network
+func NewMainchainNetworkType(name string) (MainchainNetworkType, error) {
+	networkType := MainchainNetworkType(name)
+	return networkType, networkType.Validate()
+}
+
+// Validate checks that the network type is known
+func (n MainchainNetworkType) Validate() error {
+	if _, ok := MainchainNetworkTypes[n]; !ok {
+		return fmt.Errorf("unknown mainchain network type: %s", string(n))
+	}
+	return nil
+}
+
+// Version returns the version byte of the addresses of the network
+func (n MainchainNetworkType) Version() byte {
+	return MainchainNetworkTypes[n]
+}
+
+func (n MainchainNetworkType) String() string {
+	return string(n)
+}
+
+// UnmarshalJSON rejects unknown network types
+func (n *MainchainNetworkType) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	networkType, err := NewMainchainNetworkType(s)
+	if err != nil {
+		return err
+	}
+	*n = networkType
+	return nil
+}
+
+// MainchainAddress is a base32 encoded ProximaX address without dashes
+type MainchainAddress string
+
+// NewMainchainAddress parses a ProximaX address, either plain or in the pretty format with dashes
+func NewMainchainAddress(address string) (MainchainAddress, error) {
+	a := MainchainAddress(strings.ToUpper(strings.Replace(address, "-", "", -1)))
+	return a, a.Validate()
+}
+
+// Validate checks that the address is a canonical base32 encoded ProximaX address with a valid checksum
+func (a MainchainAddress) Validate() error {
+	if len(a) != MainchainAddressLength {
+		return fmt.Errorf("mainchain address must be %d characters without dashes: %s", MainchainAddressLength, string(a))
+	}
+	if strings.ToUpper(string(a)) != string(a) {
+		return fmt.Errorf("mainchain address must be upper case: %s", string(a))
+	}
+	raw, err := base32.StdEncoding.DecodeString(string(a))
+	if err != nil {
+		return fmt.Errorf("mainchain address is not base32 encoded: %s", string(a))
+	}
+	hash := sha3.Sum256(raw[:21])
+	if !bytes.Equal(hash[:4], raw[21:]) {
+		return fmt.Errorf("mainchain address %s has an invalid checksum", string(a))
+	}
+	return nil
+}
+
+// ValidateFor checks that the address is a valid address of the network type
+func (a MainchainAddress) ValidateFor(networkType MainchainNetworkType) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if err := networkType.Validate(); err != nil {
+		return err
+	}
+	raw, _ := base32.StdEncoding.DecodeString(string(a))
+	if raw[0] != networkType.Version() {
+		return fmt.Errorf("mainchain address %s is not an address of the %s network", string(a), networkType)
+	}
+	return nil
+}
+
+// Empty returns true if the address is not set
+func (a MainchainAddress) Empty() bool {
+	return a == ""
+}
+
+func (a MainchainAddress) String() string {
+	return string(a)
+}
+
+// UnmarshalJSON canonicalizes the address and rejects malformed ones, an empty address is kept empty
+func (a *MainchainAddress) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*a = ""
+		return nil
+	}
+	address, err := NewMainchainAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = address
+	return nil
+}
+
+// MainchainPublicKey is a hex encoded ProximaX public key
+type MainchainPublicKey string
+
+// NewMainchainPublicKey parses a hex encoded ProximaX public key
+func NewMainchainPublicKey(publicKey string) (MainchainPublicKey, error) {
+	k := MainchainPublicKey(strings.ToUpper(publicKey))
+	return k, k.Validate()
+}
+
+// Validate checks that the public key is a canonical hex encoded ProximaX public key
+func (k MainchainPublicKey) Validate() error {
+	return validateUpperHex("mainchain public key", string(k), MainchainPublicKeyLength)
+}
+
+// Empty returns true if the public key is not set
+func (k MainchainPublicKey) Empty() bool {
+	return k == ""
+}
+
+func (k MainchainPublicKey) String() string {
+	return string(k)
+}
+
+// UnmarshalJSON canonicalizes the public key and rejects malformed ones, an empty key is kept empty
+func (k *MainchainPublicKey) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*k = ""
+		return nil
+	}
+	publicKey, err := NewMainchainPublicKey(s)
+	if err != nil {
+		return err
+	}
+	*k = publicKey
+	return nil
+}
+
+// MainchainTxHash is a hex encoded ProximaX transaction hash
+type MainchainTxHash string
+
+// NewMainchainTxHash parses a hex encoded ProximaX transaction hash
+func NewMainchainTxHash(hash string) (MainchainTxHash, error) {
+	h := MainchainTxHash(strings.ToUpper(hash))
+	return h, h.Validate()
+}
+
+// Validate checks that the hash is a canonical hex encoded ProximaX transaction hash
+func (h MainchainTxHash) Validate() error {
+	return validateUpperHex("mainchain tx hash", string(h), MainchainTxHashLength)
+}
+
+// Empty returns true if the hash is not set
+func (h MainchainTxHash) Empty() bool {
+	return h == ""
+}
+
+func (h MainchainTxHash) String() string {
+	return string(h)
+}
+
+// UnmarshalJSON canonicalizes the hash and rejects malformed ones, an empty hash is kept empty
+func (h *MainchainTxHash) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*h = ""
+		return nil
+	}
+	hash, err := NewMainchainTxHash(s)
+	if err != nil {
+		return err
+	}
+	*h = hash
+	return nil
+}
+
+func validateUpperHex(name, value string, length int) error {
+	if len(value) != length {
+		return fmt.Errorf("%s must be %d hex characters: %s", name, length, value)
+	}
+	if strings.ToUpper(value) != value {
+		return fmt.Errorf("%s must be upper case: %s", name, value)
+	}
+	if _, err := hex.DecodeString(value); err != nil {
+		return fmt.Errorf("%s is not hex encoded: %s", name, value)
+	}
+	return nil
+}
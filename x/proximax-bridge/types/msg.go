@@ -10,15 +10,17 @@ var _ sdk.Msg = &MsgPeg{}
 
 // MsgUnpeg - struct for unjailing jailed validator
 type MsgPeg struct {
-	Address         sdk.AccAddress `json:"address" yaml:"address"`
-	MainchainTxHash string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	Amount          sdk.Coins      `json:"amount" yaml:"amount"`
+	Address         sdk.AccAddress  `json:"address" yaml:"address"`
+	Instance        string          `json:"instance" yaml:"instance"`
+	MainchainTxHash MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Amount          sdk.Coins       `json:"amount" yaml:"amount"`
 }
 
 // NewMsgUnpeg creates a new MsgUnpeg instance
-func NewMsgPeg(address sdk.AccAddress, mainchainTxHash string, amount sdk.Coins) MsgPeg {
+func NewMsgPeg(address sdk.AccAddress, instance string, mainchainTxHash MainchainTxHash, amount sdk.Coins) MsgPeg {
 	return MsgPeg{
 		Address:         address,
+		Instance:        instance,
 		MainchainTxHash: mainchainTxHash,
 		Amount:          amount,
 	}
@@ -44,6 +46,12 @@ func (msg MsgPeg) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.MainchainTxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
 	return nil
 }
 
@@ -55,18 +63,25 @@ var _ sdk.Msg = &MsgPegClaim{}
 
 // MsgPegClaim - struct for unjailing jailed validator
 type MsgPegClaim struct {
-	Address          sdk.AccAddress `json:"address" yaml:"address"`
-	MainchainTxHash  string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	Amount           sdk.Coins      `json:"amount" yaml:"amount"`
-	Remainning       int64          `json:"remaiining" yaml:"remaiining"`
-	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Address         sdk.AccAddress  `json:"address" yaml:"address"`
+	Instance        string          `json:"instance" yaml:"instance"`
+	Vault           string          `json:"vault" yaml:"vault"`
+	MainchainTxHash MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	// MainchainSender is the account which signed the deposit on ProximaX
+	MainchainSender  MainchainAddress `json:"mainchain_sender" yaml:"mainchain_sender"`
+	Amount           sdk.Coins        `json:"amount" yaml:"amount"`
+	Remainning       int64            `json:"remaiining" yaml:"remaiining"`
+	ValidatorAddress sdk.ValAddress   `json:"validator_address" yaml:"validator_address"`
 }
 
 // NewMsgPegClaim creates a new MsgPegClaim instance
-func NewMsgPegClaim(address sdk.AccAddress, mainchainTxHash string, amount sdk.Coins, remaiining int64, validatorAddress sdk.ValAddress) MsgPegClaim {
+func NewMsgPegClaim(address sdk.AccAddress, instance, vault string, mainchainTxHash MainchainTxHash, mainchainSender MainchainAddress, amount sdk.Coins, remaiining int64, validatorAddress sdk.ValAddress) MsgPegClaim {
 	return MsgPegClaim{
 		Address:          address,
+		Instance:         instance,
+		Vault:            vault,
 		MainchainTxHash:  mainchainTxHash,
+		MainchainSender:  mainchainSender,
 		Amount:           amount,
 		Remainning:       remaiining,
 		ValidatorAddress: validatorAddress,
@@ -93,6 +108,18 @@ func (msg MsgPegClaim) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.MainchainTxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Vault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
+	if err := msg.MainchainSender.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainAddress, err.Error())
+	}
 	return nil
 }
 
@@ -102,22 +129,36 @@ var _ sdk.Msg = &MsgUnpeg{}
 
 // MsgUnpeg - struct for unjailing jailed validator
 type MsgUnpeg struct {
-	Address              sdk.AccAddress `json:"address" yaml:"address"`
-	MainchainAddress     string         `json:"mainchain_address" yaml:"mainchain_address"`
-	Amount               sdk.Coins      `json:"amount" yaml:"amount"`
-	FirstCosignerAddress sdk.ValAddress `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Address              sdk.AccAddress   `json:"address" yaml:"address"`
+	Instance             string           `json:"instance" yaml:"instance"`
+	MainchainAddress     MainchainAddress `json:"mainchain_address" yaml:"mainchain_address"`
+	Amount               sdk.Coins        `json:"amount" yaml:"amount"`
+	FirstCosignerAddress sdk.ValAddress   `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Recipients           []UnpegRecipient `json:"recipients,omitempty" yaml:"recipients,omitempty"`
 }
 
 // NewMsgUnpeg creates a new MsgUnpeg instance
-func NewMsgUnpeg(address sdk.AccAddress, mainchainAddress string, amount sdk.Coins, firstCosignerAddress sdk.ValAddress) MsgUnpeg {
+func NewMsgUnpeg(address sdk.AccAddress, instance string, mainchainAddress MainchainAddress, amount sdk.Coins, firstCosignerAddress sdk.ValAddress) MsgUnpeg {
 	return MsgUnpeg{
 		Address:              address,
+		Instance:             instance,
 		MainchainAddress:     mainchainAddress,
 		Amount:               amount,
 		FirstCosignerAddress: firstCosignerAddress,
 	}
 }
 
+// NewMsgUnpegToRecipients creates a new MsgUnpeg instance paying out to several ProximaX addresses in a single aggregate
+func NewMsgUnpegToRecipients(address sdk.AccAddress, instance string, recipients []UnpegRecipient, firstCosignerAddress sdk.ValAddress) MsgUnpeg {
+	return MsgUnpeg{
+		Address:              address,
+		Instance:             instance,
+		Amount:               SumRecipients(recipients),
+		FirstCosignerAddress: firstCosignerAddress,
+		Recipients:           recipients,
+	}
+}
+
 const unpegConst = "unpeg"
 
 // nolint
@@ -138,6 +179,25 @@ func (msg MsgUnpeg) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if !msg.Amount.IsValid() || msg.Amount.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Amount.String())
+	}
+	// either the mainchain address receives the whole amount or the recipients share it
+	if len(msg.Recipients) == 0 {
+		if err := msg.MainchainAddress.Validate(); err != nil {
+			return sdkerrors.Wrap(ErrInvalidMainchainAddress, err.Error())
+		}
+	} else {
+		if !msg.MainchainAddress.Empty() {
+			return sdkerrors.Wrap(ErrInvalidMainchainAddress, "mainchain address must be empty when recipients are given")
+		}
+		if err := validateRecipients(msg.Recipients, msg.Amount); err != nil {
+			return sdkerrors.Wrap(ErrInvalidMainchainAddress, err.Error())
+		}
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
 	return nil
 }
 
@@ -145,19 +205,25 @@ var _ sdk.Msg = &MsgRecordUnpeg{}
 
 // MsgUnpeg - struct for unjailing jailed validator
 type MsgRecordUnpeg struct {
-	Address                sdk.AccAddress `json:"address" yaml:"address"`
-	MainchainTxHash        string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	Amount                 sdk.Coins      `json:"amount" yaml:"amount"`
-	FirstCosignerPublicKey string         `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
-	ValidatorAddress       sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	Address                sdk.AccAddress     `json:"address" yaml:"address"`
+	Instance               string             `json:"instance" yaml:"instance"`
+	Vault                  string             `json:"vault" yaml:"vault"`
+	MainchainTxHash        MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Amount                 sdk.Coins          `json:"amount" yaml:"amount"`
+	Recipients             []UnpegRecipient   `json:"recipients" yaml:"recipients"`
+	FirstCosignerPublicKey MainchainPublicKey `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
+	ValidatorAddress       sdk.ValAddress     `json:"validator_address" yaml:"validator_address"`
 }
 
 // NewMsgUnpeg creates a new MsgUnpeg instance
-func NewMsgRecordUnpeg(address sdk.AccAddress, mainchainTxHash string, amount sdk.Coins, firstCosignerPublicKey string, validatorAddress sdk.ValAddress) MsgRecordUnpeg {
+func NewMsgRecordUnpeg(address sdk.AccAddress, instance, vault string, mainchainTxHash MainchainTxHash, amount sdk.Coins, recipients []UnpegRecipient, firstCosignerPublicKey MainchainPublicKey, validatorAddress sdk.ValAddress) MsgRecordUnpeg {
 	return MsgRecordUnpeg{
 		Address:                address,
+		Instance:               instance,
+		Vault:                  vault,
 		MainchainTxHash:        mainchainTxHash,
 		Amount:                 amount,
+		Recipients:             recipients,
 		FirstCosignerPublicKey: firstCosignerPublicKey,
 		ValidatorAddress:       validatorAddress,
 	}
@@ -183,6 +249,23 @@ func (msg MsgRecordUnpeg) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.MainchainTxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := msg.FirstCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Vault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
+	if len(msg.Recipients) > 0 {
+		if err := validateRecipients(msg.Recipients, msg.Amount); err != nil {
+			return sdkerrors.Wrap(ErrInvalidMainchainAddress, err.Error())
+		}
+	}
 	return nil
 }
 
@@ -190,15 +273,17 @@ var _ sdk.Msg = &MsgNotifyCosigned{}
 
 // MsgUnpeg - struct for unjailing jailed validator
 type MsgNotifyCosigned struct {
-	Address           sdk.ValAddress `json:"address" yaml:"address"`
-	MainchainTxHash   string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	CosignerPublicKey string         `json:"cosigner_public_key" yaml:"cosigner_public_key"`
+	Address           sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance          string             `json:"instance" yaml:"instance"`
+	MainchainTxHash   MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	CosignerPublicKey MainchainPublicKey `json:"cosigner_public_key" yaml:"cosigner_public_key"`
 }
 
 // NewMsgUnpeg creates a new MsgUnpeg instance
-func NewMsgNotifyCosigned(address sdk.ValAddress, mainchainTxHash, cosignerPublicKey string) MsgNotifyCosigned {
+func NewMsgNotifyCosigned(address sdk.ValAddress, instance string, mainchainTxHash MainchainTxHash, cosignerPublicKey MainchainPublicKey) MsgNotifyCosigned {
 	return MsgNotifyCosigned{
 		Address:           address,
+		Instance:          instance,
 		MainchainTxHash:   mainchainTxHash,
 		CosignerPublicKey: cosignerPublicKey,
 	}
@@ -224,6 +309,15 @@ func (msg MsgNotifyCosigned) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.MainchainTxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := msg.CosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
 	return nil
 }
 
@@ -233,15 +327,17 @@ var _ sdk.Msg = &MsgNotCosignedClaim{}
 
 // MsgNotCosignedClaim - struct for unjailing jailed validator
 type MsgNotCosignedClaim struct {
-	Address sdk.ValAddress `json:"address" yaml:"address"`
-	TxHash  string         `json:"tx_hash" yaml:"tx_hash"`
+	Address  sdk.ValAddress  `json:"address" yaml:"address"`
+	Instance string          `json:"instance" yaml:"instance"`
+	TxHash   MainchainTxHash `json:"tx_hash" yaml:"tx_hash"`
 }
 
 // NewMsgNotCosignedClaim creates a new MsgNotCosignedClaim instance
-func NewMsgNotCosignedClaim(address sdk.ValAddress, txHash string) MsgNotCosignedClaim {
+func NewMsgNotCosignedClaim(address sdk.ValAddress, instance string, txHash MainchainTxHash) MsgNotCosignedClaim {
 	return MsgNotCosignedClaim{
-		Address: address,
-		TxHash:  txHash,
+		Address:  address,
+		Instance: instance,
+		TxHash:   txHash,
 	}
 }
 
@@ -265,6 +361,12 @@ func (msg MsgNotCosignedClaim) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
 	return nil
 }
 
@@ -275,15 +377,19 @@ var _ sdk.Msg = &MsgRequestInvitation{}
 
 // MsgRequestInvitation - struct for unjailing jailed validator
 type MsgRequestInvitation struct {
-	Address              sdk.ValAddress `json:"address" yaml:"address"`
-	NewCosignerPublicKey string         `json:"new_cosigner_public_key" yaml:"new_cosigner_public_key"`
-	FirstCosignerAddress sdk.ValAddress `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	Address              sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance             string             `json:"instance" yaml:"instance"`
+	Vault                string             `json:"vault" yaml:"vault"`
+	NewCosignerPublicKey MainchainPublicKey `json:"new_cosigner_public_key" yaml:"new_cosigner_public_key"`
+	FirstCosignerAddress sdk.ValAddress     `json:"first_cosigner_address" yaml:"first_cosigner_address"`
 }
 
 // NewMsgRequestInvitation creates a new MsgRequestInvitation instance
-func NewMsgRequestInvitation(address sdk.ValAddress, newCosignerPublicKey string, firstCosignerAddress sdk.ValAddress) MsgRequestInvitation {
+func NewMsgRequestInvitation(address sdk.ValAddress, instance, vault string, newCosignerPublicKey MainchainPublicKey, firstCosignerAddress sdk.ValAddress) MsgRequestInvitation {
 	return MsgRequestInvitation{
 		Address:              address,
+		Instance:             instance,
+		Vault:                vault,
 		NewCosignerPublicKey: newCosignerPublicKey,
 		FirstCosignerAddress: firstCosignerAddress,
 	}
@@ -309,6 +415,15 @@ func (msg MsgRequestInvitation) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.NewCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Vault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
 	return nil
 }
 
@@ -316,17 +431,21 @@ var _ sdk.Msg = &MsgPendingRequestInvitation{}
 
 // MsgRequestInvitation - struct for unjailing jailed validator
 type MsgPendingRequestInvitation struct {
-	Address                sdk.ValAddress `json:"address" yaml:"address"`
-	NewCosignerPublicKey   string         `json:"new_cosigner_public_key" yaml:"new_cosigner_public_key"`
-	FirstCosignerAddress   sdk.ValAddress `json:"first_cosigner_address" yaml:"first_cosigner_address"`
-	FirstCosignerPublicKey string         `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
-	TxHash                 string         `json:"tx_hash" yaml:"tx_hash"`
+	Address                sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance               string             `json:"instance" yaml:"instance"`
+	Vault                  string             `json:"vault" yaml:"vault"`
+	NewCosignerPublicKey   MainchainPublicKey `json:"new_cosigner_public_key" yaml:"new_cosigner_public_key"`
+	FirstCosignerAddress   sdk.ValAddress     `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	FirstCosignerPublicKey MainchainPublicKey `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
+	TxHash                 MainchainTxHash    `json:"tx_hash" yaml:"tx_hash"`
 }
 
 // NewMsgRequestInvitation creates a new MsgRequestInvitation instance
-func NewMsgPendingRequestInvitation(address sdk.ValAddress, newCosignerPublicKey string, firstCosignerAddress sdk.ValAddress, firstCosignerPublicKey, txHash string) MsgPendingRequestInvitation {
+func NewMsgPendingRequestInvitation(address sdk.ValAddress, instance, vault string, newCosignerPublicKey MainchainPublicKey, firstCosignerAddress sdk.ValAddress, firstCosignerPublicKey MainchainPublicKey, txHash MainchainTxHash) MsgPendingRequestInvitation {
 	return MsgPendingRequestInvitation{
 		Address:                address,
+		Instance:               instance,
+		Vault:                  vault,
 		NewCosignerPublicKey:   newCosignerPublicKey,
 		FirstCosignerAddress:   firstCosignerAddress,
 		FirstCosignerPublicKey: firstCosignerPublicKey,
@@ -354,6 +473,21 @@ func (msg MsgPendingRequestInvitation) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.NewCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := msg.FirstCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Vault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
 	return nil
 }
 
@@ -361,15 +495,17 @@ var _ sdk.Msg = &MsgNewCosignerInvited{}
 
 // MsgProximaXTransactionStatus - struct for unjailing jailed validator
 type MsgNewCosignerInvited struct {
-	Address            sdk.ValAddress `json:"address" yaml:"address"`
-	TxHash             string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
-	MainchainPublicKey string         `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+	Address            sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance           string             `json:"instance" yaml:"instance"`
+	TxHash             MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
 }
 
 // NewMsgRequestInvitation creates a new MsgRequestInvitation instance
-func NewMsgNewCosignerInvited(address sdk.ValAddress, txHash, pubKey string) MsgNewCosignerInvited {
+func NewMsgNewCosignerInvited(address sdk.ValAddress, instance string, txHash MainchainTxHash, pubKey MainchainPublicKey) MsgNewCosignerInvited {
 	return MsgNewCosignerInvited{
 		Address:            address,
+		Instance:           instance,
 		TxHash:             txHash,
 		MainchainPublicKey: pubKey,
 	}
@@ -395,6 +531,15 @@ func (msg MsgNewCosignerInvited) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := msg.MainchainPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
 	return nil
 }
 
@@ -403,15 +548,17 @@ var _ sdk.Msg = &MsgConfirmedInvitation{}
 
 // MsgProximaXTransactionStatus - struct for unjailing jailed validator
 type MsgConfirmedInvitation struct {
-	Address sdk.ValAddress `json:"address" yaml:"address"`
-	TxHash  string         `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	Address  sdk.ValAddress  `json:"address" yaml:"address"`
+	Instance string          `json:"instance" yaml:"instance"`
+	TxHash   MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
 }
 
 // NewMsgRequestInvitation creates a new MsgRequestInvitation instance
-func NewMsgConfirmedInvitation(address sdk.ValAddress, txHash string) MsgConfirmedInvitation {
+func NewMsgConfirmedInvitation(address sdk.ValAddress, instance string, txHash MainchainTxHash) MsgConfirmedInvitation {
 	return MsgConfirmedInvitation{
-		Address: address,
-		TxHash:  txHash,
+		Address:  address,
+		Instance: instance,
+		TxHash:   txHash,
 	}
 }
 
@@ -435,6 +582,594 @@ func (msg MsgConfirmedInvitation) ValidateBasic() error {
 	if msg.Address.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
 	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return nil
+}
+
+var _ sdk.Msg = &MsgRequestRemoval{}
+
+// MsgRequestRemoval - struct for requesting the removal of a cosigner from the multisig
+type MsgRequestRemoval struct {
+	Address              sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance             string             `json:"instance" yaml:"instance"`
+	CosignerPublicKey    MainchainPublicKey `json:"cosigner_public_key" yaml:"cosigner_public_key"`
+	FirstCosignerAddress sdk.ValAddress     `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+}
+
+// NewMsgRequestRemoval creates a new MsgRequestRemoval instance
+func NewMsgRequestRemoval(address sdk.ValAddress, instance string, cosignerPublicKey MainchainPublicKey, firstCosignerAddress sdk.ValAddress) MsgRequestRemoval {
+	return MsgRequestRemoval{
+		Address:              address,
+		Instance:             instance,
+		CosignerPublicKey:    cosignerPublicKey,
+		FirstCosignerAddress: firstCosignerAddress,
+	}
+}
+
+const requestRemovalConst = "request_removal"
+
+// nolint
+func (msg MsgRequestRemoval) Route() string { return RouterKey }
+func (msg MsgRequestRemoval) Type() string  { return requestRemovalConst }
+func (msg MsgRequestRemoval) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgRequestRemoval) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgRequestRemoval) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if msg.FirstCosignerAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing first cosigner address")
+	}
+	if err := msg.CosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return nil
+}
+
+var _ sdk.Msg = &MsgPendingRequestRemoval{}
+
+// MsgPendingRequestRemoval - struct for recording the multisig modification announced for a removal request
+type MsgPendingRequestRemoval struct {
+	Address                sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance               string             `json:"instance" yaml:"instance"`
+	CosignerPublicKey      MainchainPublicKey `json:"cosigner_public_key" yaml:"cosigner_public_key"`
+	FirstCosignerAddress   sdk.ValAddress     `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+	FirstCosignerPublicKey MainchainPublicKey `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
+	TxHash                 MainchainTxHash    `json:"tx_hash" yaml:"tx_hash"`
+}
+
+// NewMsgPendingRequestRemoval creates a new MsgPendingRequestRemoval instance
+func NewMsgPendingRequestRemoval(address sdk.ValAddress, instance string, cosignerPublicKey MainchainPublicKey, firstCosignerAddress sdk.ValAddress, firstCosignerPublicKey MainchainPublicKey, txHash MainchainTxHash) MsgPendingRequestRemoval {
+	return MsgPendingRequestRemoval{
+		Address:                address,
+		Instance:               instance,
+		CosignerPublicKey:      cosignerPublicKey,
+		FirstCosignerAddress:   firstCosignerAddress,
+		FirstCosignerPublicKey: firstCosignerPublicKey,
+		TxHash:                 txHash,
+	}
+}
+
+const pendingRequestRemovalConst = "pending_request_removal"
+
+// nolint
+func (msg MsgPendingRequestRemoval) Route() string { return RouterKey }
+func (msg MsgPendingRequestRemoval) Type() string  { return pendingRequestRemovalConst }
+func (msg MsgPendingRequestRemoval) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.FirstCosignerAddress)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgPendingRequestRemoval) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgPendingRequestRemoval) ValidateBasic() error {
+	if msg.FirstCosignerAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing first cosigner address")
+	}
+	if err := msg.CosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := msg.FirstCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return nil
+}
+
+var _ sdk.Msg = &MsgConfirmedRemoval{}
+
+// MsgConfirmedRemoval - struct for notifying that a removal has been confirmed on the mainchain
+type MsgConfirmedRemoval struct {
+	Address  sdk.ValAddress  `json:"address" yaml:"address"`
+	Instance string          `json:"instance" yaml:"instance"`
+	TxHash   MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// NewMsgConfirmedRemoval creates a new MsgConfirmedRemoval instance
+func NewMsgConfirmedRemoval(address sdk.ValAddress, instance string, txHash MainchainTxHash) MsgConfirmedRemoval {
+	return MsgConfirmedRemoval{
+		Address:  address,
+		Instance: instance,
+		TxHash:   txHash,
+	}
+}
+
+const confirmedRemovalConst = "confirmed_removal"
+
+// nolint
+func (msg MsgConfirmedRemoval) Route() string { return RouterKey }
+func (msg MsgConfirmedRemoval) Type() string  { return confirmedRemovalConst }
+func (msg MsgConfirmedRemoval) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgConfirmedRemoval) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgConfirmedRemoval) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return nil
+}
+
+var _ sdk.Msg = &MsgRequestVaultTransfer{}
+
+// MsgRequestVaultTransfer - struct for requesting a rebalancing transfer between two vaults of an instance,
+// announced by the sender as the first cosigner of the source vault
+type MsgRequestVaultTransfer struct {
+	Address   sdk.ValAddress `json:"address" yaml:"address"`
+	Instance  string         `json:"instance" yaml:"instance"`
+	FromVault string         `json:"from_vault" yaml:"from_vault"`
+	ToVault   string         `json:"to_vault" yaml:"to_vault"`
+	Amount    sdk.Coins      `json:"amount" yaml:"amount"`
+}
+
+// NewMsgRequestVaultTransfer creates a new MsgRequestVaultTransfer instance
+func NewMsgRequestVaultTransfer(address sdk.ValAddress, instance, fromVault, toVault string, amount sdk.Coins) MsgRequestVaultTransfer {
+	return MsgRequestVaultTransfer{
+		Address:   address,
+		Instance:  instance,
+		FromVault: fromVault,
+		ToVault:   toVault,
+		Amount:    amount,
+	}
+}
+
+const requestVaultTransferConst = "request_vault_transfer"
+
+// nolint
+func (msg MsgRequestVaultTransfer) Route() string { return RouterKey }
+func (msg MsgRequestVaultTransfer) Type() string  { return requestVaultTransferConst }
+func (msg MsgRequestVaultTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgRequestVaultTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgRequestVaultTransfer) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return validateVaultTransfer(msg.FromVault, msg.ToVault, msg.Amount)
+}
+
+var _ sdk.Msg = &MsgRecordVaultTransfer{}
+
+// MsgRecordVaultTransfer - struct for recording a transfer between vaults announced on the mainchain
+type MsgRecordVaultTransfer struct {
+	Address                sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance               string             `json:"instance" yaml:"instance"`
+	MainchainTxHash        MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	FromVault              string             `json:"from_vault" yaml:"from_vault"`
+	ToVault                string             `json:"to_vault" yaml:"to_vault"`
+	Amount                 sdk.Coins          `json:"amount" yaml:"amount"`
+	FirstCosignerPublicKey MainchainPublicKey `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
+	// Sweep is true when the transfer is the aggregate of a sweep the zone asked for
+	Sweep bool `json:"sweep" yaml:"sweep"`
+}
+
+// NewMsgRecordVaultTransfer creates a new MsgRecordVaultTransfer instance
+func NewMsgRecordVaultTransfer(address sdk.ValAddress, instance string, mainchainTxHash MainchainTxHash, fromVault, toVault string, amount sdk.Coins, firstCosignerPublicKey MainchainPublicKey, sweep bool) MsgRecordVaultTransfer {
+	return MsgRecordVaultTransfer{
+		Address:                address,
+		Instance:               instance,
+		MainchainTxHash:        mainchainTxHash,
+		FromVault:              fromVault,
+		ToVault:                toVault,
+		Amount:                 amount,
+		FirstCosignerPublicKey: firstCosignerPublicKey,
+		Sweep:                  sweep,
+	}
+}
+
+const recordVaultTransferConst = "record_vault_transfer"
+
+// nolint
+func (msg MsgRecordVaultTransfer) Route() string { return RouterKey }
+func (msg MsgRecordVaultTransfer) Type() string  { return recordVaultTransferConst }
+func (msg MsgRecordVaultTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgRecordVaultTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgRecordVaultTransfer) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := msg.MainchainTxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := msg.FirstCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	return validateVaultTransfer(msg.FromVault, msg.ToVault, msg.Amount)
+}
+
+var _ sdk.Msg = &MsgConfirmedVaultTransfer{}
+
+// MsgConfirmedVaultTransfer - struct for notifying that a transfer between vaults has been confirmed on the mainchain
+type MsgConfirmedVaultTransfer struct {
+	Address  sdk.ValAddress  `json:"address" yaml:"address"`
+	Instance string          `json:"instance" yaml:"instance"`
+	TxHash   MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// NewMsgConfirmedVaultTransfer creates a new MsgConfirmedVaultTransfer instance
+func NewMsgConfirmedVaultTransfer(address sdk.ValAddress, instance string, txHash MainchainTxHash) MsgConfirmedVaultTransfer {
+	return MsgConfirmedVaultTransfer{
+		Address:  address,
+		Instance: instance,
+		TxHash:   txHash,
+	}
+}
+
+const confirmedVaultTransferConst = "confirmed_vault_transfer"
+
+// nolint
+func (msg MsgConfirmedVaultTransfer) Route() string { return RouterKey }
+func (msg MsgConfirmedVaultTransfer) Type() string  { return confirmedVaultTransferConst }
+func (msg MsgConfirmedVaultTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgConfirmedVaultTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgConfirmedVaultTransfer) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return nil
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgLockFundsClaim{}
+
+// MsgLockFundsClaim is the claim of a validator on the outcome of the funds locked for an aggregate
+// announced from a vault, by the cosigner with the initiator public key
+type MsgLockFundsClaim struct {
+	Address            sdk.ValAddress     `json:"address" yaml:"address"`
+	Instance           string             `json:"instance" yaml:"instance"`
+	TxHash             MainchainTxHash    `json:"tx_hash" yaml:"tx_hash"`
+	InitiatorPublicKey MainchainPublicKey `json:"initiator_public_key" yaml:"initiator_public_key"`
+	Outcome            string             `json:"outcome" yaml:"outcome"`
+}
+
+// NewMsgLockFundsClaim creates a new MsgLockFundsClaim instance
+func NewMsgLockFundsClaim(address sdk.ValAddress, instance string, txHash MainchainTxHash, initiatorPublicKey MainchainPublicKey, outcome string) MsgLockFundsClaim {
+	return MsgLockFundsClaim{
+		Address:            address,
+		Instance:           instance,
+		TxHash:             txHash,
+		InitiatorPublicKey: initiatorPublicKey,
+		Outcome:            outcome,
+	}
+}
+
+const lockFundsClaimConst = "lock_funds_claim"
+
+// nolint
+func (msg MsgLockFundsClaim) Route() string { return RouterKey }
+func (msg MsgLockFundsClaim) Type() string  { return lockFundsClaimConst }
+func (msg MsgLockFundsClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgLockFundsClaim) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgLockFundsClaim) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := msg.InitiatorPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	if err := ValidateLockFundsOutcome(msg.Outcome); err != nil {
+		return sdkerrors.Wrap(ErrInvalidLockFundsOutcome, err.Error())
+	}
+	return nil
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgCancelUnpeg{}
+
+// MsgCancelUnpeg takes an unpeg of its sender out of the withdrawal queue and refunds its coins
+type MsgCancelUnpeg struct {
+	Address sdk.AccAddress `json:"address" yaml:"address"`
+	ID      uint64         `json:"id" yaml:"id"`
+}
+
+// NewMsgCancelUnpeg creates a new MsgCancelUnpeg instance
+func NewMsgCancelUnpeg(address sdk.AccAddress, id uint64) MsgCancelUnpeg {
+	return MsgCancelUnpeg{
+		Address: address,
+		ID:      id,
+	}
+}
+
+const cancelUnpegConst = "cancel_unpeg"
+
+// nolint
+func (msg MsgCancelUnpeg) Route() string { return RouterKey }
+func (msg MsgCancelUnpeg) Type() string  { return cancelUnpegConst }
+func (msg MsgCancelUnpeg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgCancelUnpeg) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgCancelUnpeg) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if msg.ID == 0 {
+		return sdkerrors.Wrap(ErrQueuedUnpegNotFound, "id must be positive")
+	}
+	return nil
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgVetoUnpeg{}
+
+// MsgVetoUnpeg is the veto of a guardian on an unpeg held back by the time lock, which refunds it
+type MsgVetoUnpeg struct {
+	Guardian sdk.AccAddress `json:"guardian" yaml:"guardian"`
+	ID       uint64         `json:"id" yaml:"id"`
+}
+
+// NewMsgVetoUnpeg creates a new MsgVetoUnpeg instance
+func NewMsgVetoUnpeg(guardian sdk.AccAddress, id uint64) MsgVetoUnpeg {
+	return MsgVetoUnpeg{
+		Guardian: guardian,
+		ID:       id,
+	}
+}
+
+const vetoUnpegConst = "veto_unpeg"
+
+// nolint
+func (msg MsgVetoUnpeg) Route() string { return RouterKey }
+func (msg MsgVetoUnpeg) Type() string  { return vetoUnpegConst }
+func (msg MsgVetoUnpeg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Guardian}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgVetoUnpeg) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgVetoUnpeg) ValidateBasic() error {
+	if msg.Guardian.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing guardian address")
+	}
+	if msg.ID == 0 {
+		return sdkerrors.Wrap(ErrQueuedUnpegNotFound, "id must be positive")
+	}
+	return nil
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgRecordUnpegBatch{}
+
+// MsgRecordUnpegBatch records the aggregate the first cosigner of an unpeg batch announced on the mainchain
+type MsgRecordUnpegBatch struct {
+	ValidatorAddress       sdk.ValAddress     `json:"validator_address" yaml:"validator_address"`
+	Instance               string             `json:"instance" yaml:"instance"`
+	BatchID                uint64             `json:"batch_id" yaml:"batch_id"`
+	MainchainTxHash        MainchainTxHash    `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	FirstCosignerPublicKey MainchainPublicKey `json:"first_cosigner_public_key" yaml:"first_cosigner_public_key"`
+}
+
+// NewMsgRecordUnpegBatch creates a new MsgRecordUnpegBatch instance
+func NewMsgRecordUnpegBatch(validatorAddress sdk.ValAddress, instance string, batchID uint64, mainchainTxHash MainchainTxHash, firstCosignerPublicKey MainchainPublicKey) MsgRecordUnpegBatch {
+	return MsgRecordUnpegBatch{
+		ValidatorAddress:       validatorAddress,
+		Instance:               instance,
+		BatchID:                batchID,
+		MainchainTxHash:        mainchainTxHash,
+		FirstCosignerPublicKey: firstCosignerPublicKey,
+	}
+}
+
+const recordUnpegBatchConst = "record_unpeg_batch"
+
+// nolint
+func (msg MsgRecordUnpegBatch) Route() string { return RouterKey }
+func (msg MsgRecordUnpegBatch) Type() string  { return recordUnpegBatchConst }
+func (msg MsgRecordUnpegBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddress)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgRecordUnpegBatch) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgRecordUnpegBatch) ValidateBasic() error {
+	if msg.ValidatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if msg.BatchID == 0 {
+		return sdkerrors.Wrap(ErrUnpegBatchNotFound, "batch id must be positive")
+	}
+	if err := msg.MainchainTxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := msg.FirstCosignerPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	return nil
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgConfirmedUnpegBatch{}
+
+// MsgConfirmedUnpegBatch - struct for notifying that the aggregate of an unpeg batch has been confirmed on the mainchain
+type MsgConfirmedUnpegBatch struct {
+	Address  sdk.ValAddress  `json:"address" yaml:"address"`
+	Instance string          `json:"instance" yaml:"instance"`
+	TxHash   MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+}
+
+// NewMsgConfirmedUnpegBatch creates a new MsgConfirmedUnpegBatch instance
+func NewMsgConfirmedUnpegBatch(address sdk.ValAddress, instance string, txHash MainchainTxHash) MsgConfirmedUnpegBatch {
+	return MsgConfirmedUnpegBatch{
+		Address:  address,
+		Instance: instance,
+		TxHash:   txHash,
+	}
+}
+
+const confirmedUnpegBatchConst = "confirmed_unpeg_batch"
+
+// nolint
+func (msg MsgConfirmedUnpegBatch) Route() string { return RouterKey }
+func (msg MsgConfirmedUnpegBatch) Type() string  { return confirmedUnpegBatchConst }
+func (msg MsgConfirmedUnpegBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Address)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgConfirmedUnpegBatch) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgConfirmedUnpegBatch) ValidateBasic() error {
+	if msg.Address.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := msg.TxHash.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainTxHash, err.Error())
+	}
+	if err := ValidateInstanceName(msg.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	return nil
+}
+
+func validateVaultTransfer(fromVault, toVault string, amount sdk.Coins) error {
+	if err := ValidateInstanceName(fromVault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
+	if err := ValidateInstanceName(toVault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
+	if fromVault == toVault {
+		return sdkerrors.Wrapf(ErrInvalidVault, "transfer from vault %s to itself", fromVault)
+	}
+	if !amount.IsValid() || amount.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amount.String())
+	}
 	return nil
 }
 
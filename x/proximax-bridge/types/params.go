@@ -2,22 +2,61 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/peggy/x/oracle"
 )
 
 // Default parameter namespace
 const (
 	DefaultParamspace = ModuleName
 	// TODO: Define your default parameters
+
+	// ClaimWeightingValidators weighs oracle claims across all bonded validators
+	ClaimWeightingValidators = "validators"
+	// ClaimWeightingCosigners weighs oracle claims across bonded validators registered as cosigners only
+	ClaimWeightingCosigners = "cosigners"
+
+	// DefaultProphecyExpiry is about a day with 6 second blocks
+	DefaultProphecyExpiry int64 = 14400
+
+	// MaxCosigners is the maximum number of cosigners of a ProximaX multisig account, that is of a vault
+	MaxCosigners = 10
+
+	// DefaultMainchainNetworkType is the ProximaX public network
+	DefaultMainchainNetworkType MainchainNetworkType = "public"
+
+	// MainchainAddressLength is the length of a base32 encoded ProximaX address without dashes
+	MainchainAddressLength = 40
+	// MainchainPublicKeyLength is the length of a hex encoded ProximaX public key
+	MainchainPublicKeyLength = 64
+	// MainchainTxHashLength is the length of a hex encoded ProximaX transaction hash
+	MainchainTxHashLength = 64
 )
 
 // Parameter store keys
 var (
 	// TODO: Define your keys for the parameter store
 	// KeyParamName          = []byte("ParamName")
-	KeyMainchainMultisigAddress = []byte("MainchainMultisigAddress")
-	KeyCosigners                = []byte("Cosigners")
+	KeyInstances                = []byte("Instances")
+	KeyConsensusNeeded          = []byte("ConsensusNeeded")
+	KeyClaimWeighting           = []byte("ClaimWeighting")
+	KeyProphecyExpiry           = []byte("ProphecyExpiry")
+	KeyFaultyClaimSlashFraction = []byte("FaultyClaimSlashFraction")
+	KeyMultisigApproval         = []byte("MultisigApproval")
+	KeyColdMultisigApproval     = []byte("ColdMultisigApproval")
+	KeyHotVaultLimits           = []byte("HotVaultLimits")
+	KeyFees                     = []byte("Fees")
+	KeyFeeDistribution          = []byte("FeeDistribution")
+	KeyLockFundsCost            = []byte("LockFundsCost")
+	KeyLimits                   = []byte("Limits")
+	KeyVolumeWindow             = []byte("VolumeWindow")
+	KeyTimeLock                 = []byte("TimeLock")
+	KeyAddressFilter            = []byte("AddressFilter")
+	KeyUnpegBatchWindow         = []byte("UnpegBatchWindow")
 )
 
 // ParamKeyTable for proximax-bridge module
@@ -29,21 +68,140 @@ func ParamKeyTable() params.KeyTable {
 type Params struct {
 	// TODO: Add your Paramaters to the Paramter struct
 	// KeyParamName string `json:"key_param_name"`
-	MainchainMultisigAddress string     `json:"mainchain_address"`
-	Cosigners                []Cosigner `json:"cosigners"`
+	Instances                []BridgeInstance `json:"instances"`
+	ConsensusNeeded          ConsensusNeeded  `json:"consensus_needed"`
+	ClaimWeighting           string           `json:"claim_weighting"`
+	ProphecyExpiry           int64            `json:"prophecy_expiry"`
+	FaultyClaimSlashFraction sdk.Dec          `json:"faulty_claim_slash_fraction"`
+	MultisigApproval         MultisigApproval `json:"multisig_approval"`
+	// ColdMultisigApproval is the approval of cold vaults, at least as strict as the one of hot vaults
+	ColdMultisigApproval MultisigApproval `json:"cold_multisig_approval"`
+	HotVaultLimits       HotVaultLimits   `json:"hot_vault_limits"`
+	Fees                 []DenomFee       `json:"fees"`
+	FeeDistribution      FeeDistribution  `json:"fee_distribution"`
+	// LockFundsCost is what a cosigner is reimbursed for the funds locked for an aggregate which expired
+	LockFundsCost sdk.Coins    `json:"lock_funds_cost"`
+	Limits        []DenomLimit `json:"limits"`
+	// VolumeWindow is the duration of block time the volume caps of the limits apply over
+	VolumeWindow time.Duration `json:"volume_window"`
+	TimeLock     TimeLock      `json:"time_lock"`
+	// AddressFilter is the list of the addresses blocked from bridging, or allowed to in the allowlist mode
+	AddressFilter AddressFilter `json:"address_filter"`
+	// UnpegBatchWindow is the number of blocks whose unpegs a vault pays out in a single aggregate, zero batches none
+	UnpegBatchWindow int64 `json:"unpeg_batch_window"`
 }
 
 type Cosigner struct {
-	ValidatorAddress   string `json:"validator_address"`
-	MainchainPublicKey string `json:"mainchain_public_key"`
+	ValidatorAddress   string             `json:"validator_address"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key"`
+	// Vault is the name of the vault of the instance the cosigner cosigns for
+	Vault string `json:"vault"`
+}
+
+// ConsensusNeeded is the share of voting power which has to agree on a claim
+// before its prophecy is finalized, for each kind of claim.
+type ConsensusNeeded struct {
+	PegClaim         sdk.Dec `json:"peg_claim" yaml:"peg_claim"`
+	NotCosignedClaim sdk.Dec `json:"not_cosigned_claim" yaml:"not_cosigned_claim"`
+	LockFundsClaim   sdk.Dec `json:"lock_funds_claim" yaml:"lock_funds_claim"`
+	// ConfirmedClaim is needed to act on the mainchain confirmation of a multisig transaction
+	ConfirmedClaim sdk.Dec `json:"confirmed_claim" yaml:"confirmed_claim"`
+}
+
+// MultisigApproval is the share of the cosigners whose signatures the multisig account requires,
+// to approve a transaction and to remove a cosigner. More than the share has to sign.
+type MultisigApproval struct {
+	MinApproval sdk.Dec `json:"min_approval" yaml:"min_approval"`
+	MinRemoval  sdk.Dec `json:"min_removal" yaml:"min_removal"`
+}
+
+// DefaultMultisigApproval requires a simple majority of the cosigners
+func DefaultMultisigApproval() MultisigApproval {
+	return MultisigApproval{
+		MinApproval: sdk.NewDecWithPrec(5, 1),
+		MinRemoval:  sdk.NewDecWithPrec(5, 1),
+	}
+}
+
+// DefaultColdMultisigApproval requires two thirds of the cosigners
+func DefaultColdMultisigApproval() MultisigApproval {
+	return MultisigApproval{
+		MinApproval: sdk.NewDecWithPrec(66, 2),
+		MinRemoval:  sdk.NewDecWithPrec(66, 2),
+	}
+}
+
+// RequiredSignatures returns how many of the cosigners make more than the share, at least one
+func RequiredSignatures(share sdk.Dec, cosigners int) int {
+	required := int(share.MulInt64(int64(cosigners)).TruncateInt64()) + 1
+	if required > cosigners {
+		required = cosigners
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+// MinApprovalFor returns the min approval of a multisig account with the given number of cosigners
+func (a MultisigApproval) MinApprovalFor(cosigners int) int {
+	return RequiredSignatures(a.MinApproval, cosigners)
+}
+
+// MinRemovalFor returns the min removal of a multisig account with the given number of cosigners
+func (a MultisigApproval) MinRemovalFor(cosigners int) int {
+	return RequiredSignatures(a.MinRemoval, cosigners)
+}
+
+// Stricter returns the stricter share of both approvals, for the min approval and the min removal each
+func (a MultisigApproval) Stricter(other MultisigApproval) MultisigApproval {
+	return MultisigApproval{
+		MinApproval: sdk.MaxDec(a.MinApproval, other.MinApproval),
+		MinRemoval:  sdk.MaxDec(a.MinRemoval, other.MinRemoval),
+	}
+}
+
+// DefaultConsensusNeeded uses the oracle module's default threshold for every claim type
+func DefaultConsensusNeeded() ConsensusNeeded {
+	threshold := sdk.MustNewDecFromStr(fmt.Sprintf("%f", oracle.DefaultConsensusNeeded))
+	return ConsensusNeeded{
+		PegClaim:         threshold,
+		NotCosignedClaim: threshold,
+		LockFundsClaim:   threshold,
+		ConfirmedClaim:   threshold,
+	}
+}
+
+// MinCosignerStakeShare is the share of the total stake a validator needs to become a cosigner
+func MinCosignerStakeShare() sdk.Dec {
+	return sdk.NewDecWithPrec(1, 1)
+}
+
+// DefaultFaultyClaimSlashFraction slashes 1% of the stake for each faulty claim
+func DefaultFaultyClaimSlashFraction() sdk.Dec {
+	return sdk.NewDecWithPrec(1, 2)
 }
 
 // NewParams creates a new Params object
-func NewParams(mainchainMultisigAddress string, cosigners []Cosigner) Params {
+func NewParams(instances []BridgeInstance, consensusNeeded ConsensusNeeded, claimWeighting string, prophecyExpiry int64, faultyClaimSlashFraction sdk.Dec, multisigApproval MultisigApproval, coldMultisigApproval MultisigApproval, hotVaultLimits HotVaultLimits, fees []DenomFee, feeDistribution FeeDistribution, lockFundsCost sdk.Coins, limits []DenomLimit, volumeWindow time.Duration, timeLock TimeLock, addressFilter AddressFilter, unpegBatchWindow int64) Params {
 	return Params{
 		// TODO: Create your Params Type
-		MainchainMultisigAddress: mainchainMultisigAddress,
-		Cosigners:                cosigners,
+		Instances:                instances,
+		ConsensusNeeded:          consensusNeeded,
+		ClaimWeighting:           claimWeighting,
+		ProphecyExpiry:           prophecyExpiry,
+		FaultyClaimSlashFraction: faultyClaimSlashFraction,
+		MultisigApproval:         multisigApproval,
+		ColdMultisigApproval:     coldMultisigApproval,
+		HotVaultLimits:           hotVaultLimits,
+		Fees:                     fees,
+		FeeDistribution:          feeDistribution,
+		LockFundsCost:            lockFundsCost,
+		Limits:                   limits,
+		VolumeWindow:             volumeWindow,
+		TimeLock:                 timeLock,
+		AddressFilter:            addressFilter,
+		UnpegBatchWindow:         unpegBatchWindow,
 	}
 }
 
@@ -61,12 +219,319 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		// TODO: Pair your key with the param
 		// params.NewParamSetPair(KeyParamName, &p.ParamName),
-		params.NewParamSetPair(KeyMainchainMultisigAddress, &p.MainchainMultisigAddress, func(value interface{}) error { return nil }),
-		params.NewParamSetPair(KeyCosigners, &p.Cosigners, func(value interface{}) error { return nil }),
+		params.NewParamSetPair(KeyInstances, &p.Instances, validateInstancesParam),
+		params.NewParamSetPair(KeyConsensusNeeded, &p.ConsensusNeeded, validateConsensusNeeded),
+		params.NewParamSetPair(KeyClaimWeighting, &p.ClaimWeighting, validateClaimWeighting),
+		params.NewParamSetPair(KeyProphecyExpiry, &p.ProphecyExpiry, validateProphecyExpiry),
+		params.NewParamSetPair(KeyFaultyClaimSlashFraction, &p.FaultyClaimSlashFraction, validateFaultyClaimSlashFraction),
+		params.NewParamSetPair(KeyMultisigApproval, &p.MultisigApproval, validateMultisigApproval),
+		params.NewParamSetPair(KeyColdMultisigApproval, &p.ColdMultisigApproval, validateMultisigApproval),
+		params.NewParamSetPair(KeyHotVaultLimits, &p.HotVaultLimits, validateHotVaultLimits),
+		params.NewParamSetPair(KeyFees, &p.Fees, validateFees),
+		params.NewParamSetPair(KeyFeeDistribution, &p.FeeDistribution, validateFeeDistribution),
+		params.NewParamSetPair(KeyLockFundsCost, &p.LockFundsCost, validateLockFundsCost),
+		params.NewParamSetPair(KeyLimits, &p.Limits, validateLimits),
+		params.NewParamSetPair(KeyVolumeWindow, &p.VolumeWindow, validateVolumeWindow),
+		params.NewParamSetPair(KeyTimeLock, &p.TimeLock, validateTimeLock),
+		params.NewParamSetPair(KeyAddressFilter, &p.AddressFilter, validateAddressFilter),
+		params.NewParamSetPair(KeyUnpegBatchWindow, &p.UnpegBatchWindow, validateUnpegBatchWindow),
 	}
 }
 
+// Validate checks that every parameter is valid
+func (p Params) Validate() error {
+	if err := validateInstances(p.Instances); err != nil {
+		return err
+	}
+	if err := validateConsensusNeeded(p.ConsensusNeeded); err != nil {
+		return err
+	}
+	if err := validateClaimWeighting(p.ClaimWeighting); err != nil {
+		return err
+	}
+	if err := validateProphecyExpiry(p.ProphecyExpiry); err != nil {
+		return err
+	}
+	if err := validateFaultyClaimSlashFraction(p.FaultyClaimSlashFraction); err != nil {
+		return err
+	}
+	if err := validateMultisigApproval(p.MultisigApproval); err != nil {
+		return err
+	}
+	if err := validateMultisigApproval(p.ColdMultisigApproval); err != nil {
+		return err
+	}
+	if err := validateColdMultisigApproval(p.MultisigApproval, p.ColdMultisigApproval); err != nil {
+		return err
+	}
+	if err := validateHotVaultLimits(p.HotVaultLimits); err != nil {
+		return err
+	}
+	if err := validateFees(p.Fees); err != nil {
+		return err
+	}
+	if err := validateFeeDistribution(p.FeeDistribution); err != nil {
+		return err
+	}
+	if err := validateLockFundsCost(p.LockFundsCost); err != nil {
+		return err
+	}
+	if err := validateLimits(p.Limits); err != nil {
+		return err
+	}
+	if err := validateVolumeWindow(p.VolumeWindow); err != nil {
+		return err
+	}
+	if err := validateTimeLock(p.TimeLock); err != nil {
+		return err
+	}
+	if err := validateAddressFilter(p.AddressFilter); err != nil {
+		return err
+	}
+	return validateUnpegBatchWindow(p.UnpegBatchWindow)
+}
+
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams("", []Cosigner{})
+	return NewParams([]BridgeInstance{DefaultBridgeInstance()}, DefaultConsensusNeeded(), ClaimWeightingValidators, DefaultProphecyExpiry, DefaultFaultyClaimSlashFraction(), DefaultMultisigApproval(), DefaultColdMultisigApproval(), DefaultHotVaultLimits(), []DenomFee{}, DefaultFeeDistribution(), DefaultLockFundsCost(), []DenomLimit{}, DefaultVolumeWindow, DefaultTimeLock(), DefaultAddressFilter(), DefaultUnpegBatchWindow)
+}
+
+// Validate checks the validator address and the mainchain public key of the cosigner
+func (c Cosigner) Validate() error {
+	if _, err := sdk.ValAddressFromBech32(c.ValidatorAddress); err != nil {
+		return fmt.Errorf("invalid cosigner validator address %s: %w", c.ValidatorAddress, err)
+	}
+	return c.MainchainPublicKey.Validate()
+}
+
+// ApprovalFor returns the multisig approval of the tier of the vault. A parameter change proposal validates
+// each approval on its own and can leave the cold one less strict than the hot one, so a cold vault never
+// gets less than the hot approval.
+func (p Params) ApprovalFor(vault Vault) MultisigApproval {
+	if vault.Cold {
+		return p.ColdMultisigApproval.Stricter(p.MultisigApproval)
+	}
+	return p.MultisigApproval
+}
+
+// GetInstance returns the bridge instance with the given name
+func (p Params) GetInstance(name string) (BridgeInstance, bool) {
+	for _, instance := range p.Instances {
+		if instance.Name == name {
+			return instance, true
+		}
+	}
+	return BridgeInstance{}, false
+}
+
+// SetInstance replaces the bridge instance of the same name
+func (p *Params) SetInstance(instance BridgeInstance) {
+	for i := range p.Instances {
+		if p.Instances[i].Name == instance.Name {
+			p.Instances[i] = instance
+			return
+		}
+	}
+}
+
+func validateInstances(i interface{}) error {
+	v, ok := i.([]BridgeInstance)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	names := make(map[string]bool)
+	// a denom is pegged by a single instance, or burning it on one network would release funds of another
+	denoms := make(map[string]string)
+	for _, instance := range v {
+		if err := instance.Validate(); err != nil {
+			return err
+		}
+		if names[instance.Name] {
+			return fmt.Errorf("duplicate bridge instance: %s", instance.Name)
+		}
+		names[instance.Name] = true
+		for _, mapping := range instance.Denoms {
+			if other, found := denoms[mapping.Denom]; found {
+				return fmt.Errorf("denom %s is pegged by both instance %s and instance %s", mapping.Denom, other, instance.Name)
+			}
+			denoms[mapping.Denom] = instance.Name
+		}
+	}
+
+	return nil
+}
+
+// validateInstancesParam validates the instances as a parameter, which leaves the cosigners out:
+// they are kept in the module store and join or leave only through invitations and removals
+func validateInstancesParam(i interface{}) error {
+	v, ok := i.([]BridgeInstance)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, instance := range v {
+		if len(instance.Cosigners) > 0 {
+			return fmt.Errorf("instance %s: cosigners cannot be changed through the parameters", instance.Name)
+		}
+	}
+	return validateInstances(v)
+}
+
+func validateCosigners(v []Cosigner) error {
+	publicKeys := make(map[MainchainPublicKey]bool)
+	for _, cosigner := range v {
+		if err := cosigner.Validate(); err != nil {
+			return err
+		}
+		if publicKeys[cosigner.MainchainPublicKey] {
+			return fmt.Errorf("duplicate cosigner mainchain public key: %s", cosigner.MainchainPublicKey)
+		}
+		publicKeys[cosigner.MainchainPublicKey] = true
+	}
+
+	return nil
+}
+
+func validateConsensusNeeded(i interface{}) error {
+	v, ok := i.(ConsensusNeeded)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if err := validateThreshold("peg_claim", v.PegClaim); err != nil {
+		return err
+	}
+	if err := validateThreshold("not_cosigned_claim", v.NotCosignedClaim); err != nil {
+		return err
+	}
+	if err := validateThreshold("lock_funds_claim", v.LockFundsClaim); err != nil {
+		return err
+	}
+	return validateThreshold("confirmed_claim", v.ConfirmedClaim)
+}
+
+func validateThreshold(name string, threshold sdk.Dec) error {
+	if threshold.IsNil() {
+		return fmt.Errorf("consensus needed for %s must be set", name)
+	}
+	if !threshold.IsPositive() {
+		return fmt.Errorf("consensus needed for %s must be positive: %s", name, threshold)
+	}
+	if threshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("consensus needed for %s too large: %s", name, threshold)
+	}
+	return nil
+}
+
+func validateClaimWeighting(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	switch v {
+	case ClaimWeightingValidators, ClaimWeightingCosigners:
+		return nil
+	default:
+		return fmt.Errorf("invalid claim weighting: %s", v)
+	}
+}
+
+func validateProphecyExpiry(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("prophecy expiry must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateFaultyClaimSlashFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() {
+		return fmt.Errorf("faulty claim slash fraction must be set")
+	}
+	if v.IsNegative() {
+		return fmt.Errorf("faulty claim slash fraction cannot be negative: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("faulty claim slash fraction too large: %s", v)
+	}
+
+	return nil
+}
+
+func validateMultisigApproval(i interface{}) error {
+	v, ok := i.(MultisigApproval)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if err := validateApprovalShare("min_approval", v.MinApproval); err != nil {
+		return err
+	}
+	return validateApprovalShare("min_removal", v.MinRemoval)
+}
+
+func validateApprovalShare(name string, share sdk.Dec) error {
+	if share.IsNil() {
+		return fmt.Errorf("multisig %s must be set", name)
+	}
+	if share.IsNegative() {
+		return fmt.Errorf("multisig %s cannot be negative: %s", name, share)
+	}
+	if share.GTE(sdk.OneDec()) {
+		return fmt.Errorf("multisig %s must be less than one: %s", name, share)
+	}
+	return nil
+}
+
+// validateColdMultisigApproval checks that cold vaults require at least the approval of hot vaults
+func validateColdMultisigApproval(hot, cold MultisigApproval) error {
+	if cold.MinApproval.LT(hot.MinApproval) {
+		return fmt.Errorf("cold multisig min_approval %s is less strict than the hot one %s", cold.MinApproval, hot.MinApproval)
+	}
+	if cold.MinRemoval.LT(hot.MinRemoval) {
+		return fmt.Errorf("cold multisig min_removal %s is less strict than the hot one %s", cold.MinRemoval, hot.MinRemoval)
+	}
+	return nil
+}
+
+func validateHotVaultLimits(i interface{}) error {
+	v, ok := i.(HotVaultLimits)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, limit := range []struct {
+		name  string
+		coins sdk.Coins
+	}{{"floor", v.Floor}, {"target", v.Target}, {"ceiling", v.Ceiling}} {
+		if !limit.coins.IsValid() {
+			return fmt.Errorf("invalid hot vault %s: %s", limit.name, limit.coins)
+		}
+	}
+	for _, coin := range append(v.Floor, v.Ceiling...) {
+		if !v.Target.AmountOf(coin.Denom).IsPositive() {
+			return fmt.Errorf("hot vault limit of %s without a target", coin.Denom)
+		}
+	}
+	for _, target := range v.Target {
+		if v.Floor.AmountOf(target.Denom).GT(target.Amount) {
+			return fmt.Errorf("hot vault floor of %s above the target %s", target.Denom, target.Amount)
+		}
+		if ceiling := v.Ceiling.AmountOf(target.Denom); ceiling.IsPositive() && ceiling.LT(target.Amount) {
+			return fmt.Errorf("hot vault ceiling of %s below the target %s", target.Denom, target.Amount)
+		}
+	}
+	return nil
 }
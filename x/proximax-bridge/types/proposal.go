@@ -0,0 +1,269 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeChangeMultisigAddress defines the type for a ChangeMultisigAddressProposal
+	ProposalTypeChangeMultisigAddress = "ChangeMultisigAddress"
+	// ProposalTypeAddCosigner defines the type for a AddCosignerProposal
+	ProposalTypeAddCosigner = "AddCosigner"
+	// ProposalTypeRemoveCosigner defines the type for a RemoveCosignerProposal
+	ProposalTypeRemoveCosigner = "RemoveCosigner"
+	// ProposalTypeChangeMultisigApproval defines the type for a ChangeMultisigApprovalProposal
+	ProposalTypeChangeMultisigApproval = "ChangeMultisigApproval"
+)
+
+// Assert the proposals implement govtypes.Content at compile-time
+var (
+	_ govtypes.Content = ChangeMultisigAddressProposal{}
+	_ govtypes.Content = AddCosignerProposal{}
+	_ govtypes.Content = RemoveCosignerProposal{}
+	_ govtypes.Content = ChangeMultisigApprovalProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeChangeMultisigAddress)
+	govtypes.RegisterProposalTypeCodec(ChangeMultisigAddressProposal{}, "proximaxbridge/ChangeMultisigAddressProposal")
+	govtypes.RegisterProposalType(ProposalTypeAddCosigner)
+	govtypes.RegisterProposalTypeCodec(AddCosignerProposal{}, "proximaxbridge/AddCosignerProposal")
+	govtypes.RegisterProposalType(ProposalTypeRemoveCosigner)
+	govtypes.RegisterProposalTypeCodec(RemoveCosignerProposal{}, "proximaxbridge/RemoveCosignerProposal")
+	govtypes.RegisterProposalType(ProposalTypeChangeMultisigApproval)
+	govtypes.RegisterProposalTypeCodec(ChangeMultisigApprovalProposal{}, "proximaxbridge/ChangeMultisigApprovalProposal")
+}
+
+// ChangeMultisigAddressProposal replaces the mainchain multisig address and the tier of a vault of a bridge instance,
+// or adds the vault if the instance has none of that name
+type ChangeMultisigAddressProposal struct {
+	Title                    string           `json:"title" yaml:"title"`
+	Description              string           `json:"description" yaml:"description"`
+	Instance                 string           `json:"instance" yaml:"instance"`
+	Vault                    string           `json:"vault" yaml:"vault"`
+	MainchainMultisigAddress MainchainAddress `json:"mainchain_multisig_address" yaml:"mainchain_multisig_address"`
+	Cold                     bool             `json:"cold" yaml:"cold"`
+}
+
+// NewChangeMultisigAddressProposal creates a new ChangeMultisigAddressProposal instance
+func NewChangeMultisigAddressProposal(title, description, instance, vault string, mainchainMultisigAddress MainchainAddress, cold bool) ChangeMultisigAddressProposal {
+	return ChangeMultisigAddressProposal{title, description, instance, vault, mainchainMultisigAddress, cold}
+}
+
+// GetTitle returns the title of the proposal
+func (p ChangeMultisigAddressProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p ChangeMultisigAddressProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p ChangeMultisigAddressProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p ChangeMultisigAddressProposal) ProposalType() string {
+	return ProposalTypeChangeMultisigAddress
+}
+
+// ValidateBasic runs basic stateless validity checks
+func (p ChangeMultisigAddressProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if err := ValidateInstanceName(p.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := ValidateInstanceName(p.Vault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
+	if err := p.MainchainMultisigAddress.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainAddress, err.Error())
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p ChangeMultisigAddressProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Change Multisig Address Proposal:
+  Title:                      %s
+  Description:                %s
+  Instance:                   %s
+  Vault:                      %s
+  Mainchain Multisig Address: %s
+  Cold:                       %t
+`, p.Title, p.Description, p.Instance, p.Vault, p.MainchainMultisigAddress, p.Cold))
+	return b.String()
+}
+
+// AddCosignerProposal adds a validator to the cosigners of the mainchain multisig of a vault of a bridge instance
+type AddCosignerProposal struct {
+	Title              string             `json:"title" yaml:"title"`
+	Description        string             `json:"description" yaml:"description"`
+	Instance           string             `json:"instance" yaml:"instance"`
+	Vault              string             `json:"vault" yaml:"vault"`
+	ValidatorAddress   sdk.ValAddress     `json:"validator_address" yaml:"validator_address"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+}
+
+// NewAddCosignerProposal creates a new AddCosignerProposal instance
+func NewAddCosignerProposal(title, description, instance, vault string, validatorAddress sdk.ValAddress, mainchainPublicKey MainchainPublicKey) AddCosignerProposal {
+	return AddCosignerProposal{title, description, instance, vault, validatorAddress, mainchainPublicKey}
+}
+
+// GetTitle returns the title of the proposal
+func (p AddCosignerProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p AddCosignerProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p AddCosignerProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p AddCosignerProposal) ProposalType() string { return ProposalTypeAddCosigner }
+
+// ValidateBasic runs basic stateless validity checks
+func (p AddCosignerProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if err := ValidateInstanceName(p.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := ValidateInstanceName(p.Vault); err != nil {
+		return sdkerrors.Wrap(ErrInvalidVault, err.Error())
+	}
+	if p.ValidatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing validator address")
+	}
+	if err := p.MainchainPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p AddCosignerProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Add Cosigner Proposal:
+  Title:                %s
+  Description:          %s
+  Instance:             %s
+  Vault:                %s
+  Validator Address:    %s
+  Mainchain Public Key: %s
+`, p.Title, p.Description, p.Instance, p.Vault, p.ValidatorAddress, p.MainchainPublicKey))
+	return b.String()
+}
+
+// RemoveCosignerProposal removes a cosigner, identified by its mainchain public key, from the mainchain multisig of a bridge instance
+type RemoveCosignerProposal struct {
+	Title              string             `json:"title" yaml:"title"`
+	Description        string             `json:"description" yaml:"description"`
+	Instance           string             `json:"instance" yaml:"instance"`
+	MainchainPublicKey MainchainPublicKey `json:"mainchain_public_key" yaml:"mainchain_public_key"`
+}
+
+// NewRemoveCosignerProposal creates a new RemoveCosignerProposal instance
+func NewRemoveCosignerProposal(title, description, instance string, mainchainPublicKey MainchainPublicKey) RemoveCosignerProposal {
+	return RemoveCosignerProposal{title, description, instance, mainchainPublicKey}
+}
+
+// GetTitle returns the title of the proposal
+func (p RemoveCosignerProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p RemoveCosignerProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p RemoveCosignerProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p RemoveCosignerProposal) ProposalType() string { return ProposalTypeRemoveCosigner }
+
+// ValidateBasic runs basic stateless validity checks
+func (p RemoveCosignerProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if err := ValidateInstanceName(p.Instance); err != nil {
+		return sdkerrors.Wrap(ErrInvalidInstance, err.Error())
+	}
+	if err := p.MainchainPublicKey.Validate(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidMainchainPubKey, err.Error())
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p RemoveCosignerProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Remove Cosigner Proposal:
+  Title:                %s
+  Description:          %s
+  Instance:             %s
+  Mainchain Public Key: %s
+`, p.Title, p.Description, p.Instance, p.MainchainPublicKey))
+	return b.String()
+}
+
+// ChangeMultisigApprovalProposal changes the approval policy of the hot or the cold vaults
+// and re-balances the thresholds of the multisig of every vault of the tier
+type ChangeMultisigApprovalProposal struct {
+	Title            string           `json:"title" yaml:"title"`
+	Description      string           `json:"description" yaml:"description"`
+	MultisigApproval MultisigApproval `json:"multisig_approval" yaml:"multisig_approval"`
+	Cold             bool             `json:"cold" yaml:"cold"`
+}
+
+// NewChangeMultisigApprovalProposal creates a new ChangeMultisigApprovalProposal instance
+func NewChangeMultisigApprovalProposal(title, description string, multisigApproval MultisigApproval, cold bool) ChangeMultisigApprovalProposal {
+	return ChangeMultisigApprovalProposal{title, description, multisigApproval, cold}
+}
+
+// GetTitle returns the title of the proposal
+func (p ChangeMultisigApprovalProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p ChangeMultisigApprovalProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p ChangeMultisigApprovalProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p ChangeMultisigApprovalProposal) ProposalType() string {
+	return ProposalTypeChangeMultisigApproval
+}
+
+// ValidateBasic runs basic stateless validity checks
+func (p ChangeMultisigApprovalProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if err := validateMultisigApproval(p.MultisigApproval); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p ChangeMultisigApprovalProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Change Multisig Approval Proposal:
+  Title:        %s
+  Description:  %s
+  Min Approval: %s
+  Min Removal:  %s
+  Cold:         %t
+`, p.Title, p.Description, p.MultisigApproval.MinApproval, p.MultisigApproval.MinRemoval, p.Cold))
+	return b.String()
+}
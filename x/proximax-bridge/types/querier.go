@@ -1,12 +1,225 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/peggy/x/oracle"
+)
+
 // Query endpoints supported by the proximax-bridge querier
 const (
 	//TODO: Describe query parameters, update <action> with your query
 	// Query<Action>    = "<action>"
-	QueryParams = "parameters"
+	QueryParams       = "parameters"
+	QueryFaultyClaims = "faulty_claims"
+	QueryProphecy     = "prophecy"
+
+	QueryCosignerSetChanges = "cosigner_set_changes"
+	QueryCosignerSet        = "cosigner_set"
+
+	QueryVaults       = "vaults"
+	QueryDepositVault = "deposit_vault"
+
+	QueryFeeEstimate = "fee_estimate"
+	QueryFeePool     = "fee_pool"
+	QueryRewards     = "rewards"
+
+	QueryLockFundsReimbursements = "lock_funds_reimbursements"
+
+	QueryQueuedUnpeg  = "queued_unpeg"
+	QueryQueuedUnpegs = "queued_unpegs"
+
+	QueryTimeLockedUnpegs = "time_locked_unpegs"
+
+	QueryUnpegBatch   = "unpeg_batch"
+	QueryUnpegBatches = "unpeg_batches"
 )
 
+// QueryCosignerSetChangesParams defines the params for querying the cosigner set change log,
+// of all instances if Instance is empty
+type QueryCosignerSetChangesParams struct {
+	Instance string `json:"instance" yaml:"instance"`
+}
+
+// NewQueryCosignerSetChangesParams creates a new QueryCosignerSetChangesParams instance
+func NewQueryCosignerSetChangesParams(instance string) QueryCosignerSetChangesParams {
+	return QueryCosignerSetChangesParams{Instance: instance}
+}
+
+// QueryCosignerSetParams defines the params for querying the cosigner set of an instance as of a block height
+type QueryCosignerSetParams struct {
+	Instance string `json:"instance" yaml:"instance"`
+	Height   int64  `json:"height" yaml:"height"`
+}
+
+// NewQueryCosignerSetParams creates a new QueryCosignerSetParams instance
+func NewQueryCosignerSetParams(instance string, height int64) QueryCosignerSetParams {
+	return QueryCosignerSetParams{Instance: instance, Height: height}
+}
+
+// QueryVaultsParams defines the params for querying the vaults of an instance
+type QueryVaultsParams struct {
+	Instance string `json:"instance" yaml:"instance"`
+}
+
+// NewQueryVaultsParams creates a new QueryVaultsParams instance
+func NewQueryVaultsParams(instance string) QueryVaultsParams {
+	return QueryVaultsParams{Instance: instance}
+}
+
+// QueryDepositVaultParams defines the params for querying the vault deposits of a denom are routed to
+type QueryDepositVaultParams struct {
+	Instance string `json:"instance" yaml:"instance"`
+	Denom    string `json:"denom" yaml:"denom"`
+}
+
+// NewQueryDepositVaultParams creates a new QueryDepositVaultParams instance
+func NewQueryDepositVaultParams(instance, denom string) QueryDepositVaultParams {
+	return QueryDepositVaultParams{Instance: instance, Denom: denom}
+}
+
+// QueryFeeEstimateParams defines the params for estimating the bridge fee on an amount bridged in a direction
+type QueryFeeEstimateParams struct {
+	Direction string    `json:"direction" yaml:"direction"`
+	Amount    sdk.Coins `json:"amount" yaml:"amount"`
+}
+
+// NewQueryFeeEstimateParams creates a new QueryFeeEstimateParams instance
+func NewQueryFeeEstimateParams(direction string, amount sdk.Coins) QueryFeeEstimateParams {
+	return QueryFeeEstimateParams{Direction: direction, Amount: amount}
+}
+
+// QueryResFeeEstimate is the bridge fee on an amount and the net amount the recipient receives
+type QueryResFeeEstimate struct {
+	Direction string    `json:"direction" yaml:"direction"`
+	Amount    sdk.Coins `json:"amount" yaml:"amount"`
+	Fee       sdk.Coins `json:"fee" yaml:"fee"`
+	Net       sdk.Coins `json:"net" yaml:"net"`
+}
+
+// QueryResRewards is the fee pool, the height it is paid out next at
+// and the points the validators earned since the last payout
+type QueryResRewards struct {
+	FeePool          sdk.Coins      `json:"fee_pool" yaml:"fee_pool"`
+	NextPayoutHeight int64          `json:"next_payout_height" yaml:"next_payout_height"`
+	Points           []RewardPoints `json:"points" yaml:"points"`
+}
+
+// QueryResVaults describes the vaults of an instance with their cosigners and balances,
+// and the sweeps asked for which the cosigners have not announced yet.
+// Total is the sum of the balances and of the pending transfers between vaults, and
+// Supply is the amount of the denoms pegged by the instance on the zone, which Total backs.
+type QueryResVaults struct {
+	Instance         string          `json:"instance" yaml:"instance"`
+	Vaults           []QueryResVault `json:"vaults" yaml:"vaults"`
+	PendingTransfers []VaultTransfer `json:"pending_transfers" yaml:"pending_transfers"`
+	PendingSweeps    []SweepRequest  `json:"pending_sweeps" yaml:"pending_sweeps"`
+	Total            sdk.Coins       `json:"total" yaml:"total"`
+	Supply           sdk.Coins       `json:"supply" yaml:"supply"`
+}
+
+// QueryResVault describes a vault with its cosigners and balance
+type QueryResVault struct {
+	Vault     Vault      `json:"vault" yaml:"vault"`
+	Cosigners []Cosigner `json:"cosigners" yaml:"cosigners"`
+	Balance   sdk.Coins  `json:"balance" yaml:"balance"`
+}
+
+// QueryQueuedUnpegParams defines the params for querying an unpeg of the withdrawal queue
+type QueryQueuedUnpegParams struct {
+	ID uint64 `json:"id" yaml:"id"`
+}
+
+// NewQueryQueuedUnpegParams creates a new QueryQueuedUnpegParams instance
+func NewQueryQueuedUnpegParams(id uint64) QueryQueuedUnpegParams {
+	return QueryQueuedUnpegParams{ID: id}
+}
+
+// QueryQueuedUnpegsParams defines the params for querying the withdrawal queue,
+// the whole queue if Sender is empty
+type QueryQueuedUnpegsParams struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+}
+
+// NewQueryQueuedUnpegsParams creates a new QueryQueuedUnpegsParams instance
+func NewQueryQueuedUnpegsParams(sender sdk.AccAddress) QueryQueuedUnpegsParams {
+	return QueryQueuedUnpegsParams{Sender: sender}
+}
+
+// QueryTimeLockedUnpegsParams defines the params for querying the unpegs held back by the time lock,
+// all of them if Sender is empty
+type QueryTimeLockedUnpegsParams struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+}
+
+// NewQueryTimeLockedUnpegsParams creates a new QueryTimeLockedUnpegsParams instance
+func NewQueryTimeLockedUnpegsParams(sender sdk.AccAddress) QueryTimeLockedUnpegsParams {
+	return QueryTimeLockedUnpegsParams{Sender: sender}
+}
+
+// QueryUnpegBatchParams defines the params for querying an unpeg batch
+type QueryUnpegBatchParams struct {
+	ID uint64 `json:"id" yaml:"id"`
+}
+
+// NewQueryUnpegBatchParams creates a new QueryUnpegBatchParams instance
+func NewQueryUnpegBatchParams(id uint64) QueryUnpegBatchParams {
+	return QueryUnpegBatchParams{ID: id}
+}
+
+// QueryUnpegBatchesParams defines the params for querying the unpeg batches,
+// of every status if Status is empty
+type QueryUnpegBatchesParams struct {
+	Status string `json:"status" yaml:"status"`
+}
+
+// NewQueryUnpegBatchesParams creates a new QueryUnpegBatchesParams instance
+func NewQueryUnpegBatchesParams(status string) QueryUnpegBatchesParams {
+	return QueryUnpegBatchesParams{Status: status}
+}
+
+// QueryFaultyClaimsParams defines the params for querying faulty claims,
+// of all validators if ValidatorAddress is empty
+type QueryFaultyClaimsParams struct {
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+}
+
+// NewQueryFaultyClaimsParams creates a new QueryFaultyClaimsParams instance
+func NewQueryFaultyClaimsParams(validatorAddress sdk.ValAddress) QueryFaultyClaimsParams {
+	return QueryFaultyClaimsParams{ValidatorAddress: validatorAddress}
+}
+
+// QueryProphecyParams defines the params for querying a bridge prophecy
+type QueryProphecyParams struct {
+	ID string `json:"id" yaml:"id"`
+}
+
+// NewQueryProphecyParams creates a new QueryProphecyParams instance
+func NewQueryProphecyParams(id string) QueryProphecyParams {
+	return QueryProphecyParams{ID: id}
+}
+
+// QueryResProphecy describes a bridge prophecy, what each validator claimed on it
+// and how much of the voting power agrees on the leading claim.
+// ClaimType, CreatedHeight and ConsensusNeeded are only known while the prophecy is open.
+type QueryResProphecy struct {
+	ID                string          `json:"id" yaml:"id"`
+	ClaimType         string          `json:"claim_type" yaml:"claim_type"`
+	CreatedHeight     int64           `json:"created_height" yaml:"created_height"`
+	Status            oracle.Status   `json:"status" yaml:"status"`
+	Claims            []ProphecyClaim `json:"claims" yaml:"claims"`
+	HighestClaimPower int64           `json:"highest_claim_power" yaml:"highest_claim_power"`
+	TotalPower        int64           `json:"total_power" yaml:"total_power"`
+	ConsensusReached  sdk.Dec         `json:"consensus_reached" yaml:"consensus_reached"`
+	ConsensusNeeded   sdk.Dec         `json:"consensus_needed" yaml:"consensus_needed"`
+}
+
+// ProphecyClaim is the claim a validator made on a prophecy and the voting power it is counted with
+type ProphecyClaim struct {
+	ValidatorAddress string `json:"validator_address" yaml:"validator_address"`
+	Claim            string `json:"claim" yaml:"claim"`
+	Power            int64  `json:"power" yaml:"power"`
+}
+
 /*
 Below you will be able how to set your own queries:
 
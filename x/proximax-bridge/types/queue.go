@@ -0,0 +1,141 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueuedUnpeg is an unpeg held back in the withdrawal queue, whose coins the module holds until it is released
+type QueuedUnpeg struct {
+	ID     uint64   `json:"id" yaml:"id"`
+	Height int64    `json:"height" yaml:"height"`
+	Unpeg  MsgUnpeg `json:"unpeg" yaml:"unpeg"`
+}
+
+// QueuedPeg is a peg which reached consensus over the volume cap, minted once the volume caps allow
+type QueuedPeg struct {
+	ID     uint64      `json:"id" yaml:"id"`
+	Height int64       `json:"height" yaml:"height"`
+	Peg    MsgPegClaim `json:"peg" yaml:"peg"`
+}
+
+// PendingUnpeg is an unpeg paid out of a vault on its own, waiting for its first cosigner to announce the aggregate.
+// Amount and Recipients are what the vault pays out.
+type PendingUnpeg struct {
+	ID                   uint64           `json:"id" yaml:"id"`
+	Instance             string           `json:"instance" yaml:"instance"`
+	Vault                string           `json:"vault" yaml:"vault"`
+	Address              sdk.AccAddress   `json:"address" yaml:"address"`
+	Amount               sdk.Coins        `json:"amount" yaml:"amount"`
+	Recipients           []UnpegRecipient `json:"recipients" yaml:"recipients"`
+	FirstCosignerAddress sdk.ValAddress   `json:"first_cosigner_address" yaml:"first_cosigner_address"`
+}
+
+// IsAnnouncedBy returns true when the recorded announcement pays out this unpeg and comes from its first cosigner
+func (u PendingUnpeg) IsAnnouncedBy(msg MsgRecordUnpeg) bool {
+	if u.Instance != msg.Instance || u.Vault != msg.Vault || !u.Address.Equals(msg.Address) || !u.FirstCosignerAddress.Equals(msg.ValidatorAddress) {
+		return false
+	}
+	if !sameCoins(u.Amount, msg.Amount) || len(u.Recipients) != len(msg.Recipients) {
+		return false
+	}
+	for i, recipient := range u.Recipients {
+		if recipient.MainchainAddress != msg.Recipients[i].MainchainAddress || !sameCoins(recipient.Amount, msg.Recipients[i].Amount) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCoins compares coins without panicking on different denominations
+func sameCoins(a, b sdk.Coins) bool {
+	return a.IsAllGTE(b) && b.IsAllGTE(a)
+}
+
+// QueuePosition is the place of an unpeg in the withdrawal queue, counting from 1,
+// and the block time from which it is expected to be released, as far as the limits and the queue stay as they are
+type QueuePosition struct {
+	QueuedUnpeg QueuedUnpeg `json:"queued_unpeg" yaml:"queued_unpeg"`
+	Position    int64       `json:"position" yaml:"position"`
+	ETA         time.Time   `json:"eta" yaml:"eta"`
+}
+
+// validatePendingUnpegs reports unpegs of unknown vaults, duplicate ids and malformed unpegs
+func validatePendingUnpegs(unpegs []PendingUnpeg, instances []BridgeInstance, report func(string, error)) {
+	ids := make(map[uint64]int)
+	for i, unpeg := range unpegs {
+		field := fmt.Sprintf("pending_unpegs[%d]", i)
+		if j, ok := ids[unpeg.ID]; ok {
+			report(field+".id", fmt.Errorf("duplicate of pending_unpegs[%d]: %d", j, unpeg.ID))
+		} else {
+			ids[unpeg.ID] = i
+		}
+		if err := validateVaultOf(unpeg.Instance, unpeg.Vault, instances); err != nil {
+			report(field+".vault", err)
+		}
+		if unpeg.Address.Empty() {
+			report(field+".address", fmt.Errorf("address cannot be empty"))
+		}
+		if unpeg.FirstCosignerAddress.Empty() {
+			report(field+".first_cosigner_address", fmt.Errorf("first cosigner must be set"))
+		}
+		if !unpeg.Amount.IsValid() || unpeg.Amount.Empty() {
+			report(field+".amount", fmt.Errorf("invalid amount: %s", unpeg.Amount))
+		} else if err := validateRecipients(unpeg.Recipients, unpeg.Amount); err != nil {
+			report(field+".recipients", err)
+		}
+	}
+}
+
+// validateQueuedPegs reports malformed pegs, unknown instances and duplicate ids
+func validateQueuedPegs(pegs []QueuedPeg, instances []BridgeInstance, report func(string, error)) {
+	names := make(map[string]bool)
+	for _, instance := range instances {
+		names[instance.Name] = true
+	}
+	ids := make(map[uint64]int)
+	for i, queued := range pegs {
+		field := fmt.Sprintf("queued_pegs[%d]", i)
+		if j, ok := ids[queued.ID]; ok {
+			report(field+".id", fmt.Errorf("duplicate of queued_pegs[%d]: %d", j, queued.ID))
+		} else {
+			ids[queued.ID] = i
+		}
+		if err := queued.Peg.ValidateBasic(); err != nil {
+			report(field+".peg", err)
+			continue
+		}
+		if !queued.Peg.Amount.IsValid() || queued.Peg.Amount.Empty() {
+			report(field+".peg.amount", fmt.Errorf("invalid amount: %s", queued.Peg.Amount))
+		}
+		if !names[queued.Peg.Instance] {
+			report(field+".peg.instance", fmt.Errorf("unknown instance %q", queued.Peg.Instance))
+		}
+	}
+}
+
+// validateQueuedUnpegs reports malformed unpegs, unknown instances and duplicate ids
+func validateQueuedUnpegs(unpegs []QueuedUnpeg, instances []BridgeInstance, report func(string, error)) {
+	names := make(map[string]bool)
+	for _, instance := range instances {
+		names[instance.Name] = true
+	}
+	ids := make(map[uint64]int)
+	for i, queued := range unpegs {
+		field := fmt.Sprintf("queued_unpegs[%d]", i)
+		if j, ok := ids[queued.ID]; ok {
+			report(field+".id", fmt.Errorf("duplicate of queued_unpegs[%d]: %d", j, queued.ID))
+		} else {
+			ids[queued.ID] = i
+		}
+		if err := queued.Unpeg.ValidateBasic(); err != nil {
+			report(field+".unpeg", err)
+			continue
+		}
+		if !names[queued.Unpeg.Instance] {
+			report(field+".unpeg.instance", fmt.Errorf("unknown instance %q", queued.Unpeg.Instance))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxUnpegRecipients is the most ProximaX addresses a single unpeg pays out to, one inner transfer each
+const MaxUnpegRecipients = 100
+
+// UnpegRecipient is a ProximaX address an unpeg pays out to and the coins it receives
+type UnpegRecipient struct {
+	MainchainAddress MainchainAddress `json:"mainchain_address" yaml:"mainchain_address"`
+	Amount           sdk.Coins        `json:"amount" yaml:"amount"`
+}
+
+// NewUnpegRecipient creates a new UnpegRecipient object
+func NewUnpegRecipient(mainchainAddress MainchainAddress, amount sdk.Coins) UnpegRecipient {
+	return UnpegRecipient{
+		MainchainAddress: mainchainAddress,
+		Amount:           amount,
+	}
+}
+
+func (r UnpegRecipient) String() string {
+	return fmt.Sprintf("%s:%s", r.MainchainAddress, r.Amount)
+}
+
+// GetRecipients returns the ProximaX addresses the unpeg pays out to,
+// which is the whole amount to the mainchain address unless the recipients are given
+func (msg MsgUnpeg) GetRecipients() []UnpegRecipient {
+	if len(msg.Recipients) == 0 {
+		return []UnpegRecipient{NewUnpegRecipient(msg.MainchainAddress, msg.Amount)}
+	}
+	return msg.Recipients
+}
+
+// SumRecipients returns the coins paid out to all the recipients
+func SumRecipients(recipients []UnpegRecipient) sdk.Coins {
+	sum := sdk.NewCoins()
+	for _, recipient := range recipients {
+		sum = sum.Add(recipient.Amount...)
+	}
+	return sum
+}
+
+// RecipientsAttribute encodes the recipients as the value of an event attribute
+func RecipientsAttribute(recipients []UnpegRecipient) string {
+	bz, err := json.Marshal(recipients)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+// ParseRecipientsAttribute decodes the recipients from the value of an event attribute
+func ParseRecipientsAttribute(value string) ([]UnpegRecipient, error) {
+	var recipients []UnpegRecipient
+	if err := json.Unmarshal([]byte(value), &recipients); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// validateRecipients checks that each recipient is a distinct valid address receiving valid coins, which add up to the amount
+func validateRecipients(recipients []UnpegRecipient, amount sdk.Coins) error {
+	if len(recipients) > MaxUnpegRecipients {
+		return fmt.Errorf("an unpeg pays out to at most %d recipients: %d", MaxUnpegRecipients, len(recipients))
+	}
+	addresses := make(map[MainchainAddress]bool)
+	for _, recipient := range recipients {
+		if err := recipient.MainchainAddress.Validate(); err != nil {
+			return err
+		}
+		if addresses[recipient.MainchainAddress] {
+			return fmt.Errorf("duplicate recipient %s", recipient.MainchainAddress)
+		}
+		addresses[recipient.MainchainAddress] = true
+		if !recipient.Amount.IsValid() || recipient.Amount.Empty() {
+			return fmt.Errorf("invalid amount for recipient %s: %s", recipient.MainchainAddress, recipient.Amount)
+		}
+	}
+	// IsEqual panics on different denoms
+	if sum := SumRecipients(recipients); !sum.IsAllGTE(amount) || !amount.IsAllGTE(sum) {
+		return fmt.Errorf("recipients receive %s, not the amount %s", sum, amount)
+	}
+	return nil
+}
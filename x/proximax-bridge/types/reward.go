@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultFeeDistributionPeriod is about ten minutes with 6 second blocks
+const DefaultFeeDistributionPeriod int64 = 100
+
+// FeeDistribution is how the fee pool is paid out to the validators who took part in the bridge.
+// Every period the pool is split between them pro rata to the points they earned since the last payout,
+// each initiated unpeg, cosignature and peg claim matching consensus earning its weight in points.
+type FeeDistribution struct {
+	Period    int64   `json:"period" yaml:"period"`
+	Initiator sdk.Dec `json:"initiator" yaml:"initiator"`
+	Cosigner  sdk.Dec `json:"cosigner" yaml:"cosigner"`
+	Claimant  sdk.Dec `json:"claimant" yaml:"claimant"`
+}
+
+// RewardPoints are the points a validator earned since the last payout of the fee pool
+type RewardPoints struct {
+	Validator sdk.ValAddress `json:"validator" yaml:"validator"`
+	Points    sdk.Dec        `json:"points" yaml:"points"`
+}
+
+// NewFeeDistribution creates a new FeeDistribution object
+func NewFeeDistribution(period int64, initiator, cosigner, claimant sdk.Dec) FeeDistribution {
+	return FeeDistribution{
+		Period:    period,
+		Initiator: initiator,
+		Cosigner:  cosigner,
+		Claimant:  claimant,
+	}
+}
+
+// DefaultFeeDistribution weighs initiating an unpeg, which also announces the aggregate, twice a cosignature or a claim
+func DefaultFeeDistribution() FeeDistribution {
+	return NewFeeDistribution(DefaultFeeDistributionPeriod, sdk.NewDec(2), sdk.OneDec(), sdk.OneDec())
+}
+
+// IsPayoutHeight tells whether the fee pool is paid out at the end of the block at the height
+func (d FeeDistribution) IsPayoutHeight(height int64) bool {
+	return height%d.Period == 0
+}
+
+func validateFeeDistribution(i interface{}) error {
+	v, ok := i.(FeeDistribution)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.Period <= 0 {
+		return fmt.Errorf("fee distribution period must be positive: %d", v.Period)
+	}
+	weights := map[string]sdk.Dec{"initiator": v.Initiator, "cosigner": v.Cosigner, "claimant": v.Claimant}
+	for _, name := range []string{"initiator", "cosigner", "claimant"} {
+		weight := weights[name]
+		if weight.IsNil() {
+			return fmt.Errorf("%s weight must be set", name)
+		}
+		if weight.IsNegative() {
+			return fmt.Errorf("%s weight cannot be negative: %s", name, weight)
+		}
+	}
+	return nil
+}
+
+// validateRewardPoints reports malformed and duplicate points
+func validateRewardPoints(points []RewardPoints, report func(string, error)) {
+	validators := make(map[string]int)
+	for i, entry := range points {
+		field := fmt.Sprintf("reward_points[%d]", i)
+		if entry.Validator.Empty() {
+			report(field+".validator", fmt.Errorf("validator must be set"))
+		} else if j, ok := validators[entry.Validator.String()]; ok {
+			report(field+".validator", fmt.Errorf("duplicate of reward_points[%d]: %s", j, entry.Validator))
+		} else {
+			validators[entry.Validator.String()] = i
+		}
+		if entry.Points.IsNil() || !entry.Points.IsPositive() {
+			report(field+".points", fmt.Errorf("points must be positive: %s", entry.Points))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultTimeLockDelay is about an hour with 6 second blocks
+const DefaultTimeLockDelay int64 = 600
+
+// TimeLock holds unpegs above the threshold back for a delay before they join the withdrawal queue,
+// during which any of the guardians can veto them. An empty threshold locks no unpeg.
+type TimeLock struct {
+	Threshold sdk.Coins        `json:"threshold" yaml:"threshold"`
+	Delay     int64            `json:"delay" yaml:"delay"`
+	Guardians []sdk.AccAddress `json:"guardians" yaml:"guardians"`
+}
+
+// TimeLockedUnpeg is an unpeg held back by the time lock until the unlock height, whose coins the module holds
+type TimeLockedUnpeg struct {
+	ID           uint64   `json:"id" yaml:"id"`
+	Height       int64    `json:"height" yaml:"height"`
+	UnlockHeight int64    `json:"unlock_height" yaml:"unlock_height"`
+	Unpeg        MsgUnpeg `json:"unpeg" yaml:"unpeg"`
+}
+
+// NewTimeLock creates a new TimeLock object
+func NewTimeLock(threshold sdk.Coins, delay int64, guardians []sdk.AccAddress) TimeLock {
+	return TimeLock{
+		Threshold: threshold,
+		Delay:     delay,
+		Guardians: guardians,
+	}
+}
+
+// DefaultTimeLock locks no unpeg until governance sets a threshold and the guardians
+func DefaultTimeLock() TimeLock {
+	return NewTimeLock(sdk.Coins{}, DefaultTimeLockDelay, []sdk.AccAddress{})
+}
+
+// Applies tells whether an amount is above the threshold in any of its denoms
+func (t TimeLock) Applies(amount sdk.Coins) bool {
+	for _, coin := range t.Threshold {
+		if amount.AmountOf(coin.Denom).GT(coin.Amount) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGuardian tells whether an account is one of the guardians
+func (t TimeLock) IsGuardian(address sdk.AccAddress) bool {
+	for _, guardian := range t.Guardians {
+		if guardian.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateTimeLock(i interface{}) error {
+	v, ok := i.(TimeLock)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.Threshold.IsValid() {
+		return fmt.Errorf("invalid time lock threshold: %s", v.Threshold)
+	}
+	if v.Delay <= 0 {
+		return fmt.Errorf("time lock delay must be positive: %d", v.Delay)
+	}
+	guardians := make(map[string]bool)
+	for _, guardian := range v.Guardians {
+		if guardian.Empty() {
+			return fmt.Errorf("guardian address cannot be empty")
+		}
+		if guardians[guardian.String()] {
+			return fmt.Errorf("duplicate guardian %s", guardian)
+		}
+		guardians[guardian.String()] = true
+	}
+	return nil
+}
+
+// validateTimeLockedUnpegs reports malformed unpegs, unknown instances
+// and ids already taken by the withdrawal queue or another time-locked unpeg
+func validateTimeLockedUnpegs(unpegs []TimeLockedUnpeg, queued []QueuedUnpeg, instances []BridgeInstance, report func(string, error)) {
+	names := make(map[string]bool)
+	for _, instance := range instances {
+		names[instance.Name] = true
+	}
+	ids := make(map[uint64]string)
+	for i, unpeg := range queued {
+		ids[unpeg.ID] = fmt.Sprintf("queued_unpegs[%d]", i)
+	}
+	for i, locked := range unpegs {
+		field := fmt.Sprintf("time_locked_unpegs[%d]", i)
+		if other, ok := ids[locked.ID]; ok {
+			report(field+".id", fmt.Errorf("duplicate of %s: %d", other, locked.ID))
+		} else {
+			ids[locked.ID] = field
+		}
+		if locked.UnlockHeight < locked.Height {
+			report(field+".unlock_height", fmt.Errorf("unlock height %d before height %d", locked.UnlockHeight, locked.Height))
+		}
+		if err := locked.Unpeg.ValidateBasic(); err != nil {
+			report(field+".unpeg", err)
+			continue
+		}
+		if !names[locked.Unpeg.Instance] {
+			report(field+".unpeg.instance", fmt.Errorf("unknown instance %q", locked.Unpeg.Instance))
+		}
+	}
+}
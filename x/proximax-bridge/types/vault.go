@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Vault is a ProximaX multisig account holding part of the reserves of a bridge instance.
+// ProximaX caps the cosigners of a multisig, so the cosigners of an instance are split among its vaults.
+// Hot vaults take deposits and pay unpegs, cold vaults hold the reserves beyond the hot vault limits
+// behind a stricter multisig approval and are only moved by sweeps.
+type Vault struct {
+	Name                     string           `json:"name" yaml:"name"`
+	MainchainMultisigAddress MainchainAddress `json:"mainchain_multisig_address" yaml:"mainchain_multisig_address"`
+	Cold                     bool             `json:"cold" yaml:"cold"`
+}
+
+// NewVault creates a new Vault object
+func NewVault(name string, mainchainMultisigAddress MainchainAddress, cold bool) Vault {
+	return Vault{
+		Name:                     name,
+		MainchainMultisigAddress: mainchainMultisigAddress,
+		Cold:                     cold,
+	}
+}
+
+// ValidateFor checks the name and the multisig address of the vault on the network of an instance
+func (v Vault) ValidateFor(networkType MainchainNetworkType) error {
+	if err := ValidateInstanceName(v.Name); err != nil {
+		return fmt.Errorf("invalid vault name: %w", err)
+	}
+	if err := v.MainchainMultisigAddress.ValidateFor(networkType); err != nil {
+		return fmt.Errorf("vault %s: %w", v.Name, err)
+	}
+	return nil
+}
+
+// VaultBalance is the amount of the pegged denoms a vault holds on the mainchain
+type VaultBalance struct {
+	Instance string    `json:"instance" yaml:"instance"`
+	Vault    string    `json:"vault" yaml:"vault"`
+	Balance  sdk.Coins `json:"balance" yaml:"balance"`
+}
+
+// VaultTransfer is a rebalancing transfer between two vaults of an instance, announced on the mainchain
+// and waiting for the cosignatures of the source vault. The amount is deducted from the source vault
+// until the transfer is confirmed, or refunded when it is not cosigned.
+type VaultTransfer struct {
+	Instance        string          `json:"instance" yaml:"instance"`
+	MainchainTxHash MainchainTxHash `json:"mainchain_tx_hash" yaml:"mainchain_tx_hash"`
+	FromVault       string          `json:"from_vault" yaml:"from_vault"`
+	ToVault         string          `json:"to_vault" yaml:"to_vault"`
+	Amount          sdk.Coins       `json:"amount" yaml:"amount"`
+	// Sweep is true for a transfer the zone proposed to keep a hot vault within its limits
+	Sweep bool `json:"sweep" yaml:"sweep"`
+}
+
+// HotVaultLimits bound the balance of every hot vault, per denom. A hot vault holding more than the ceiling
+// is swept down to the target into a cold vault, one holding less than the floor is refilled up to the target.
+// Denoms without a target are never swept, and without a ceiling never swept into a cold vault.
+type HotVaultLimits struct {
+	Floor   sdk.Coins `json:"floor" yaml:"floor"`
+	Target  sdk.Coins `json:"target" yaml:"target"`
+	Ceiling sdk.Coins `json:"ceiling" yaml:"ceiling"`
+}
+
+// DefaultHotVaultLimits sweeps nothing
+func DefaultHotVaultLimits() HotVaultLimits {
+	return HotVaultLimits{
+		Floor:   sdk.Coins{},
+		Target:  sdk.Coins{},
+		Ceiling: sdk.Coins{},
+	}
+}
+
+// SweepRequest is a sweep between a hot and a cold vault the zone asked the cosigners of the source vault for.
+// It blocks further sweeps of the vault until the cosigners record the aggregate on the mainchain, or it expires.
+type SweepRequest struct {
+	Instance  string    `json:"instance" yaml:"instance"`
+	FromVault string    `json:"from_vault" yaml:"from_vault"`
+	ToVault   string    `json:"to_vault" yaml:"to_vault"`
+	Amount    sdk.Coins `json:"amount" yaml:"amount"`
+	Height    int64     `json:"height" yaml:"height"`
+}